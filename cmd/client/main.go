@@ -1,10 +1,189 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
 
-type Ops func()
+	"github.com/rodrigues-daniel/data-platform/internal/client"
+	"github.com/rodrigues-daniel/data-platform/internal/gitops"
+)
+
+// Ops executa um subcomando da CLI
+type Ops func(ctx context.Context, c *client.Client, args []string) error
+
+var commands = map[string]Ops{
+	"backup":           backupCmd,
+	"restore":          restoreCmd,
+	"import-confluent": importConfluentCmd,
+	"sync":             syncCmd,
+}
 
 func main() {
+	url := flag.String("url", getEnv("SCHEMA_REGISTRY_URL", "http://localhost:8080"), "URL do Schema Registry")
+	apiKey := flag.String("api-key", getEnv("SCHEMA_REGISTRY_API_KEY", ""), "API key para autenticação")
+	token := flag.String("token", getEnv("SCHEMA_REGISTRY_TOKEN", ""), "bearer token JWT para autenticação")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	op, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "comando desconhecido: %s\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	c := client.NewClient(*url)
+	c.SetCredentials(*apiKey, *token)
+
+	if err := op(context.Background(), c, flag.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "erro: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// backupCmd exporta o registry para um arquivo NDJSON (ou stdout)
+func backupCmd(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	output := fs.String("o", "-", "arquivo de saída (- para stdout)")
+	fs.Parse(args)
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return c.Backup(ctx, w)
+}
+
+// restoreCmd restaura um backup NDJSON em um registry vazio
+func restoreCmd(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	input := fs.String("i", "-", "arquivo de backup (- para stdin)")
+	resume := fs.Bool("resume", false, "retoma uma restauração interrompida")
+	fs.Parse(args)
+
+	r, closeInput, err := openInput(*input)
+	if err != nil {
+		return err
+	}
+	defer closeInput()
+
+	report, err := c.Restore(ctx, r, *resume)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restaurados: %d schemas, %d configs, %d modos (%d já existentes)\n",
+		report.Schemas, report.Configs, report.Modes, report.Skipped)
+	return nil
+}
+
+// importConfluentCmd importa o dump do tópico _schemas ou a listagem REST do Confluent
+func importConfluentCmd(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("import-confluent", flag.ExitOnError)
+	input := fs.String("i", "-", "arquivo exportado do Confluent (- para stdin)")
+	fs.Parse(args)
+
+	r, closeInput, err := openInput(*input)
+	if err != nil {
+		return err
+	}
+	defer closeInput()
+
+	report, err := c.ImportConfluent(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Importados: %d schemas, %d configs, %d modos (%d ignorados)\n", report.Imported, report.Configs, report.Modes, report.Skipped)
+	for _, warning := range report.Warnings {
+		fmt.Printf("aviso: %s\n", warning)
+	}
+	for _, conflict := range report.Conflicts {
+		fmt.Printf("conflito: %s v%d (id %s): %s\n", conflict.Subject, conflict.Version, conflict.ID, conflict.Reason)
+	}
+	if len(report.Conflicts) > 0 {
+		return fmt.Errorf("%d conflitos na importação", len(report.Conflicts))
+	}
+	return nil
+}
+
+// syncCmd reconcilia o registry com uma árvore de arquivos de schema
+func syncCmd(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	dir := fs.String("dir", "./schemas", "diretório com os arquivos de schema")
+	apply := fs.Bool("apply", false, "aplica o plano (por padrão apenas exibe o diff)")
+	fs.Parse(args)
+
+	desired, err := gitops.LoadDirectory(*dir)
+	if err != nil {
+		return err
+	}
+
+	plan, err := gitops.BuildPlan(ctx, c, desired)
+	if err != nil {
+		return err
+	}
+	plan.Print(os.Stdout)
+
+	if incompatible := plan.Incompatible(); len(incompatible) > 0 {
+		return fmt.Errorf("%d alterações incompatíveis", len(incompatible))
+	}
+	if !*apply || plan.Empty() {
+		return nil
+	}
+
+	if err := gitops.Apply(ctx, c, plan); err != nil {
+		return err
+	}
+	fmt.Printf("Plano aplicado: %d alterações\n", len(plan.Changes))
+	return nil
+}
+
+// openInput abre o arquivo informado ou stdin para "-"
+func openInput(path string) (io.Reader, func(), error) {
+	if path == "-" {
+		return os.Stdin, func() {}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "uso: client [-url URL] <comando> [opções]\n\ncomandos:\n")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", name)
+	}
+}
 
-	fmt.Printf("SFSFSf")
+// getEnv obtém variável de ambiente ou valor padrão
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
 }
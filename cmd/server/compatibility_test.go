@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/dtos"
+)
+
+func TestCompatibilityEndpoint(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, schema := range []string{
+		`{"type":"record","name":"User","fields":[{"name":"id","type":"string"}]}`,
+		`{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"age","type":"int","default":0}]}`,
+	} {
+		body := `{"subject":"users","schema_type":"AVRO","schema":` + schema + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/v1/schemas/users/versions", strings.NewReader(body)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("register failed: %d %s", w.Code, w.Body.String())
+		}
+	}
+
+	// Campo novo sem default: a versão nova não lê registros antigos (viola BACKWARD, o padrão)
+	withEmail := `{"schema":{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"email","type":"string"}]}}`
+
+	tests := []struct {
+		name                  string
+		path                  string
+		body                  string
+		wantStatus            int
+		wantCompatible        bool
+		wantIncompatibilities int
+	}{
+		{name: "should check against latest by default", path: "/versions", body: withEmail, wantStatus: http.StatusOK},
+		{name: "should detect schema type from content", path: "/versions/1?verbose=true", body: withEmail, wantStatus: http.StatusOK, wantIncompatibilities: 1},
+		{name: "should omit details without verbose", path: "/versions/1", body: withEmail, wantStatus: http.StatusOK},
+		{name: "should check against all versions", path: "/versions/all?verbose=true", body: withEmail, wantStatus: http.StatusOK, wantIncompatibilities: 2},
+		{
+			name:           "should accept compatible schema against all versions",
+			path:           "/versions/all",
+			body:           `{"schema_type":"AVRO","schema":{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"age","type":"int","default":0}]}}`,
+			wantStatus:     http.StatusOK,
+			wantCompatible: true,
+		},
+		{
+			name:           "should check at the requested compatibility level",
+			path:           "/versions",
+			body:           strings.Replace(withEmail, `{"schema"`, `{"compatibility":"FORWARD","schema"`, 1),
+			wantStatus:     http.StatusOK,
+			wantCompatible: true,
+		},
+		{
+			name:       "should reject unknown compatibility level",
+			path:       "/versions",
+			body:       strings.Replace(withEmail, `{"schema"`, `{"compatibility":"SIDEWAYS","schema"`, 1),
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{name: "should reject invalid version", path: "/versions/abc", body: withEmail, wantStatus: http.StatusUnprocessableEntity},
+		{name: "should return 404 for unknown version", path: "/versions/9", body: withEmail, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/v1/compatibility/subjects/users"+tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data dtos.CompatibilityResponse `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			result := response.Data
+			if result.IsCompatible != tt.wantCompatible {
+				t.Fatalf("is_compatible = %v, want %v: %v", result.IsCompatible, tt.wantCompatible, result.Errors)
+			}
+			if len(result.Incompatibilities) != tt.wantIncompatibilities {
+				t.Fatalf("incompatibilities = %+v, want %d", result.Incompatibilities, tt.wantIncompatibilities)
+			}
+			for _, incompatibility := range result.Incompatibilities {
+				if incompatibility.Rule != "BACKWARD" || incompatibility.Type != "FIELD_ADDED" || incompatibility.Path != "User.email" || incompatibility.New != "string" {
+					t.Errorf("unexpected incompatibility: %+v", incompatibility)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestDiffEndpoint(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, schema := range []string{
+		`{"type":"object","properties":{"id":{"type":"string"}}}`,
+		`{"type":"object","properties":{"id":{"type":"string"},"email":{"type":"string"}}}`,
+	} {
+		body := `{"subject":"users","schema_type":"JSON","schema":` + schema + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/v1/schemas/users/versions", strings.NewReader(body)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("register failed: %d %s", w.Code, w.Body.String())
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		{name: "should diff against latest by default", query: "?from=1", wantStatus: http.StatusOK},
+		{name: "should diff explicit versions", query: "?from=1&to=2", wantStatus: http.StatusOK},
+		{name: "should require from", query: "", wantStatus: http.StatusBadRequest},
+		{name: "should reject invalid version", query: "?from=abc", wantStatus: http.StatusUnprocessableEntity},
+		{name: "should return 404 for unknown version", query: "?from=1&to=9", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/subjects/users/diff"+tt.query, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data models.SchemaDiff `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			diff := response.Data
+			if diff.From != 1 || diff.To != 2 || len(diff.Changes) != 1 {
+				t.Fatalf("unexpected diff: %+v", diff)
+			}
+			if change := diff.Changes[0]; change.Type != models.ChangeFieldAdded || change.Path != "$.email" || change.Compatibility != models.CompatibilityFull {
+				t.Errorf("unexpected change: %+v", change)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestRegisterDryRun(t *testing.T) {
+	router := newTestRouter(t)
+
+	register := func(query, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/v1/schemas/users/versions"+query, strings.NewReader(body)))
+		return w
+	}
+
+	if w := register("", `{"subject":"users","schema_type":"AVRO","schema":{"type":"record","name":"User","fields":[{"name":"id","type":"string"}]}}`); w.Code != http.StatusCreated {
+		t.Fatalf("register failed: %d %s", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantValid  bool
+		wantStages []string
+		wantLint   string // aviso esperado na etapa LINT, quando informado
+	}{
+		{
+			name:      "should accept compatible schema",
+			body:      `{"subject":"users","schema_type":"AVRO","schema":{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"age","type":"int","default":0}]}}`,
+			wantValid: true,
+		},
+		{
+			name:      "should report lint warnings without failing",
+			body:      `{"subject":"users","schema_type":"AVRO","schema":{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"Age","type":"int","default":0}]}}`,
+			wantValid: true,
+			wantLint:  "field User.Age should start with a lowercase letter",
+		},
+		{
+			name:       "should report incompatibilities",
+			body:       `{"subject":"users","schema_type":"AVRO","schema":{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"email","type":"string"}]}}`,
+			wantStages: []string{models.StageCompatibility},
+		},
+		{
+			name:       "should report invalid content",
+			body:       `{"subject":"users","schema_type":"AVRO","schema":{"type":"record","fields":[]}}`,
+			wantStages: []string{models.StageValidation},
+		},
+		{
+			name: "should report every failed stage",
+			body: `{"subject":"users","schema_type":"AVRO","schema":{"type":"record","name":"User","fields":[{"name":"id","type":"int"}]},
+				"references":[{"name":"Address","subject":"addresses","version":1}]}`,
+			wantStages: []string{models.StageReferences, models.StageCompatibility},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := register("?dryRun=true", tt.body)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var response struct {
+				Data models.RegistrationCheck `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			check := response.Data
+			if check.Valid != tt.wantValid || check.Version != 2 {
+				t.Fatalf("unexpected check: %+v", check)
+			}
+
+			stages := []string{}
+			for _, finding := range check.Findings {
+				if finding.Severity == models.SeverityError && (len(stages) == 0 || stages[len(stages)-1] != finding.Stage) {
+					stages = append(stages, finding.Stage)
+				}
+			}
+			if strings.Join(stages, ",") != strings.Join(tt.wantStages, ",") {
+				t.Errorf("stages = %v, want %v: %+v", stages, tt.wantStages, check.Findings)
+			}
+
+			if tt.wantLint != "" {
+				found := false
+				for _, finding := range check.Findings {
+					found = found || (finding.Stage == models.StageLint && finding.Severity == models.SeverityWarning && finding.Message == tt.wantLint)
+				}
+				if !found {
+					t.Errorf("expected lint warning %q: %+v", tt.wantLint, check.Findings)
+				}
+			}
+		})
+	}
+
+	// Nada foi gravado
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/schemas/users/versions/2", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected dry run to leave no version 2, got %d", w.Code)
+	}
+}
+
+func TestRegisterSubjectFromPath(t *testing.T) {
+	router := newTestRouter(t)
+
+	register := func(query, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/v1/schemas/users/versions"+query, strings.NewReader(body)))
+		return w
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		body     string
+		wantCode int
+	}{
+		{name: "should reject mismatched subject", body: `{"subject":"orders","schema_type":"JSON","schema":{"type":"object"}}`, wantCode: http.StatusBadRequest},
+		{name: "should reject mismatched subject in dry run", query: "?dryRun=true", body: `{"subject":"orders","schema_type":"JSON","schema":{"type":"object"}}`, wantCode: http.StatusBadRequest},
+		{name: "should use path subject when body omits it", body: `{"schema_type":"JSON","schema":{"type":"object"}}`, wantCode: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := register(tt.query, tt.body); w.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// Nada foi registrado em orders
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/schemas/orders/versions/latest", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected no orders subject, got %d", w.Code)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// sseEvent evento recebido pelo stream SSE
+type sseEvent struct {
+	id    uint64
+	event string
+	data  models.SchemaEvent
+}
+
+// openEventStream abre GET /v1/events e retorna um canal com os eventos recebidos
+func openEventStream(t *testing.T, server *httptest.Server, query, lastEventID string) <-chan sseEvent {
+	t.Helper()
+
+	req, _ := http.NewRequest("GET", server.URL+"/v1/events"+query, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open event stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+
+	received := make(chan sseEvent, 16)
+	go func() {
+		defer close(received)
+
+		var current sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if current.id != 0 {
+					received <- current
+				}
+				current = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				current.id, _ = strconv.ParseUint(strings.TrimPrefix(line, "id: "), 10, 64)
+			case strings.HasPrefix(line, "event: "):
+				current.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &current.data)
+			}
+		}
+	}()
+	return received
+}
+
+func nextEvent(t *testing.T, received <-chan sseEvent) sseEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-received:
+		if !ok {
+			t.Fatal("event stream closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return sseEvent{}
+}
+
+func TestEventStream(t *testing.T) {
+	// Registrado antes dos streams: os cleanups fecham as conexões SSE antes do servidor
+	server := httptest.NewServer(newTestRouter(t))
+	t.Cleanup(server.Close)
+
+	register := func(subject string) {
+		t.Helper()
+
+		body := `{"subject":"` + subject + `","schema_type":"JSON","schema":{"type":"object","properties":{"id":{"type":"string"}}}}`
+		resp, err := http.Post(server.URL+"/v1/schemas/"+subject+"/versions", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("register %s: %v", subject, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("register %s: expected 201, got %d", subject, resp.StatusCode)
+		}
+	}
+
+	register("users")
+	register("payments.order")
+
+	// after=0 transmite desde o início, apenas os subjects do prefixo
+	stream := openEventStream(t, server, "?after=0&subject=payments.", "")
+	first := nextEvent(t, stream)
+	if first.event != models.EventSchemaCreated || first.data.Subject != "payments.order" {
+		t.Fatalf("unexpected first event: %+v", first)
+	}
+	if first.id != first.data.Sequence || first.id < 2 {
+		t.Errorf("expected id to be the stream sequence after the users event, got %d", first.id)
+	}
+
+	// Retomada com Last-Event-ID recebe apenas eventos posteriores
+	resumed := openEventStream(t, server, "?subject=payments.", strconv.FormatUint(first.id, 10))
+	register("users")
+	register("payments.order")
+	next := nextEvent(t, resumed)
+	if next.data.Subject != "payments.order" || next.data.Version != 2 || next.id <= first.id {
+		t.Errorf("unexpected resumed event: %+v", next)
+	}
+
+	// Sem after ou Last-Event-ID, apenas eventos novos
+	live := openEventStream(t, server, "", "")
+	register("orders")
+	if event := nextEvent(t, live); event.data.Subject != "orders" {
+		t.Errorf("expected only new events, got %+v", event)
+	}
+
+	resp, err := http.Get(server.URL + "/v1/events?after=abc")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid cursor, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/grpcapi/registrypb"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newTestGRPCClient serve a API gRPC real em memória
+func newTestGRPCClient(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	registry, _, kv, _ := newTestRegistry(t)
+	srv := setupGRPCServer(registry, nil, initializeACL(kv), nil)
+
+	listener := bufconn.Listen(1 << 20)
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// errorCode extrai o código numérico da API do ErrorInfo do status
+func errorCode(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetMetadata()["error_code"]
+		}
+	}
+	return ""
+}
+
+func TestGRPCAPI(t *testing.T) {
+	conn := newTestGRPCClient(t)
+	client := registrypb.NewSchemaRegistryClient(conn)
+	ctx := context.Background()
+
+	registered, err := client.RegisterSchema(ctx, &registrypb.RegisterSchemaRequest{
+		Subject:    "orders",
+		SchemaType: "JSON",
+		Schema:     `{"type":"object","properties":{"id":{"type":"string"}},"required":["id"]}`,
+		Metadata:   map[string]string{"team": "checkout"},
+	})
+	if err != nil {
+		t.Fatalf("RegisterSchema: %v", err)
+	}
+	if registered.GetVersion() != 1 || registered.GetFingerprint() == "" || registered.GetCreatedAt() == nil {
+		t.Errorf("unexpected registered schema: %v", registered)
+	}
+
+	latest, err := client.GetSchema(ctx, &registrypb.GetSchemaRequest{Subject: "orders"})
+	if err != nil || latest.GetId() != registered.GetId() {
+		t.Errorf("GetSchema(latest) = %v, %v", latest, err)
+	}
+	byID, err := client.GetSchemaByID(ctx, &registrypb.GetSchemaByIDRequest{Id: registered.GetId()})
+	if err != nil || byID.GetSubject() != "orders" {
+		t.Errorf("GetSchemaByID = %v, %v", byID, err)
+	}
+
+	subjects, err := client.ListSubjects(ctx, &registrypb.ListSubjectsRequest{Labels: map[string]string{"team": "checkout"}})
+	if err != nil || len(subjects.GetSubjects()) != 1 || subjects.GetSubjects()[0].GetLatestVersion() != 1 {
+		t.Errorf("ListSubjects = %v, %v", subjects, err)
+	}
+	versions, err := client.ListVersions(ctx, &registrypb.ListVersionsRequest{Subject: "orders"})
+	if err != nil || len(versions.GetVersions()) != 1 {
+		t.Errorf("ListVersions = %v, %v", versions, err)
+	}
+
+	config, err := client.UpdateConfig(ctx, &registrypb.UpdateConfigRequest{Subject: "orders", Compatibility: "FULL"})
+	if err != nil || config.GetCompatibility() != "FULL" {
+		t.Errorf("UpdateConfig = %v, %v", config, err)
+	}
+	config, err = client.GetConfig(ctx, &registrypb.GetConfigRequest{Subject: "orders"})
+	if err != nil || config.GetCompatibility() != "FULL" {
+		t.Errorf("GetConfig = %v, %v", config, err)
+	}
+	config, err = client.DeleteConfig(ctx, &registrypb.DeleteConfigRequest{Subject: "orders"})
+	if err != nil || config.GetCompatibility() != "BACKWARD" {
+		t.Errorf("DeleteConfig = %v, %v", config, err)
+	}
+
+	compatibility, err := client.CheckCompatibility(ctx, &registrypb.CheckCompatibilityRequest{Subject: "orders", Schema: `{"type":"object"}`})
+	if err != nil || compatibility == nil {
+		t.Errorf("CheckCompatibility = %v, %v", compatibility, err)
+	}
+
+	data, _ := structpb.NewValue(map[string]interface{}{"id": "1"})
+	validation, err := client.ValidateData(ctx, &registrypb.ValidateDataRequest{Subject: "orders", Data: data})
+	if err != nil || !validation.GetIsValid() {
+		t.Errorf("ValidateData = %v, %v", validation, err)
+	}
+
+	tests := []struct {
+		name      string
+		call      func() error
+		wantCode  codes.Code
+		wantError string
+	}{
+		{
+			name: "should return NotFound for unknown subject",
+			call: func() error {
+				_, err := client.GetSchema(ctx, &registrypb.GetSchemaRequest{Subject: "missing"})
+				return err
+			},
+			wantCode: codes.NotFound, wantError: "40401",
+		},
+		{
+			name: "should return NotFound for unknown version",
+			call: func() error {
+				_, err := client.GetSchema(ctx, &registrypb.GetSchemaRequest{Subject: "orders", Version: 9})
+				return err
+			},
+			wantCode: codes.NotFound, wantError: "40402",
+		},
+		{
+			name: "should reject negative version",
+			call: func() error {
+				_, err := client.GetSchema(ctx, &registrypb.GetSchemaRequest{Subject: "orders", Version: -1})
+				return err
+			},
+			wantCode: codes.InvalidArgument, wantError: "42202",
+		},
+		{
+			name: "should reject invalid schema",
+			call: func() error {
+				_, err := client.RegisterSchema(ctx, &registrypb.RegisterSchemaRequest{Subject: "broken", SchemaType: "JSON", Schema: "{"})
+				return err
+			},
+			wantCode: codes.InvalidArgument, wantError: "42201",
+		},
+		{
+			name: "should reject invalid config",
+			call: func() error {
+				_, err := client.UpdateConfig(ctx, &registrypb.UpdateConfigRequest{Subject: "orders", Compatibility: "SOMETIMES"})
+				return err
+			},
+			wantCode: codes.InvalidArgument, wantError: "42203",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", status.Code(err), tt.wantCode, err)
+			}
+			if got := errorCode(err); got != tt.wantError {
+				t.Errorf("error_code = %q, want %q", got, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestGRPCReflection(t *testing.T) {
+	conn := newTestGRPCClient(t)
+
+	stream, err := grpc_reflection_v1.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("failed to open reflection stream: %v", err)
+	}
+	if err := stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		if service.GetName() == "schemaregistry.v1.SchemaRegistry" {
+			return
+		}
+	}
+	t.Errorf("SchemaRegistry not listed by reflection: %v", resp)
+}
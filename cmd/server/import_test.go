@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/confluent"
+)
+
+func TestImportSharedConfluentID(t *testing.T) {
+	ctx := context.Background()
+	registry, _, _, _ := newTestRegistry(t)
+
+	// O Confluent atribui o mesmo ID a conteúdos idênticos em subjects diferentes
+	listing := `[
+		{"subject":"orders-value","version":1,"id":7,"schemaType":"JSON","schema":"{\"type\":\"object\"}"},
+		{"subject":"refunds-value","version":3,"id":7,"schemaType":"JSON","schema":"{\"type\":\"object\"}"},
+		{"subject":"users-value","version":1,"id":7,"schemaType":"JSON","schema":"{\"type\":\"string\"}"}
+	]`
+
+	report, err := confluent.Import(ctx, registry, strings.NewReader(listing))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if report.Imported != 2 || len(report.Conflicts) != 1 || report.Conflicts[0].Subject != "users-value" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	if _, err := registry.FindSchema(ctx, "refunds-value", 3, false); err != nil {
+		t.Fatalf("expected refunds-value version 3 to be imported: %v", err)
+	}
+
+	// Removendo uma das versões, o ID continua resolvendo para a outra
+	if err := registry.DeleteSchema(ctx, "orders-value", 1, false); err != nil {
+		t.Fatalf("soft delete failed: %v", err)
+	}
+	if schema, err := registry.FindSchemaByID(ctx, "7", false); err != nil || schema.Subject != "refunds-value" {
+		t.Fatalf("expected id 7 to resolve to the active version, got %+v (%v)", schema, err)
+	}
+
+	if err := registry.DeleteSchema(ctx, "orders-value", 1, true); err != nil {
+		t.Fatalf("permanent delete failed: %v", err)
+	}
+	if schema, err := registry.GetSchemaByID(ctx, "7"); err != nil || schema.Subject != "refunds-value" || schema.Version != 3 {
+		t.Errorf("expected id 7 to survive the permanent delete, got %+v (%v)", schema, err)
+	}
+}
@@ -2,23 +2,40 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
 	"github.com/rodrigues-daniel/data-platform/internal/api"
+	"github.com/rodrigues-daniel/data-platform/internal/audit"
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+	"github.com/rodrigues-daniel/data-platform/internal/events"
+	"github.com/rodrigues-daniel/data-platform/internal/grpcapi"
+	"github.com/rodrigues-daniel/data-platform/internal/grpcapi/registrypb"
+	"github.com/rodrigues-daniel/data-platform/internal/natsapi"
 	"github.com/rodrigues-daniel/data-platform/internal/schema"
+	"github.com/rodrigues-daniel/data-platform/internal/tlsconfig"
+	"github.com/rodrigues-daniel/data-platform/internal/webhook"
 
 	"github.com/gorilla/mux"
 	"github.com/nats-io/nats-server/v2/server"
 	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
 )
 
 var (
@@ -49,29 +66,55 @@ func (a *JetStreamAdapter) Publish(subj string, data []byte) error {
 	return err
 }
 
+// PublishEvent publica com Nats-Msg-Id, deduplicando republicações do relay do outbox
+func (a *JetStreamAdapter) PublishEvent(subj string, data []byte, msgID string) error {
+	_, err := a.js.Publish(subj, data, nats.MsgId(msgID))
+	return err
+}
+
 func main() {
 	// Registra as métricas no registro padrão
 	prometheus.MustRegister(requestsTotal)
 	prometheus.MustRegister(requestDuration)
+	prometheus.MustRegister(schema.CacheRequests)
+	prometheus.MustRegister(schema.OutboxEvents)
+	prometheus.MustRegister(schema.OutboxPending)
+	prometheus.MustRegister(api.RateLimitedRequests)
+	prometheus.MustRegister(webhook.Deliveries)
+	prometheus.MustRegister(grpcapi.RequestsTotal)
+	prometheus.MustRegister(grpcapi.RequestDuration)
+
+	// Certificados TLS (opcionais) da API e da porta de clientes NATS
+	httpTLS := loadTLSReloader("HTTP")
+	natsTLS := loadTLSReloader("NATS")
+	watchTLSReloaders(httpTLS, natsTLS)
 
 	// Inicializar NATS com JetStream
-	js, kv, nc, ns := initializeNATSWithJetStream()
+	js, kv, nc, ns := initializeNATSWithJetStream(natsTLS)
 	defer cleanupNATS(nc, ns)
 
-	// Inicializar registry e configurar HTTP
-	registry := initializeRegistry(js, kv)
-	server := setupHTTPServer(registry)
+	// Inicializar auditoria, registry e configurar HTTP
+	recorder := initializeAudit(js)
+	registry := initializeRegistry(js, kv, recorder)
+	authenticators := initializeAuth(kv)
+	aclStore := initializeACL(kv)
+	webhooks, deliveries := initializeWebhooks(js, kv)
+	server := setupHTTPServer(registry, recorder, events.NewSource(js), webhooks, deliveries, authenticators, aclStore, httpTLS)
+	grpcServer := setupGRPCServer(registry, authenticators, aclStore, httpTLS)
+	if natsService := initializeNATSService(nc, registry, authenticators, aclStore); natsService != nil {
+		defer natsService.Stop()
+	}
 
 	// Demonstrar funcionamento do KV
 	demonstrateKVUsage(kv)
 
 	// Iniciar servidores e aguardar shutdown
 
-	startServers(server)
+	startServers(server, grpcServer, getEnv("GRPC_PORT", ":9091"))
 }
 
 // initializeNATSWithJetStream configura e inicia o NATS com JetStream
-func initializeNATSWithJetStream() (nats.JetStreamContext, nats.KeyValue, *nats.Conn, *server.Server) {
+func initializeNATSWithJetStream(tlsReloader *tlsconfig.Reloader) (nats.JetStreamContext, nats.KeyValue, *nats.Conn, *server.Server) {
 	// Configuração do servidor NATS embutido
 	storeDir := getEnv("NATS_STORE_DIR", "./jetstream-data")
 	serverName := getEnv("NATS_SERVER_NAME", "schema-registry-standalone")
@@ -84,7 +127,14 @@ func initializeNATSWithJetStream() (nats.JetStreamContext, nats.KeyValue, *nats.
 		Port:       getEnvAsInt("NATS_PORT", 4222), // Porta fixa para desenvolvimento
 		Host:       getEnv("NATS_HOST", "0.0.0.0"),
 		HTTPPort:   getEnvAsInt("NATS_HTTP_PORT", 8222), // Monitoramento
+		// Sinais (inclusive SIGHUP para recarregar TLS) são tratados pela aplicação
+		NoSigs: true,
+	}
+	if tlsReloader != nil {
+		opts.TLSConfig = tlsReloader.TLSConfig()
+		opts.TLSVerify = opts.TLSConfig.ClientAuth == tls.RequireAndVerifyClientCert
 	}
+	internalUser := configureNATSAuthorization(opts, getEnvAsBool("AUTH_ENABLED", false))
 
 	// Criar e iniciar servidor NATS
 	ns, err := server.NewServer(opts)
@@ -106,13 +156,22 @@ func initializeNATSWithJetStream() (nats.JetStreamContext, nats.KeyValue, *nats.
 	log.Printf("NATS Server '%s' rodando em: %s", serverName, ns.ClientURL())
 	log.Printf("JetStream Store Directory: %s", storeDir)
 
-	// Conectar ao servidor
-	nc, err := nats.Connect(ns.ClientURL(),
+	// Conectar ao servidor; com TLS habilitado a conexão interna é feita em processo
+	connectOpts := []nats.Option{
 		nats.Name("Schema-Registry-Client"),
-		nats.Timeout(10*time.Second),
-		nats.PingInterval(20*time.Second),
+		nats.Timeout(10 * time.Second),
+		nats.PingInterval(20 * time.Second),
 		nats.MaxPingsOutstanding(5),
-	)
+	}
+	if internalUser != nil {
+		connectOpts = append(connectOpts, internalUser)
+	}
+	url := ns.ClientURL()
+	if tlsReloader != nil {
+		url = nats.DefaultURL
+		connectOpts = append(connectOpts, nats.InProcessServer(ns))
+	}
+	nc, err := nats.Connect(url, connectOpts...)
 	if err != nil {
 		log.Fatal("Erro ao conectar:", err)
 	}
@@ -151,6 +210,65 @@ func initializeNATSWithJetStream() (nats.JetStreamContext, nats.KeyValue, *nats.
 	return js, kv, nc, ns
 }
 
+// configureNATSAuthorization com a autenticação habilitada, restringe os clientes externos:
+// só a conexão do próprio servidor (usuário interno com senha gerada a cada início) grava no
+// bucket schemadb e nos streams internos. Clientes sem credenciais usam a API $SR.* e consomem
+// SCHEMA_EVENTS. Retorna a opção de conexão do usuário interno (nil quando desabilitada).
+func configureNATSAuthorization(opts *server.Options, enabled bool) nats.Option {
+	if !enabled {
+		log.Println("Aviso: NATS sem autorização; qualquer cliente pode gravar no bucket schemadb")
+		return nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatal("Erro ao gerar credencial interna do NATS:", err)
+	}
+	password := hex.EncodeToString(secret)
+
+	opts.Users = []*server.User{
+		{Username: natsInternalUser, Password: password},
+		{Username: natsClientUser, Permissions: natsClientPermissions()},
+	}
+	opts.NoAuthUser = natsClientUser
+	return nats.UserInfo(natsInternalUser, password)
+}
+
+const (
+	natsInternalUser = "schema-registry"
+	natsClientUser   = "client"
+)
+
+// natsClientPermissions clientes externos: requisições à API NATS ($SR.*, com autenticação e
+// ACLs próprias), descoberta de serviços e consumers do stream de eventos. Gravações no KV,
+// auditoria, outbox e log de webhooks ficam restritas ao servidor.
+func natsClientPermissions() *server.Permissions {
+	events := schema.EventStreamName
+	return &server.Permissions{
+		Publish: &server.SubjectPermission{
+			Allow: []string{
+				natsapi.SubjectPrefix + ".>",
+				"$SRV.>",
+				"$JS.API.INFO",
+				"$JS.API.STREAM.NAMES",
+				"$JS.API.STREAM.INFO." + events,
+				"$JS.API.STREAM.MSG.GET." + events,
+				"$JS.API.DIRECT.GET." + events,
+				"$JS.API.DIRECT.GET." + events + ".>",
+				"$JS.API.CONSUMER.*." + events,
+				"$JS.API.CONSUMER.*." + events + ".>",
+				"$JS.API.CONSUMER.DURABLE.CREATE." + events + ".>",
+				"$JS.API.CONSUMER.MSG.NEXT." + events + ".>",
+				"$JS.ACK." + events + ".>",
+				"$JS.FC." + events + ".>",
+			},
+		},
+		Subscribe: &server.SubjectPermission{
+			Allow: []string{"_INBOX.>", schema.EventSubjectPrefix + ">"},
+		},
+	}
+}
+
 // waitForJetStream aguarda o JetStream estar pronto
 func waitForJetStream(js nats.JetStreamContext, ctx context.Context) error {
 	for {
@@ -196,28 +314,253 @@ func createOrGetKVBucket(js nats.JetStreamContext, bucketName string) (nats.KeyV
 	return kv, nil
 }
 
+// createOrUpdateStream cria um stream JetStream ou atualiza a configuração do existente
+func createOrUpdateStream(js nats.JetStreamContext, config *nats.StreamConfig) error {
+	_, err := js.StreamInfo(config.Name)
+	if err == nil {
+		_, err = js.UpdateStream(config)
+		return err
+	}
+	if err != nats.ErrStreamNotFound {
+		return err
+	}
+
+	_, err = js.AddStream(config)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Stream '%s' criado com sucesso", config.Name)
+	return nil
+}
+
+// initializeAudit provisiona o stream de auditoria
+func initializeAudit(js nats.JetStreamContext) *audit.Recorder {
+	if err := createOrUpdateStream(js, audit.StreamConfig()); err != nil {
+		log.Fatal("Erro ao criar stream de auditoria:", err)
+	}
+
+	return audit.NewRecorder(js)
+}
+
+// loadTLSReloader carrega a configuração TLS de <prefix>_TLS_* (nil quando não configurada)
+func loadTLSReloader(prefix string) *tlsconfig.Reloader {
+	config := tlsconfig.Config{
+		CertFile:   getEnv(prefix+"_TLS_CERT_FILE", ""),
+		KeyFile:    getEnv(prefix+"_TLS_KEY_FILE", ""),
+		CAFile:     getEnv(prefix+"_TLS_CA_FILE", ""),
+		MinVersion: getEnv(prefix+"_TLS_MIN_VERSION", "1.2"),
+		ClientAuth: getEnv(prefix+"_TLS_CLIENT_AUTH", "none"),
+	}
+	if !config.Enabled() {
+		return nil
+	}
+
+	reloader, err := tlsconfig.NewReloader(config)
+	if err != nil {
+		log.Fatalf("Erro ao carregar TLS (%s): %v", prefix, err)
+	}
+	log.Printf("TLS habilitado para %s (%s)", prefix, config.CertFile)
+	return reloader
+}
+
+// watchTLSReloaders recarrega os certificados quando os arquivos mudam ou ao receber SIGHUP
+func watchTLSReloaders(reloaders ...*tlsconfig.Reloader) {
+	var active []*tlsconfig.Reloader
+	for _, reloader := range reloaders {
+		if reloader != nil {
+			active = append(active, reloader)
+		}
+	}
+	if len(active) == 0 {
+		return
+	}
+
+	interval := time.Duration(getEnvAsInt("TLS_RELOAD_INTERVAL_SECONDS", 30)) * time.Second
+	for _, reloader := range active {
+		go reloader.Watch(context.Background(), interval)
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for range hangup {
+			for _, reloader := range active {
+				if err := reloader.Reload(); err != nil {
+					log.Printf("Aviso: falha ao recarregar certificado TLS: %v", err)
+					continue
+				}
+				log.Println("Certificado TLS recarregado (SIGHUP)")
+			}
+		}
+	}()
+}
+
 // initializeRegistry configura o schema registry
-func initializeRegistry(js nats.JetStreamContext, kv nats.KeyValue) *schema.Registry {
+func initializeRegistry(js nats.JetStreamContext, kv nats.KeyValue, recorder *audit.Recorder) *schema.Registry {
 	storage := schema.NewStorage(kv)
+	if err := storage.EnableCache(context.Background()); err != nil {
+		log.Printf("Aviso: cache desabilitado, leituras irão direto ao KV: %v", err)
+	}
 	validator := schema.NewValidator(storage)
 	njs := &JetStreamAdapter{js: js}
 
 	registry := schema.NewRegistry(storage, validator, njs)
+	registry.SetAuditor(recorder)
+	registry.SetMaxVersions(getEnvAsInt("SCHEMA_MAX_VERSIONS", 0))
+	registry.SetBatchConcurrency(getEnvAsInt("BATCH_CONCURRENCY", schema.DefaultBatchConcurrency))
+
+	// Política opcional: chaves de metadata exigidas em todo registro (ex.: owner,team)
+	var requiredMetadata []string
+	for _, key := range strings.Split(getEnv("SCHEMA_REQUIRED_METADATA", ""), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			requiredMetadata = append(requiredMetadata, key)
+		}
+	}
+	if len(requiredMetadata) > 0 {
+		registry.AddPolicy(schema.RequiredMetadata(requiredMetadata...))
+	}
+
+	// Eventos gravados no outbox junto com as alterações e publicados pelo relay
+	retention := schema.EventRetention{
+		MaxAge:   time.Duration(getEnvAsInt("EVENTS_MAX_AGE_HOURS", 0)) * time.Hour,
+		MaxBytes: int64(getEnvAsInt("EVENTS_MAX_BYTES", 0)),
+		MaxMsgs:  int64(getEnvAsInt("EVENTS_MAX_MSGS", 0)),
+	}
+	if err := createOrUpdateStream(js, schema.EventStreamConfig(retention)); err != nil {
+		log.Fatal("Erro ao criar stream de eventos:", err)
+	}
+	registry.SetOutbox(schema.NewOutbox(kv))
+	interval := time.Duration(getEnvAsInt("EVENT_RELAY_INTERVAL_MS", int(schema.DefaultRelayInterval/time.Millisecond))) * time.Millisecond
+	go func() {
+		if err := registry.RunEventRelay(context.Background(), njs, interval); err != nil {
+			log.Printf("Aviso: relay de eventos encerrado, eventos ficam no outbox: %v", err)
+		}
+	}()
 	log.Println("Schema Registry inicializado com sucesso")
 	return registry
 }
 
+// initializeNATSService expõe o registry como serviço NATS micro ($SR.*), com a mesma
+// autenticação e ACLs das APIs HTTP e gRPC
+func initializeNATSService(nc *nats.Conn, registry *schema.Registry, authenticators []auth.Authenticator, aclStore *acl.Store) *natsapi.Service {
+	if !getEnvAsBool("NATS_API_ENABLED", true) {
+		return nil
+	}
+
+	service := natsapi.NewService(registry)
+	if len(authenticators) > 0 {
+		service.SetAuthenticators(authenticators)
+		service.SetAuthorizer(aclStore)
+	}
+	if err := service.Start(nc); err != nil {
+		log.Fatal("Erro ao iniciar API NATS:", err)
+	}
+	log.Printf("API NATS disponível em %s.* (serviço %s)", natsapi.SubjectPrefix, natsapi.ServiceName)
+	return service
+}
+
+// initializeAuth configura os autenticadores da API (desabilitado por padrão)
+func initializeAuth(kv nats.KeyValue) []auth.Authenticator {
+	if !getEnvAsBool("AUTH_ENABLED", false) {
+		log.Println("Aviso: autenticação da API desabilitada (AUTH_ENABLED=false)")
+		return nil
+	}
+
+	// Certificado de cliente só é apresentado quando TLS com client auth está ativo
+	authenticators := []auth.Authenticator{auth.NewCertAuthenticator()}
+
+	keyStore := auth.NewAPIKeyStore(kv)
+	if err := keyStore.Bootstrap(getEnv("AUTH_API_KEYS", "")); err != nil {
+		log.Fatal("Erro ao carregar API keys:", err)
+	}
+	authenticators = append(authenticators, keyStore)
+
+	if jwksFile := getEnv("AUTH_JWKS_FILE", ""); jwksFile != "" {
+		jwtAuth, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+			JWKSFile:       jwksFile,
+			Issuer:         getEnv("AUTH_JWT_ISSUER", ""),
+			Audience:       getEnv("AUTH_JWT_AUDIENCE", ""),
+			PrincipalClaim: getEnv("AUTH_JWT_PRINCIPAL_CLAIM", "sub"),
+		})
+		if err != nil {
+			log.Fatal("Erro ao carregar JWKS:", err)
+		}
+		authenticators = append(authenticators, jwtAuth)
+	}
+
+	log.Printf("Autenticação da API habilitada (%d autenticadores)", len(authenticators))
+	return authenticators
+}
+
+// initializeACL carrega os bindings de controle de acesso persistidos no KV
+func initializeACL(kv nats.KeyValue) *acl.Store {
+	store := acl.NewStore(kv)
+	if err := store.Start(context.Background()); err != nil {
+		log.Fatal("Erro ao carregar ACLs:", err)
+	}
+
+	// Administradores iniciais, necessários para criar os primeiros bindings
+	store.Bootstrap(getEnv("ACL_ADMINS", ""))
+	return store
+}
+
+// initializeWebhooks carrega os webhooks e inicia a entrega dos eventos de schema
+func initializeWebhooks(js nats.JetStreamContext, kv nats.KeyValue) (*webhook.Store, *webhook.DeliveryLog) {
+	retention := time.Duration(getEnvAsInt("WEBHOOK_DELIVERY_RETENTION_HOURS", 168)) * time.Hour
+	if err := createOrUpdateStream(js, webhook.DeliveryStreamConfig(retention)); err != nil {
+		log.Fatal("Erro ao criar stream de entregas de webhooks:", err)
+	}
+
+	store := webhook.NewStore(kv)
+	allowed, err := webhook.ParseNetworks(os.Getenv("WEBHOOK_ALLOWED_NETWORKS"))
+	if err != nil {
+		log.Fatal("Erro ao ler WEBHOOK_ALLOWED_NETWORKS:", err)
+	}
+	store.SetAllowedNetworks(allowed)
+	if err := store.Start(context.Background()); err != nil {
+		log.Fatal("Erro ao carregar webhooks:", err)
+	}
+	deliveries := webhook.NewDeliveryLog(js)
+
+	config := webhook.DefaultConfig()
+	config.MaxAttempts = getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", config.MaxAttempts)
+	config.BaseBackoff = time.Duration(getEnvAsInt("WEBHOOK_BACKOFF_BASE_MS", int(config.BaseBackoff/time.Millisecond))) * time.Millisecond
+	config.MaxBackoff = time.Duration(getEnvAsInt("WEBHOOK_BACKOFF_MAX_SECONDS", int(config.MaxBackoff/time.Second))) * time.Second
+	config.DisableAfter = getEnvAsInt("WEBHOOK_DISABLE_AFTER_FAILURES", config.DisableAfter)
+	config.Timeout = time.Duration(getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", int(config.Timeout/time.Second))) * time.Second
+	go webhook.NewDispatcher(js, store, deliveries, config).Run(context.Background())
+
+	return store, deliveries
+}
+
 // setupHTTPServer configura o servidor HTTP com Gorilla Mux
-func setupHTTPServer(registry *schema.Registry) *http.Server {
+func setupHTTPServer(registry *schema.Registry, recorder *audit.Recorder, eventSource *events.Source, webhooks *webhook.Store, deliveries *webhook.DeliveryLog, authenticators []auth.Authenticator, aclStore *acl.Store, tlsReloader *tlsconfig.Reloader) *http.Server {
 	router := mux.NewRouter()
 
 	// Configurar middlewares
-	setupGorillaMiddlewares(router)
+	setupGorillaMiddlewares(router, authenticators)
 
 	handlers := api.NewHandlers(registry)
+	handlers.SetLatestMaxAge(time.Duration(getEnvAsInt("HTTP_CACHE_LATEST_MAX_AGE_SECONDS", 10)) * time.Second)
+	auditHandlers := api.NewAuditHandlers(recorder)
+	adminHandlers := api.NewAdminHandlers(registry)
+	aclHandlers := api.NewACLHandlers(aclStore)
+	eventHandlers := api.NewEventHandlers(eventSource)
+	eventHandlers.SetHeartbeat(time.Duration(getEnvAsInt("EVENTS_HEARTBEAT_SECONDS", 15)) * time.Second)
+	webhookHandlers := api.NewWebhookHandlers(webhooks, deliveries)
+
+	// Autorização só faz sentido com principais autenticados
+	if len(authenticators) > 0 {
+		handlers.SetAuthorizer(aclStore)
+		auditHandlers.SetAuthorizer(aclStore)
+		adminHandlers.SetAuthorizer(aclStore)
+		eventHandlers.SetAuthorizer(aclStore)
+		webhookHandlers.SetAuthorizer(aclStore)
+	}
 
 	// Configurar rotas da API
-	setupAPIRoutes(router, handlers)
+	setupAPIRoutes(router, handlers, auditHandlers, eventHandlers, webhookHandlers, adminHandlers, aclHandlers)
 
 	// Servidor HTTP
 	server := &http.Server{
@@ -227,12 +570,45 @@ func setupHTTPServer(registry *schema.Registry) *http.Server {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	if tlsReloader != nil {
+		server.TLSConfig = tlsReloader.TLSConfig("h2", "http/1.1")
+	}
+	server.RegisterOnShutdown(eventHandlers.Shutdown)
+
+	return server
+}
+
+// setupGRPCServer configura a API gRPC, com os mesmos autenticadores, ACLs e certificados da API HTTP
+func setupGRPCServer(registry *schema.Registry, authenticators []auth.Authenticator, aclStore *acl.Store, tlsReloader *tlsconfig.Reloader) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{
+		grpcapi.RecoveryInterceptor(),
+		grpcapi.MetricsInterceptor(),
+		grpcapi.ActorInterceptor(),
+	}
 
+	service := grpcapi.NewServer(registry)
+
+	// Autorização só faz sentido com principais autenticados
+	if len(authenticators) > 0 {
+		interceptors = append(interceptors, grpcapi.AuthInterceptor(authenticators))
+		service.SetAuthorizer(aclStore)
+	}
+
+	options := []grpc.ServerOption{grpc.ChainUnaryInterceptor(interceptors...)}
+	if tlsReloader != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsReloader.TLSConfig("h2"))))
+	}
+
+	server := grpc.NewServer(options...)
+	registrypb.RegisterSchemaRegistryServer(server, service)
+
+	// Reflexão para clientes como grpcurl e Postman
+	reflection.Register(server)
 	return server
 }
 
 // setupGorillaMiddlewares configura os middlewares
-func setupGorillaMiddlewares(router *mux.Router) {
+func setupGorillaMiddlewares(router *mux.Router, authenticators []auth.Authenticator) {
 	// Middleware para logging
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -242,6 +618,31 @@ func setupGorillaMiddlewares(router *mux.Router) {
 		})
 	})
 
+	// Middleware para identificar o autor das alterações (auditoria): o endereço do cliente,
+	// substituído pelo principal quando a autenticação está habilitada
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(audit.WithActor(r.Context(), r.RemoteAddr)))
+		})
+	})
+
+	// Middleware de autenticação (API key, JWT ou certificado de cliente)
+	if len(authenticators) > 0 {
+		router.Use(api.AuthMiddleware(authenticators, "/health", "/metrics", "/openapi.json", "/docs"))
+	}
+
+	// Middleware de limite de taxa (após a autenticação, para limitar por principal)
+	if getEnvAsBool("RATE_LIMIT_ENABLED", false) {
+		limiter := api.NewRateLimiter(api.RateLimitConfig{
+			ReadRate:   getEnvAsFloat("RATE_LIMIT_READ_RPS", 50),
+			ReadBurst:  getEnvAsInt("RATE_LIMIT_READ_BURST", 100),
+			WriteRate:  getEnvAsFloat("RATE_LIMIT_WRITE_RPS", 5),
+			WriteBurst: getEnvAsInt("RATE_LIMIT_WRITE_BURST", 10),
+		})
+		go limiter.Cleanup(context.Background(), 10*time.Minute)
+		router.Use(api.RateLimitMiddleware(limiter, "/health", "/metrics"))
+	}
+
 	// Middleware para content-type JSON
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -268,28 +669,78 @@ func setupGorillaMiddlewares(router *mux.Router) {
 }
 
 // setupAPIRoutes configura todas as rotas da API com Gorilla Mux
-func setupAPIRoutes(router *mux.Router, handlers *api.Handlers) {
+func setupAPIRoutes(router *mux.Router, handlers *api.Handlers, auditHandlers *api.AuditHandlers, eventHandlers *api.EventHandlers, webhookHandlers *api.WebhookHandlers, adminHandlers *api.AdminHandlers, aclHandlers *api.ACLHandlers) {
 	// Health check
 	router.HandleFunc("/health", healthCheckHandler).Methods("GET")
 
+	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+
+	// Contrato e documentação da API
+	router.HandleFunc("/openapi.json", api.OpenAPIHandler).Methods("GET")
+	router.HandleFunc("/docs", api.DocsHandler).Methods("GET")
+
+	// API versionada
+	registerAPIRoutes(router.PathPrefix("/v1").Subrouter(), handlers, auditHandlers, eventHandlers, webhookHandlers, adminHandlers, aclHandlers)
+
+	// Rotas sem versão (deprecated, mantidas por compatibilidade)
+	legacy := router.NewRoute().Subrouter()
+	legacy.Use(api.LegacyMiddleware)
+	registerAPIRoutes(legacy, handlers, auditHandlers, eventHandlers, webhookHandlers, adminHandlers, aclHandlers)
+
+	log.Println("Rotas da API configuradas com Gorilla Mux")
+}
+
+// registerAPIRoutes registra as rotas de negócio da API no router informado
+func registerAPIRoutes(router *mux.Router, handlers *api.Handlers, auditHandlers *api.AuditHandlers, eventHandlers *api.EventHandlers, webhookHandlers *api.WebhookHandlers, adminHandlers *api.AdminHandlers, aclHandlers *api.ACLHandlers) {
 	// Rotas de Schemas
+	router.HandleFunc("/schemas/ids/{id}", handlers.GetSchemaByIDHandler).Methods("GET")
 	router.HandleFunc("/schemas/{subject}/versions", handlers.RegisterSchemaHandler).Methods("POST")
 	router.HandleFunc("/schemas/{subject}/versions/{version}", handlers.GetSchemaHandler).Methods("GET")
+	router.HandleFunc("/schemas/{subject}/versions/{version}", handlers.DeleteSchemaHandler).Methods("DELETE")
+
+	// Operações em lote
+	router.HandleFunc("/batch/register", handlers.BatchRegisterHandler).Methods("POST")
+	router.HandleFunc("/batch/get", handlers.BatchGetHandler).Methods("POST")
+	router.HandleFunc("/batch/compatibility", handlers.BatchCompatibilityHandler).Methods("POST")
 
 	// Rotas de Subjects
 	router.HandleFunc("/subjects", handlers.ListSubjectsHandler).Methods("GET")
 	router.HandleFunc("/subjects/{subject}/versions", handlers.ListVersionsHandler).Methods("GET")
+	router.HandleFunc("/subjects/{subject}/diff", handlers.DiffHandler).Methods("GET")
+	router.HandleFunc("/subjects/{subject}/lookup", handlers.LookupSchemaHandler).Methods("POST")
 
 	// Rotas de Configuração
 	router.HandleFunc("/config/{subject}", handlers.ConfigHandler).Methods("GET", "PUT", "DELETE")
+	router.HandleFunc("/mode/{subject}", handlers.ModeHandler).Methods("GET", "PUT", "DELETE")
 
 	// Rotas de Compatibilidade
 	router.HandleFunc("/compatibility/subjects/{subject}/versions", handlers.CompatibilityHandler).Methods("POST")
+	router.HandleFunc("/compatibility/subjects/{subject}/versions/{version}", handlers.CompatibilityHandler).Methods("POST")
 	router.HandleFunc("/validate/{subject}", handlers.ValidateHandler).Methods("POST")
 
-	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
-
-	log.Println("Rotas da API configuradas com Gorilla Mux")
+	// Rotas de Auditoria
+	router.HandleFunc("/audit", auditHandlers.ListAuditHandler).Methods("GET")
+
+	// Stream de eventos (Server-Sent Events)
+	router.HandleFunc("/events", eventHandlers.StreamEventsHandler).Methods("GET")
+
+	// Webhooks de eventos
+	router.HandleFunc("/webhooks", webhookHandlers.ListWebhooksHandler).Methods("GET")
+	router.HandleFunc("/webhooks", webhookHandlers.CreateWebhookHandler).Methods("POST")
+	router.HandleFunc("/webhooks/{id}", webhookHandlers.GetWebhookHandler).Methods("GET")
+	router.HandleFunc("/webhooks/{id}", webhookHandlers.UpdateWebhookHandler).Methods("PUT")
+	router.HandleFunc("/webhooks/{id}", webhookHandlers.DeleteWebhookHandler).Methods("DELETE")
+	router.HandleFunc("/webhooks/{id}/deliveries", webhookHandlers.ListDeliveriesHandler).Methods("GET")
+
+	// Rotas administrativas (backup/restore/importação)
+	router.HandleFunc("/admin/backup", adminHandlers.BackupHandler).Methods("GET")
+	router.HandleFunc("/admin/restore", adminHandlers.RestoreHandler).Methods("POST")
+	router.HandleFunc("/admin/import/confluent", adminHandlers.ImportConfluentHandler).Methods("POST")
+
+	// Rotas de controle de acesso
+	router.HandleFunc("/acl", aclHandlers.ListACLHandler).Methods("GET")
+	router.HandleFunc("/acl", aclHandlers.CreateACLHandler).Methods("POST")
+	router.HandleFunc("/acl/{id}", aclHandlers.DeleteACLHandler).Methods("DELETE")
 }
 
 // healthCheckHandler manipula health checks
@@ -325,22 +776,38 @@ func demonstrateKVUsage(kv nats.KeyValue) {
 }
 
 // startServers inicia os servidores e aguarda shutdown
-func startServers(server *http.Server) {
-	// Canal para erros do servidor HTTP
-	serverErrors := make(chan error, 1)
+func startServers(server *http.Server, grpcServer *grpc.Server, grpcAddr string) {
+	// Canal para erros dos servidores HTTP e gRPC
+	serverErrors := make(chan error, 2)
 
 	// Iniciar servidor HTTP em goroutine
 	go func() {
 		log.Printf("Schema Registry server starting on %s", server.Addr)
+		if server.TLSConfig != nil {
+			// Certificados vêm de TLSConfig (recarregáveis)
+			serverErrors <- server.ListenAndServeTLS("", "")
+			return
+		}
 		serverErrors <- server.ListenAndServe()
 	}()
 
+	// Iniciar servidor gRPC em porta separada
+	go func() {
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			serverErrors <- err
+			return
+		}
+		log.Printf("gRPC server starting on %s", grpcAddr)
+		serverErrors <- grpcServer.Serve(listener)
+	}()
+
 	// Aguardar shutdown
-	waitForShutdown(server, serverErrors)
+	waitForShutdown(server, grpcServer, serverErrors)
 }
 
 // waitForShutdown gerencia o shutdown graceful
-func waitForShutdown(server *http.Server, serverErrors chan error) {
+func waitForShutdown(server *http.Server, grpcServer *grpc.Server, serverErrors chan error) {
 	// Canal para sinais do sistema operacional
 	osSignals := make(chan os.Signal, 1)
 	signal.Notify(osSignals, syscall.SIGINT, syscall.SIGTERM)
@@ -362,6 +829,9 @@ func waitForShutdown(server *http.Server, serverErrors chan error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
+	// Chamadas gRPC em andamento terminam antes do encerramento
+	grpcServer.GracefulStop()
+
 	if err := server.Shutdown(ctx); err != nil {
 		log.Printf("Server shutdown error: %v", err)
 	} else {
@@ -398,3 +868,22 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsBool obtém variável de ambiente como bool
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,502 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/audit"
+	"github.com/rodrigues-daniel/data-platform/internal/events"
+	"github.com/rodrigues-daniel/data-platform/internal/natstest"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"github.com/gorilla/mux"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// newTestRouter monta o router real sobre um NATS embutido efêmero
+func newTestRouter(t *testing.T) *mux.Router {
+	t.Helper()
+
+	registry, recorder, kv, js := newTestRegistry(t)
+	webhooks, deliveries := initializeWebhooks(js, kv)
+	srv := setupHTTPServer(registry, recorder, events.NewSource(js), webhooks, deliveries, nil, initializeACL(kv), nil)
+	return srv.Handler.(*mux.Router)
+}
+
+// newTestRegistry inicia um NATS embutido e o registry sobre ele
+func newTestRegistry(t *testing.T) (*schema.Registry, *audit.Recorder, nats.KeyValue, nats.JetStreamContext) {
+	t.Helper()
+
+	js := natstest.Start(t, natstest.Options{}).JS
+	kv, err := createOrGetKVBucket(js, "schemadb")
+	if err != nil {
+		t.Fatalf("failed to create kv bucket: %v", err)
+	}
+
+	recorder := initializeAudit(js)
+	return initializeRegistry(js, kv, recorder), recorder, kv, js
+}
+
+// openAPI especificação servida em /openapi.json
+type openAPI struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas   map[string]map[string]interface{} `json:"schemas"`
+		Responses map[string]map[string]interface{} `json:"responses"`
+	} `json:"components"`
+}
+
+type operation struct {
+	Responses map[string]map[string]interface{} `json:"responses"`
+}
+
+func loadSpec(t *testing.T, router *mux.Router) *openAPI {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("failed to fetch spec: %d", rec.Code)
+	}
+
+	var spec openAPI
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+	return &spec
+}
+
+func (s *openAPI) operation(path, method string) (*operation, bool) {
+	raw, ok := s.Paths[path][strings.ToLower(method)]
+	if !ok {
+		return nil, false
+	}
+	var op operation
+	if err := json.Unmarshal(raw, &op); err != nil {
+		return nil, false
+	}
+	return &op, true
+}
+
+func TestRoutesMatchOpenAPI(t *testing.T) {
+	router := newTestRouter(t)
+	spec := loadSpec(t, router)
+
+	registered := map[string]bool{}
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		// Subrouters (/v1 e rotas legadas) não têm handler próprio
+		if route.GetHandler() == nil {
+			return nil
+		}
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return fmt.Errorf("route %s has no methods: %w", path, err)
+		}
+		for _, method := range methods {
+			registered[method+" "+path] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+
+	documented := map[string]bool{}
+	for path, item := range spec.Paths {
+		for method := range item {
+			if method != "parameters" {
+				documented[strings.ToUpper(method)+" "+path] = true
+			}
+		}
+	}
+
+	for route := range registered {
+		if !documented[route] {
+			t.Errorf("route %s is not documented in openapi.json", route)
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			t.Errorf("openapi.json documents %s, which is not registered", route)
+		}
+	}
+}
+
+func TestResponsesMatchOpenAPI(t *testing.T) {
+	for _, prefix := range []string{"/v1", ""} {
+		t.Run("prefix="+prefix, func(t *testing.T) {
+			testResponsesMatchOpenAPI(t, prefix)
+		})
+	}
+}
+
+// testResponsesMatchOpenAPI percorre a API com o prefixo informado ("" = rotas legadas)
+func testResponsesMatchOpenAPI(t *testing.T, prefix string) {
+	router := newTestRouter(t)
+	spec := loadSpec(t, router)
+
+	// request executa a chamada e confere status, content type e corpo contra a especificação
+	request := func(method, path, contentType, body string, header http.Header, wantStatus int) (interface{}, http.Header) {
+		t.Helper()
+
+		business := path != "/health" && path != "/metrics" && path != "/docs"
+		if business {
+			path = prefix + path
+		}
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != wantStatus {
+			t.Fatalf("%s %s: expected status %d, got %d: %s", method, path, wantStatus, rec.Code, rec.Body.String())
+		}
+
+		var match mux.RouteMatch
+		if !router.Match(req, &match) {
+			t.Fatalf("%s %s: no route", method, path)
+		}
+		template, _ := match.Route.GetPathTemplate()
+		op, ok := spec.operation(template, method)
+		if !ok {
+			t.Fatalf("%s %s: operation not documented", method, template)
+		}
+
+		response, ok := op.Responses[fmt.Sprint(rec.Code)]
+		if !ok {
+			t.Fatalf("%s %s: status %d not documented", method, template, rec.Code)
+		}
+		if ref, ok := response["$ref"].(string); ok {
+			response = spec.Components.Responses[strings.TrimPrefix(ref, "#/components/responses/")]
+		}
+
+		if deprecated := rec.Header().Get("Deprecation") != ""; deprecated != (business && prefix == "") {
+			t.Errorf("%s %s: unexpected Deprecation header %q", method, path, rec.Header().Get("Deprecation"))
+		}
+
+		content, hasContent := response["content"].(map[string]interface{})
+		if !hasContent {
+			if rec.Body.Len() > 0 {
+				t.Errorf("%s %s: status %d is documented without body, got %q", method, template, rec.Code, rec.Body.String())
+			}
+			return nil, rec.Header()
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+		media, ok := content[mediaType].(map[string]interface{})
+		if !ok {
+			t.Fatalf("%s %s: content type %q not documented for status %d", method, template, mediaType, rec.Code)
+		}
+		if mediaType != "application/json" {
+			return rec.Body.String(), rec.Header()
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+			t.Fatalf("%s %s: invalid JSON response: %v", method, template, err)
+		}
+		schema, _ := media["schema"].(map[string]interface{})
+		if err := spec.validate(schema, decoded, "$"); err != nil {
+			t.Errorf("%s %s (%d): response does not match spec: %v", method, template, rec.Code, err)
+		}
+		return decoded, rec.Header()
+	}
+
+	call := func(method, path, contentType, body string, wantStatus int) interface{} {
+		t.Helper()
+		decoded, _ := request(method, path, contentType, body, nil, wantStatus)
+		return decoded
+	}
+
+	const jsonType = "application/json"
+	orderSchema := `{"subject":"orders","schema_type":"JSON","schema":{"type":"object","properties":{"id":{"type":"string"}}}}`
+
+	call("GET", "/health", "", "", http.StatusOK)
+	call("GET", "/metrics", "", "", http.StatusOK)
+	call("GET", "/docs", "", "", http.StatusOK)
+
+	// Schemas
+	call("POST", "/schemas/orders/versions", jsonType, orderSchema, http.StatusCreated)
+	call("POST", "/schemas/orders/versions", jsonType, "not json", http.StatusBadRequest)
+	call("POST", "/schemas/xml/versions", jsonType, `{"subject":"xml","schema_type":"XML","schema":{}}`, http.StatusUnprocessableEntity)
+	fetched, header := request("GET", "/schemas/orders/versions/1", "", "", nil, http.StatusOK)
+	embedded := fetched.(map[string]interface{})["data"].(map[string]interface{})["schema"]
+	if _, isObject := embedded.(map[string]interface{}); isObject != (prefix == "/v1") {
+		t.Errorf("unexpected schema encoding for prefix %q: %T", prefix, embedded)
+	}
+	if !strings.Contains(header.Get("Cache-Control"), "immutable") {
+		t.Errorf("expected immutable Cache-Control, got %q", header.Get("Cache-Control"))
+	}
+	etag := header.Get("ETag")
+	request("GET", "/schemas/orders/versions/1", "", "", http.Header{"If-None-Match": {etag}}, http.StatusNotModified)
+	request("GET", "/schemas/orders/versions/1", "", "", http.Header{"If-None-Match": {`"stale"`}}, http.StatusOK)
+
+	schemaID := fetched.(map[string]interface{})["data"].(map[string]interface{})["id"].(string)
+	request("GET", "/schemas/ids/"+schemaID, "", "", http.Header{"If-None-Match": {"W/" + etag}}, http.StatusNotModified)
+	call("GET", "/schemas/ids/"+schemaID, "", "", http.StatusOK)
+	call("GET", "/schemas/ids/missing", "", "", http.StatusNotFound)
+
+	_, header = request("GET", "/schemas/orders/versions/latest", "", "", nil, http.StatusOK)
+	if header.Get("Last-Modified") == "" || strings.Contains(header.Get("Cache-Control"), "immutable") {
+		t.Errorf("unexpected latest cache headers: %v", header)
+	}
+	request("GET", "/schemas/orders/versions/latest", "", "", http.Header{"If-Modified-Since": {header.Get("Last-Modified")}}, http.StatusNotModified)
+	call("GET", "/schemas/orders/versions/latest", "", "", http.StatusOK)
+	call("GET", "/schemas/orders/versions/9", "", "", http.StatusNotFound)
+	call("GET", "/schemas/orders/versions/first", "", "", http.StatusUnprocessableEntity)
+	call("GET", "/schemas/missing/versions/1", "", "", http.StatusNotFound)
+
+	// Subjects
+	call("GET", "/subjects", "", "", http.StatusOK)
+	call("GET", "/subjects?prefix=ord&type=json&order=desc&limit=1", "", "", http.StatusOK)
+	call("GET", "/subjects?verbose=true&deleted=include", "", "", http.StatusOK)
+	call("GET", "/subjects?deleted=maybe", "", "", http.StatusBadRequest)
+	call("GET", "/subjects?label=team", "", "", http.StatusBadRequest)
+	call("GET", "/subjects/orders/versions", "", "", http.StatusOK)
+	call("GET", "/subjects/orders/versions?order=desc&limit=1", "", "", http.StatusOK)
+	call("GET", "/subjects/orders/versions?after=-1", "", "", http.StatusBadRequest)
+	call("GET", "/subjects/missing/versions", "", "", http.StatusNotFound)
+
+	// Configuração e modo
+	call("PUT", "/config/orders", jsonType, `{"compatibility":"NONE","max_versions":10}`, http.StatusOK)
+	call("PUT", "/config/orders", jsonType, `{"compatibility":"SOMETIMES"}`, http.StatusUnprocessableEntity)
+	call("PUT", "/config/orders", jsonType, "{", http.StatusBadRequest)
+	call("GET", "/config/orders", "", "", http.StatusOK)
+	call("DELETE", "/config/orders", "", "", http.StatusOK)
+	call("PUT", "/mode/orders", jsonType, `{"mode":"READONLY"}`, http.StatusOK)
+	call("POST", "/schemas/orders/versions", jsonType, orderSchema, http.StatusUnprocessableEntity)
+	call("PUT", "/mode/orders", jsonType, `{"mode":"WRITEONLY"}`, http.StatusUnprocessableEntity)
+	call("GET", "/mode/orders", "", "", http.StatusOK)
+	call("DELETE", "/mode/orders", "", "", http.StatusOK)
+
+	// Compatibilidade e validação
+	call("POST", "/compatibility/subjects/orders/versions", jsonType, `{"schema":"{\"type\":\"object\"}"}`, http.StatusOK)
+	call("POST", "/compatibility/subjects/orders/versions", jsonType, "[", http.StatusBadRequest)
+	call("POST", "/validate/orders", jsonType, `{"data":{"id":"1"}}`, http.StatusOK)
+	call("POST", "/validate/missing", jsonType, `{"data":{}}`, http.StatusNotFound)
+
+	// Lote
+	paymentSchema := `{"subject":"payments","schema_type":"JSON","schema":{"type":"object"}}`
+	invalidSchema := `{"subject":"refunds","schema_type":"JSON","schema":"{"}`
+	call("POST", "/batch/register", jsonType, `{"atomic":true,"schemas":[`+paymentSchema+`,`+invalidSchema+`]}`, http.StatusOK)
+	call("GET", "/schemas/payments/versions/latest", "", "", http.StatusNotFound)
+	call("POST", "/batch/register", jsonType, `{"schemas":[`+paymentSchema+`,`+invalidSchema+`]}`, http.StatusOK)
+	call("POST", "/batch/register", jsonType, `{"schemas":[]}`, http.StatusBadRequest)
+	call("POST", "/batch/get", jsonType, `{"items":[{"subject":"payments"},{"subject":"orders","version":1},{"subject":"missing"}]}`, http.StatusOK)
+	call("POST", "/batch/get", jsonType, "{", http.StatusBadRequest)
+	call("POST", "/batch/compatibility", jsonType, `{"items":[{"subject":"payments","schema":{"type":"object"}},{"subject":"missing","schema":{}}]}`, http.StatusOK)
+
+	// Auditoria
+	call("GET", "/audit?subject=orders&limit=10", "", "", http.StatusOK)
+	call("GET", "/audit?limit=zero", "", "", http.StatusBadRequest)
+
+	// Administração
+	archive := call("GET", "/admin/backup", "", "", http.StatusOK).(string)
+	call("POST", "/admin/restore", "application/x-ndjson", archive, http.StatusBadRequest)
+	call("POST", "/admin/restore?resume=true", "application/x-ndjson", archive, http.StatusOK)
+	call("POST", "/admin/restore", "application/x-ndjson", strings.Join(strings.Split(archive, "\n")[:2], "\n"), http.StatusBadRequest)
+	call("POST", "/admin/import/confluent", "text/plain", "[]", http.StatusOK)
+
+	// Controle de acesso
+	created := call("POST", "/acl", jsonType, `{"principal":"ci","role":"writer","pattern":"orders*"}`, http.StatusCreated)
+	id := created.(map[string]interface{})["data"].(map[string]interface{})["id"].(string)
+	call("POST", "/acl", jsonType, `{"principal":"ci","role":"owner","pattern":"*"}`, http.StatusBadRequest)
+	call("GET", "/acl?principal=ci", "", "", http.StatusOK)
+	call("DELETE", "/acl/"+id, "", "", http.StatusOK)
+	call("DELETE", "/acl/"+id, "", "", http.StatusNotFound)
+
+	// Remoção: soft delete e depois definitiva
+	call("DELETE", "/schemas/orders/versions/1?permanent=true", "", "", http.StatusConflict)
+	call("DELETE", "/schemas/orders/versions/1", "", "", http.StatusOK)
+	call("DELETE", "/schemas/orders/versions/1", "", "", http.StatusNotFound)
+	call("GET", "/schemas/orders/versions/1", "", "", http.StatusNotFound)
+	call("GET", "/schemas/orders/versions/1?deleted=true", "", "", http.StatusOK)
+	call("GET", "/subjects/orders/versions", "", "", http.StatusNotFound)
+	call("GET", "/subjects/orders/versions?deleted=only", "", "", http.StatusOK)
+	call("GET", "/subjects?deleted=only&verbose=true", "", "", http.StatusOK)
+	call("DELETE", "/schemas/orders/versions/1?permanent=maybe", "", "", http.StatusBadRequest)
+	call("DELETE", "/schemas/orders/versions/1?permanent=true", "", "", http.StatusOK)
+	call("DELETE", "/schemas/orders/versions/1?permanent=true", "", "", http.StatusNotFound)
+}
+
+// validate confere um valor contra o subconjunto de JSON Schema usado na especificação.
+// Objetos com properties são fechados: campos não documentados são rejeitados.
+func (s *openAPI) validate(schema map[string]interface{}, value interface{}, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		return s.validate(s.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")], value, path)
+	}
+
+	if variants, ok := schema["oneOf"].([]interface{}); ok {
+		matches := 0
+		for _, variant := range variants {
+			if s.validate(variant.(map[string]interface{}), value, path) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return fmt.Errorf("%s: value matches %d of the oneOf variants", path, matches)
+		}
+	}
+
+	if expected, ok := schema["const"]; ok && !reflect.DeepEqual(expected, value) {
+		return fmt.Errorf("%s: expected %v, got %v", path, expected, value)
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, candidate := range enum {
+			if reflect.DeepEqual(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+		}
+	}
+
+	typ, ok := schema["type"].(string)
+	if !ok {
+		return nil
+	}
+
+	switch typ {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, value)
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return fmt.Errorf("%s: expected integer, got %v", path, value)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, value)
+		}
+		itemSchema, _ := schema["items"].(map[string]interface{})
+		for i, item := range items {
+			if err := s.validate(itemSchema, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, value)
+		}
+		return s.validateObject(schema, object, path)
+	}
+	return nil
+}
+
+func (s *openAPI) validateObject(schema map[string]interface{}, object map[string]interface{}, path string) error {
+	required, _ := schema["required"].([]interface{})
+	for _, name := range required {
+		if _, ok := object[name.(string)]; !ok {
+			return fmt.Errorf("%s: missing required field %q", path, name)
+		}
+	}
+
+	properties, hasProperties := schema["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := path + "." + key
+		if property, ok := properties[key].(map[string]interface{}); ok {
+			if err := s.validate(property, object[key], fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case map[string]interface{}:
+			if err := s.validate(additional, object[key], fieldPath); err != nil {
+				return err
+			}
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s: undocumented field", fieldPath)
+			}
+		default:
+			if hasProperties {
+				return fmt.Errorf("%s: undocumented field", fieldPath)
+			}
+		}
+	}
+	return nil
+}
+
+func TestNATSAuthorization(t *testing.T) {
+	var internalUser nats.Option
+	env := natstest.Start(t, natstest.Options{Server: func(opts *server.Options) {
+		internalUser = configureNATSAuthorization(opts, true)
+	}})
+
+	connect := func(opts ...nats.Option) nats.JetStreamContext {
+		nc, err := nats.Connect(env.Server.ClientURL(), opts...)
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		t.Cleanup(nc.Close)
+		js, err := nc.JetStream(nats.MaxWait(time.Second))
+		if err != nil {
+			t.Fatalf("failed to get jetstream: %v", err)
+		}
+		return js
+	}
+
+	internal := connect(internalUser)
+	kv, err := createOrGetKVBucket(internal, "schemadb")
+	if err != nil {
+		t.Fatalf("failed to create kv bucket: %v", err)
+	}
+	if _, err := kv.PutString("auth.apikeys.internal", "{}"); err != nil {
+		t.Fatalf("server connection should write to the bucket: %v", err)
+	}
+	if err := createOrUpdateStream(internal, schema.EventStreamConfig(schema.EventRetention{})); err != nil {
+		t.Fatalf("failed to create event stream: %v", err)
+	}
+
+	client := connect()
+	if _, err := client.Publish("$KV.schemadb.auth.apikeys.forged", []byte("{}")); err == nil {
+		t.Error("anonymous client should not write to the schemadb bucket")
+	}
+	if _, err := client.Publish(audit.SubjectPrefix+"payments", []byte("{}")); err == nil {
+		t.Error("anonymous client should not write to the audit stream")
+	}
+	if err := client.PurgeStream("KV_schemadb"); err == nil {
+		t.Error("anonymous client should not purge the schemadb bucket")
+	}
+	if _, err := client.PullSubscribe(schema.EventSubjectPrefix+">", "reader", nats.BindStream(schema.EventStreamName)); err != nil {
+		t.Errorf("anonymous client should consume schema events: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/dtos"
+)
+
+func TestNormalizedRegistration(t *testing.T) {
+	router := newTestRouter(t)
+
+	do := func(method, path, body string) (int, dtos.SchemaResponse) {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		var response struct {
+			Data dtos.SchemaResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	original := `{"type":"record","name":"User","namespace":"com.acme","fields":[{"name":"id","type":"string"}]}`
+	cosmetic := `{ "name": "com.acme.User", "type": "record", "fields": [ {"type": {"type": "string"}, "name": "id"} ] }`
+
+	status, first := do("POST", "/v1/schemas/users/versions?normalize=true", `{"subject":"users","schema_type":"AVRO","schema":`+original+`}`)
+	if status != http.StatusCreated || first.Version != 1 || len(first.NormalizedSchema) == 0 {
+		t.Fatalf("register failed: %d %+v", status, first)
+	}
+	if string(first.Schema) != original {
+		t.Errorf("original schema not preserved: %s", first.Schema)
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		wantStatus  int
+		wantVersion int
+	}{
+		{
+			name:        "should return existing version for cosmetic changes",
+			method:      "POST",
+			path:        "/v1/schemas/users/versions?normalize=true",
+			body:        `{"subject":"users","schema_type":"AVRO","schema":` + cosmetic + `}`,
+			wantStatus:  http.StatusCreated,
+			wantVersion: 1,
+		},
+		{
+			name:       "should find cosmetic variant with normalized lookup",
+			method:     "POST",
+			path:       "/v1/subjects/users/lookup?normalize=true",
+			body:       `{"schema_type":"AVRO","schema":` + cosmetic + `}`,
+			wantStatus: http.StatusOK, wantVersion: 1,
+		},
+		{
+			name:       "should require exact content without normalize",
+			method:     "POST",
+			path:       "/v1/subjects/users/lookup",
+			body:       `{"schema_type":"AVRO","schema":` + cosmetic + `}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "should find exact content without normalize",
+			method:     "POST",
+			path:       "/v1/subjects/users/lookup",
+			body:       `{"schema_type":"AVRO","schema":` + original + `}`,
+			wantStatus: http.StatusOK, wantVersion: 1,
+		},
+		{
+			name:       "should reject content that cannot be normalized",
+			method:     "POST",
+			path:       "/v1/subjects/users/lookup?normalize=true",
+			body:       `{"schema_type":"AVRO","schema":{"type":"record","fields":[]}}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "should register new version for real changes",
+			method:      "POST",
+			path:        "/v1/schemas/users/versions?normalize=true",
+			body:        `{"subject":"users","schema_type":"AVRO","schema":{"type":"record","name":"com.acme.User","fields":[{"name":"id","type":"string"},{"name":"age","type":"int","default":0}]}}`,
+			wantStatus:  http.StatusCreated,
+			wantVersion: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, schema := do(tt.method, tt.path, tt.body)
+			if status != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, status)
+			}
+			if tt.wantVersion != 0 && schema.Version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", schema.Version, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/webhook"
+)
+
+func TestWebhookDelivery(t *testing.T) {
+	received := make(chan *http.Request, 4)
+	bodies := make(chan []byte, 4)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	defer target.Close()
+
+	t.Setenv("WEBHOOK_ALLOWED_NETWORKS", "127.0.0.1")
+	server := httptest.NewServer(newTestRouter(t))
+	defer server.Close()
+
+	do := func(method, path, body string) (int, models.SchemaResponse) {
+		t.Helper()
+
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		defer resp.Body.Close()
+
+		var response models.SchemaResponse
+		json.NewDecoder(resp.Body).Decode(&response)
+		return resp.StatusCode, response
+	}
+
+	status, response := do("POST", "/v1/webhooks", `{"url":"`+target.URL+`","subjects":"orders*","event_types":["SCHEMA_CREATED"],"secret":"s3cret"}`)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", status, response.Error)
+	}
+	created := response.Data.(map[string]interface{})
+	id := created["id"].(string)
+	if _, ok := created["secret"]; ok {
+		t.Error("secret must not be returned")
+	}
+
+	// Sem secret no pedido, o gerado é retornado uma única vez
+	status, response = do("POST", "/v1/webhooks", `{"url":"`+target.URL+`","subjects":"none"}`)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", status, response.Error)
+	}
+	generated := response.Data.(map[string]interface{})
+	if secret, _ := generated["secret"].(string); len(secret) != 64 {
+		t.Errorf("expected generated secret in create response, got %v", generated["secret"])
+	}
+	if _, response := do("GET", "/v1/webhooks/"+generated["id"].(string), ""); response.Data.(map[string]interface{})["secret"] != nil {
+		t.Error("generated secret must not be returned after creation")
+	}
+
+	if status, _ := do("POST", "/v1/webhooks", `{"url":"http://10.0.0.1/hook"}`); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for private network url, got %d", status)
+	}
+	if status, _ := do("POST", "/v1/webhooks", `{"url":"not a url"}`); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid url, got %d", status)
+	}
+	if status, _ := do("GET", "/v1/webhooks/unknown", ""); status != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown webhook, got %d", status)
+	}
+
+	register := `{"subject":"orders","schema_type":"JSON","schema":{"type":"object"}}`
+	if status, response := do("POST", "/v1/schemas/orders/versions", register); status != http.StatusCreated {
+		t.Fatalf("register failed: %d %s", status, response.Error)
+	}
+
+	select {
+	case req := <-received:
+		body := <-bodies
+		timestamp, _ := strconv.ParseInt(req.Header.Get(webhook.TimestampHeader), 10, 64)
+		if req.Header.Get(webhook.SignatureHeader) != webhook.Sign("s3cret", timestamp, body) {
+			t.Error("invalid delivery signature")
+		}
+		var event models.SchemaEvent
+		json.Unmarshal(body, &event)
+		if event.Type != models.EventSchemaCreated || event.Subject != "orders" {
+			t.Errorf("unexpected event: %s", body)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for webhook delivery")
+	}
+
+	// O log de entregas registra a tentativa
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		status, response := do("GET", "/v1/webhooks/"+id+"/deliveries", "")
+		if status != http.StatusOK {
+			t.Fatalf("expected 200, got %d", status)
+		}
+		deliveries := response.Data.(map[string]interface{})["deliveries"].([]interface{})
+		if len(deliveries) == 1 {
+			if got := deliveries[0].(map[string]interface{})["status"]; got != models.DeliverySuccess {
+				t.Errorf("expected successful delivery, got %v", got)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 1 delivery, got %d", len(deliveries))
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if status, _ := do("DELETE", "/v1/webhooks/"+id, ""); status != http.StatusOK {
+		t.Errorf("expected 200 on delete, got %d", status)
+	}
+}
@@ -0,0 +1,275 @@
+package acl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	OpRead     = "read"
+	OpRegister = "register"
+	OpDelete   = "delete"
+	OpConfig   = "config"
+	OpMode     = "mode"
+	// OpAdmin operações globais: backup, restore, importação, ACLs
+	OpAdmin = "admin"
+
+	RoleReader = "reader"
+	RoleWriter = "writer"
+	RoleAdmin  = "admin"
+
+	keyPrefix = "acl.bindings."
+)
+
+// roleOperations operações concedidas por cada papel
+var roleOperations = map[string][]string{
+	RoleReader: {OpRead},
+	RoleWriter: {OpRead, OpRegister},
+	RoleAdmin:  {OpRead, OpRegister, OpDelete, OpConfig, OpMode, OpAdmin},
+}
+
+// DeniedError acesso negado, com o motivo exposto ao cliente
+type DeniedError struct {
+	Principal string
+	Operation string
+	Subject   string
+}
+
+func (e *DeniedError) Error() string {
+	if e.Subject == "" {
+		return fmt.Sprintf("principal %s is not allowed to perform %s operations", e.Principal, e.Operation)
+	}
+	return fmt.Sprintf("principal %s is not allowed to %s on subject %s", e.Principal, e.Operation, e.Subject)
+}
+
+// Store mantém os bindings persistidos no KV, com cópia em memória atualizada via watcher
+type Store struct {
+	kv       nats.KeyValue
+	mu       sync.RWMutex
+	bindings map[string]models.ACLBinding
+	static   []models.ACLBinding
+}
+
+func NewStore(kv nats.KeyValue) *Store {
+	return &Store{kv: kv, bindings: make(map[string]models.ACLBinding)}
+}
+
+// Start carrega os bindings e acompanha alterações feitas por outras instâncias
+func (s *Store) Start(ctx context.Context) error {
+	watcher, err := s.kv.Watch(keyPrefix + ">")
+	if err != nil {
+		return fmt.Errorf("failed to watch acl bindings: %w", err)
+	}
+
+	initial := make(chan struct{})
+	go func() {
+		defer watcher.Stop()
+		loaded := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case entry, ok := <-watcher.Updates():
+				if !ok {
+					return
+				}
+				if entry == nil {
+					if !loaded {
+						loaded = true
+						close(initial)
+					}
+					continue
+				}
+				s.apply(entry)
+			}
+		}
+	}()
+
+	select {
+	case <-initial:
+		return nil
+	case <-time.After(10 * time.Second):
+		return fmt.Errorf("timeout loading acl bindings")
+	}
+}
+
+func (s *Store) apply(entry nats.KeyValueEntry) {
+	id := strings.TrimPrefix(entry.Key(), keyPrefix)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry.Operation() != nats.KeyValuePut {
+		delete(s.bindings, id)
+		return
+	}
+
+	var binding models.ACLBinding
+	if err := json.Unmarshal(entry.Value(), &binding); err != nil {
+		log.Printf("Warning: invalid acl binding %s: %v", id, err)
+		return
+	}
+	s.bindings[id] = binding
+}
+
+// Bootstrap concede papel admin global aos principais informados (não persistido)
+func (s *Store) Bootstrap(admins string) {
+	for _, name := range strings.Split(admins, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			s.static = append(s.static, models.ACLBinding{Principal: name, Role: RoleAdmin, Pattern: "*"})
+		}
+	}
+}
+
+// Create persiste um novo binding
+func (s *Store) Create(binding *models.ACLBinding) (*models.ACLBinding, error) {
+	if binding.Principal == "" {
+		return nil, fmt.Errorf("principal is required")
+	}
+	if _, ok := roleOperations[binding.Role]; !ok {
+		return nil, fmt.Errorf("invalid role: %s", binding.Role)
+	}
+	if binding.Pattern == "" {
+		return nil, fmt.Errorf("pattern is required")
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate id: %w", err)
+	}
+	binding.ID = hex.EncodeToString(id)
+	binding.CreatedAt = time.Now()
+
+	data, err := json.Marshal(binding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal acl binding: %w", err)
+	}
+	if _, err := s.kv.Put(keyPrefix+binding.ID, data); err != nil {
+		return nil, fmt.Errorf("failed to save acl binding: %w", err)
+	}
+
+	s.mu.Lock()
+	s.bindings[binding.ID] = *binding
+	s.mu.Unlock()
+
+	return binding, nil
+}
+
+// Delete remove um binding
+func (s *Store) Delete(id string) error {
+	s.mu.RLock()
+	_, ok := s.bindings[id]
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("acl binding not found: %s", id)
+	}
+
+	if err := s.kv.Delete(keyPrefix + id); err != nil {
+		return fmt.Errorf("failed to delete acl binding: %w", err)
+	}
+
+	s.mu.Lock()
+	delete(s.bindings, id)
+	s.mu.Unlock()
+	return nil
+}
+
+// List lista os bindings, opcionalmente de um único principal
+func (s *Store) List(principal string) []models.ACLBinding {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []models.ACLBinding{}
+	for _, binding := range s.bindings {
+		if principal == "" || binding.Principal == principal {
+			result = append(result, binding)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Principal != result[j].Principal {
+			return result[i].Principal < result[j].Principal
+		}
+		return result[i].Pattern < result[j].Pattern
+	})
+	return result
+}
+
+// Authorize verifica se o principal pode executar a operação no subject.
+// Operações globais (subject vazio) exigem um binding com padrão "*".
+func (s *Store) Authorize(principal *auth.Principal, operation, subject string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, binding := range s.static {
+		if allows(binding, principal.Name, operation, subject) {
+			return nil
+		}
+	}
+	for _, binding := range s.bindings {
+		if allows(binding, principal.Name, operation, subject) {
+			return nil
+		}
+	}
+
+	return &DeniedError{Principal: principal.Name, Operation: operation, Subject: subject}
+}
+
+func allows(binding models.ACLBinding, principal, operation, subject string) bool {
+	if binding.Principal != principal {
+		return false
+	}
+
+	granted := false
+	for _, op := range roleOperations[binding.Role] {
+		if op == operation {
+			granted = true
+			break
+		}
+	}
+	if !granted {
+		return false
+	}
+
+	if subject == "" {
+		return binding.Pattern == "*"
+	}
+	return MatchPattern(binding.Pattern, subject)
+}
+
+// MatchPattern compara um subject com um padrão onde "*" casa qualquer sequência
+func MatchPattern(pattern, subject string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == subject
+	}
+
+	if !strings.HasPrefix(subject, parts[0]) {
+		return false
+	}
+	subject = subject[len(parts[0]):]
+
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(subject, part)
+		}
+		idx := strings.Index(subject, part)
+		if idx < 0 {
+			return false
+		}
+		subject = subject[idx+len(part):]
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package acl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		subject string
+		want    bool
+	}{
+		{"*", "payments.order", true},
+		{"payments.*", "payments.order", true},
+		{"payments.*", "payments", false},
+		{"payments.*", "billing.payments.order", false},
+		{"*.order", "payments.order", true},
+		{"payments.*.v1", "payments.order.v1", true},
+		{"payments.*.v1", "payments.order.v2", false},
+		{"payments.order", "payments.order", true},
+		{"payments.order", "payments.orders", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.subject, func(t *testing.T) {
+			if got := MatchPattern(tt.pattern, tt.subject); got != tt.want {
+				t.Errorf("MatchPattern(%q, %q) = %v, want %v", tt.pattern, tt.subject, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	store := NewStore(nil)
+	store.Bootstrap("root")
+	store.bindings["1"] = models.ACLBinding{ID: "1", Principal: "payments-ci", Role: RoleWriter, Pattern: "payments.*"}
+	store.bindings["2"] = models.ACLBinding{ID: "2", Principal: "analytics", Role: RoleReader, Pattern: "*"}
+
+	tests := []struct {
+		name      string
+		principal string
+		operation string
+		subject   string
+		want      bool
+	}{
+		{"writer should register on own prefix", "payments-ci", OpRegister, "payments.order", true},
+		{"writer should not register on other prefix", "payments-ci", OpRegister, "billing.invoice", false},
+		{"writer should not delete", "payments-ci", OpDelete, "payments.order", false},
+		{"writer should not change config", "payments-ci", OpConfig, "payments.order", false},
+		{"reader should read any subject", "analytics", OpRead, "billing.invoice", true},
+		{"reader should not register", "analytics", OpRegister, "billing.invoice", false},
+		{"reader should not perform admin operations", "analytics", OpAdmin, "", false},
+		{"scoped binding should not grant global operations", "payments-ci", OpRead, "", false},
+		{"bootstrap admin should perform admin operations", "root", OpAdmin, "", true},
+		{"bootstrap admin should change mode", "root", OpMode, "payments.order", true},
+		{"unknown principal should be denied", "nobody", OpRead, "payments.order", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := store.Authorize(&auth.Principal{Name: tt.principal}, tt.operation, tt.subject)
+			if tt.want {
+				if err != nil {
+					t.Errorf("expected access, got %v", err)
+				}
+				return
+			}
+
+			var denied *DeniedError
+			if !errors.As(err, &denied) {
+				t.Fatalf("expected DeniedError, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+type ACLHandlers struct {
+	store *acl.Store
+}
+
+func NewACLHandlers(store *acl.Store) *ACLHandlers {
+	return &ACLHandlers{store: store}
+}
+
+// ListACLHandler lista os bindings (?principal=)
+func (h *ACLHandlers) ListACLHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.store, acl.OpAdmin, "") {
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, h.store.List(r.URL.Query().Get("principal")))
+}
+
+// CreateACLHandler concede um papel a um principal sobre um padrão de subjects
+func (h *ACLHandlers) CreateACLHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.store, acl.OpAdmin, "") {
+		return
+	}
+
+	var binding models.ACLBinding
+	if err := json.NewDecoder(r.Body).Decode(&binding); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	created, err := h.store.Create(&binding)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, created)
+}
+
+// DeleteACLHandler remove um binding
+func (h *ACLHandlers) DeleteACLHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.store, acl.OpAdmin, "") {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if err := h.store.Delete(id); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, id)
+}
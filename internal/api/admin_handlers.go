@@ -0,0 +1,87 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/backup"
+	"github.com/rodrigues-daniel/data-platform/internal/confluent"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+)
+
+type AdminHandlers struct {
+	registry   *schema.Registry
+	authorizer Authorizer
+}
+
+func NewAdminHandlers(registry *schema.Registry) *AdminHandlers {
+	return &AdminHandlers{registry: registry}
+}
+
+// SetAuthorizer habilita o controle de acesso
+func (h *AdminHandlers) SetAuthorizer(authorizer Authorizer) {
+	h.authorizer = authorizer
+}
+
+// BackupHandler exporta o registry inteiro em NDJSON
+func (h *AdminHandlers) BackupHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, acl.OpAdmin, "") {
+		return
+	}
+
+	// O export pode exceder o WriteTimeout do servidor
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("schema-registry-%s.ndjson", time.Now().UTC().Format("20060102T150405Z"))
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	// Com o corpo já iniciado, falhas só podem ser registradas no log; o arquivo fica sem
+	// trailer e é rejeitado pelo restore
+	if err := backup.Export(r.Context(), h.registry, w); err != nil {
+		log.Printf("Backup failed: %v", err)
+	}
+}
+
+// RestoreHandler restaura um backup NDJSON em um registry vazio; ?resume=true retoma uma
+// restauração interrompida
+func (h *AdminHandlers) RestoreHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, acl.OpAdmin, "") {
+		return
+	}
+
+	resume, _ := strconv.ParseBool(r.URL.Query().Get("resume"))
+	report, err := backup.Restore(r.Context(), h.registry, r.Body, resume)
+	if err != nil {
+		writeRegistryError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, report)
+}
+
+// ImportConfluentHandler importa um export do Confluent Schema Registry preservando IDs e versões
+func (h *AdminHandlers) ImportConfluentHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, acl.OpAdmin, "") {
+		return
+	}
+
+	report, err := confluent.Import(r.Context(), h.registry, r.Body)
+	if err != nil {
+		writeRegistryError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, report)
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/audit"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+type AuditHandlers struct {
+	recorder   *audit.Recorder
+	authorizer Authorizer
+}
+
+func NewAuditHandlers(recorder *audit.Recorder) *AuditHandlers {
+	return &AuditHandlers{recorder: recorder}
+}
+
+// SetAuthorizer habilita o controle de acesso
+func (h *AuditHandlers) SetAuthorizer(authorizer Authorizer) {
+	h.authorizer = authorizer
+}
+
+// ListAuditHandler lista o trail de auditoria (?subject=&since=&after=&limit=)
+func (h *AuditHandlers) ListAuditHandler(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := models.AuditQuery{Subject: params.Get("subject")}
+	if query.Subject != "" {
+		if err := audit.ValidateSubject(query.Subject); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Trail de um subject exige leitura do subject; o trail completo exige admin
+	operation := acl.OpRead
+	if query.Subject == "" {
+		operation = acl.OpAdmin
+	}
+	if !authorize(w, r, h.authorizer, operation, query.Subject) {
+		return
+	}
+
+	if since := params.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid since, expected RFC3339 timestamp")
+			return
+		}
+		query.Since = parsed
+	}
+
+	if after := params.Get("after"); after != "" {
+		cursor, err := strconv.ParseUint(after, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid after cursor")
+			return
+		}
+		query.After = cursor
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		query.Limit = parsed
+	}
+
+	page, err := h.recorder.Query(r.Context(), query)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, page)
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/dtos"
+	"github.com/rodrigues-daniel/data-platform/internal/mappers"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+)
+
+// maxBatchItems itens aceitos por requisição em lote
+const maxBatchItems = 1000
+
+// BatchRegisterHandler registra vários schemas; com atomic, registra todos ou nenhum
+func (h *Handlers) BatchRegisterHandler(w http.ResponseWriter, r *http.Request) {
+	var req dtos.BatchRegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !validBatchSize(w, len(req.Schemas)) {
+		return
+	}
+
+	schemas := make([]*models.Schema, len(req.Schemas))
+	subjects := make([]string, len(req.Schemas))
+	for i, item := range req.Schemas {
+		schema := mappers.MapCreateSchemaRequestToModel(item)
+		schemas[i] = &schema
+		subjects[i] = item.Subject
+	}
+
+	// Itens sem permissão não são processados; em lote atômico, abortam o lote inteiro
+	results := make([]schema.BatchResult, len(schemas))
+	pending := make([]int, 0, len(schemas))
+	forbidden := false
+	for i, subject := range subjects {
+		if !allowed(r, h.authorizer, acl.OpRegister, subject) {
+			results[i].Err = forbiddenItem(subject)
+			forbidden = true
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	if forbidden && req.Atomic {
+		for _, i := range pending {
+			results[i].Err = fmt.Errorf("%w: other items are not permitted", schema.ErrBatchAborted)
+		}
+	} else {
+		batch := make([]*models.Schema, len(pending))
+		for j, i := range pending {
+			batch[j] = schemas[i]
+		}
+		for j, result := range h.registry.RegisterBatch(r.Context(), batch, req.Atomic) {
+			results[pending[j]] = result
+		}
+	}
+
+	h.sendSuccess(w, http.StatusOK, h.batchResponse(r, subjects, results, http.StatusCreated))
+}
+
+// BatchGetHandler obtém várias versões (version omitida = latest)
+func (h *Handlers) BatchGetHandler(w http.ResponseWriter, r *http.Request) {
+	var req dtos.BatchGetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !validBatchSize(w, len(req.Items)) {
+		return
+	}
+
+	subjects := make([]string, len(req.Items))
+	refs := make([]schema.VersionRef, 0, len(req.Items))
+	positions := make([]int, 0, len(req.Items))
+	results := make([]schema.BatchResult, len(req.Items))
+	for i, item := range req.Items {
+		subjects[i] = item.Subject
+		if !allowed(r, h.authorizer, acl.OpRead, item.Subject) {
+			results[i].Err = forbiddenItem(item.Subject)
+			continue
+		}
+		refs = append(refs, schema.VersionRef{Subject: item.Subject, Version: item.Version})
+		positions = append(positions, i)
+	}
+
+	for j, result := range h.registry.GetBatch(r.Context(), refs) {
+		results[positions[j]] = result
+	}
+
+	h.sendSuccess(w, http.StatusOK, h.batchResponse(r, subjects, results, http.StatusOK))
+}
+
+// BatchCompatibilityHandler verifica vários schemas contra a última versão de seus subjects
+func (h *Handlers) BatchCompatibilityHandler(w http.ResponseWriter, r *http.Request) {
+	var req dtos.BatchCompatibilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !validBatchSize(w, len(req.Items)) {
+		return
+	}
+
+	subjects := make([]string, len(req.Items))
+	schemas := make([]*models.Schema, 0, len(req.Items))
+	positions := make([]int, 0, len(req.Items))
+	results := make([]schema.BatchResult, len(req.Items))
+	for i, item := range req.Items {
+		subjects[i] = item.Subject
+		if !allowed(r, h.authorizer, acl.OpRead, item.Subject) {
+			results[i].Err = forbiddenItem(item.Subject)
+			continue
+		}
+		schemas = append(schemas, mappers.MapCompatibilityCheckRequestToSchema(item.Subject,
+			dtos.CompatibilityCheckRequest{SchemaType: item.SchemaType, Schema: item.Schema}))
+		positions = append(positions, i)
+	}
+
+	for j, result := range h.registry.CheckCompatibilityBatch(r.Context(), schemas) {
+		results[positions[j]] = result
+	}
+
+	h.sendSuccess(w, http.StatusOK, h.batchResponse(r, subjects, results, http.StatusOK))
+}
+
+// batchResponse converte os resultados do registry no formato da API, item a item
+func (h *Handlers) batchResponse(r *http.Request, subjects []string, results []schema.BatchResult, successStatus int) dtos.BatchResponse {
+	response := dtos.BatchResponse{Results: make([]dtos.BatchItemResult, len(results))}
+	for i, result := range results {
+		item := dtos.BatchItemResult{Index: i, Subject: subjects[i]}
+
+		switch {
+		case result.Err != nil:
+			status, code, details := ClassifyError(result.Err, http.StatusInternalServerError)
+			item.Status, item.ErrorCode, item.Details = status, code, details
+			item.Error = result.Err.Error()
+			response.Failed++
+		case result.Compatibility != nil:
+			item.Status = successStatus
+			item.Data = result.Compatibility
+			if !isLegacy(r) {
+				item.Data = mappers.MapCompatibilityResult(result.Compatibility, false)
+			}
+			response.Succeeded++
+		default:
+			item.Status = successStatus
+			item.Data = schemaResponse(r, result.Schema)
+			response.Succeeded++
+		}
+
+		response.Results[i] = item
+	}
+	return response
+}
+
+// forbiddenItem item de lote que o principal não pode executar
+func forbiddenItem(subject string) error {
+	return fmt.Errorf("%w on subject %s", errForbidden, subject)
+}
+
+// validBatchSize rejeita lotes vazios ou acima do limite
+func validBatchSize(w http.ResponseWriter, size int) bool {
+	if size == 0 || size > maxBatchItems {
+		writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeBadRequest,
+			fmt.Sprintf("Batch must contain between 1 and %d items", maxBatchItems),
+			map[string]interface{}{"items": size, "max_items": maxBatchItems})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// immutableMaxAge validade de versões numeradas e buscas por ID, que não mudam após o registro
+const immutableMaxAge = 365 * 24 * time.Hour
+
+// schemaETag ETag forte derivado do fingerprint. Inclui ID, versão e estado de remoção para
+// que "latest" e leituras com ?deleted=true mudem de ETag quando a representação muda.
+func schemaETag(schema *models.Schema) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d\x00%t", schema.Fingerprint(), schema.ID, schema.Version, schema.Deleted)))
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// writeCacheHeaders define ETag, Cache-Control e, para "latest", Last-Modified. Retorna true
+// quando a representação do cliente ainda é atual e a resposta 304 já foi enviada.
+func writeCacheHeaders(w http.ResponseWriter, r *http.Request, schema *models.Schema, latest bool, latestMaxAge time.Duration) bool {
+	etag := schemaETag(schema)
+
+	// Respostas autenticadas não podem ser servidas por caches compartilhados a outros clientes
+	visibility := "public"
+	if _, ok := auth.PrincipalFromContext(r.Context()); ok {
+		visibility = "private"
+	}
+
+	header := w.Header()
+	header.Set("ETag", etag)
+
+	var lastModified time.Time
+	if latest {
+		lastModified = schema.UpdatedAt
+		header.Set("Cache-Control", fmt.Sprintf("%s, max-age=%d, must-revalidate", visibility, int(latestMaxAge.Seconds())))
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	} else {
+		header.Set("Cache-Control", fmt.Sprintf("%s, max-age=%d, immutable", visibility, int(immutableMaxAge.Seconds())))
+	}
+
+	if !notModified(r, etag, lastModified) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// notModified avalia If-None-Match (comparação fraca) e, na sua ausência, If-Modified-Since
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	const etag = `"abc"`
+
+	tests := []struct {
+		name         string
+		header       map[string]string
+		lastModified time.Time
+		want         bool
+	}{
+		{name: "should miss without conditional headers", want: false},
+		{name: "should match etag", header: map[string]string{"If-None-Match": `"abc"`}, want: true},
+		{name: "should match weak etag", header: map[string]string{"If-None-Match": `W/"abc"`}, want: true},
+		{name: "should match etag in list", header: map[string]string{"If-None-Match": `"old", "abc"`}, want: true},
+		{name: "should match wildcard", header: map[string]string{"If-None-Match": "*"}, want: true},
+		{name: "should miss stale etag", header: map[string]string{"If-None-Match": `"old"`}, want: false},
+		{
+			name:         "should ignore If-Modified-Since when If-None-Match is present",
+			header:       map[string]string{"If-None-Match": `"old"`, "If-Modified-Since": modified.Format(http.TimeFormat)},
+			lastModified: modified,
+			want:         false,
+		},
+		{
+			name:         "should match unchanged resource",
+			header:       map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			lastModified: modified.Add(500 * time.Millisecond),
+			want:         true,
+		},
+		{
+			name:         "should miss modified resource",
+			header:       map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			lastModified: modified.Add(time.Minute),
+			want:         false,
+		},
+		{
+			name:   "should ignore If-Modified-Since without Last-Modified",
+			header: map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/schemas/orders/versions/1", nil)
+			for name, value := range tt.header {
+				req.Header.Set(name, value)
+			}
+			if got := notModified(req, etag, tt.lastModified); got != tt.want {
+				t.Errorf("notModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaETag(t *testing.T) {
+	base := models.Schema{ID: "orders-1", Subject: "orders", Version: 1, Schema: `{"type":"object"}`, SchemaType: models.SchemaTypeJSON}
+
+	deleted := base
+	deleted.Deleted = true
+	changed := base
+	changed.Schema = `{"type":"string"}`
+	otherSubject := base
+	otherSubject.Subject = "payments"
+
+	if schemaETag(&base) == schemaETag(&deleted) {
+		t.Error("expected deleted state to change the ETag")
+	}
+	if schemaETag(&base) == schemaETag(&changed) {
+		t.Error("expected schema content to change the ETag")
+	}
+	if schemaETag(&base) != schemaETag(&otherSubject) {
+		t.Error("expected ETag to depend only on fingerprint, ID, version and deleted state")
+	}
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+)
+
+// errorMapping associa um erro sentinela ao status HTTP e ao código da API
+type errorMapping struct {
+	err    error
+	status int
+	code   int
+}
+
+// errForbidden principal sem permissão para um item de uma operação em lote
+var errForbidden = errors.New("operation not permitted for this principal")
+
+var registryErrors = []errorMapping{
+	{schema.ErrSubjectNotFound, http.StatusNotFound, models.ErrorCodeSubjectNotFound},
+	{schema.ErrVersionNotFound, http.StatusNotFound, models.ErrorCodeVersionNotFound},
+	{schema.ErrSchemaNotFound, http.StatusNotFound, models.ErrorCodeSchemaNotFound},
+	{schema.ErrIncompatible, http.StatusConflict, models.ErrorCodeIncompatibleSchema},
+	{schema.ErrConflict, http.StatusConflict, models.ErrorCodeConflict},
+	{schema.ErrInvalidSchema, http.StatusUnprocessableEntity, models.ErrorCodeInvalidSchema},
+	{schema.ErrInvalidConfig, http.StatusUnprocessableEntity, models.ErrorCodeInvalidConfig},
+	{schema.ErrInvalidMode, http.StatusUnprocessableEntity, models.ErrorCodeInvalidMode},
+	{schema.ErrOperationNotPermitted, http.StatusUnprocessableEntity, models.ErrorCodeOperationNotPermitted},
+	{schema.ErrVersionLimit, http.StatusUnprocessableEntity, models.ErrorCodeVersionLimit},
+	{schema.ErrPolicyViolation, http.StatusUnprocessableEntity, models.ErrorCodePolicyViolation},
+	{schema.ErrBatchAborted, http.StatusConflict, models.ErrorCodeBatchAborted},
+	{errForbidden, http.StatusForbidden, models.ErrorCodeForbidden},
+}
+
+// defaultErrorCodes código usado quando o erro não tem um código específico
+var defaultErrorCodes = map[int]int{
+	http.StatusBadRequest:          models.ErrorCodeBadRequest,
+	http.StatusUnauthorized:        models.ErrorCodeUnauthorized,
+	http.StatusForbidden:           models.ErrorCodeForbidden,
+	http.StatusNotFound:            models.ErrorCodeNotFound,
+	http.StatusConflict:            models.ErrorCodeConflict,
+	http.StatusUnprocessableEntity: models.ErrorCodeInvalidSchema,
+	http.StatusTooManyRequests:     models.ErrorCodeRateLimited,
+	http.StatusInternalServerError: models.ErrorCodeInternal,
+}
+
+// writeRegistryError responde com o status e o código correspondentes ao erro do registry.
+// Erros sem sentinela conhecido usam fallbackStatus.
+func writeRegistryError(w http.ResponseWriter, err error, fallbackStatus int) {
+	status, code, details := ClassifyError(err, fallbackStatus)
+	writeErrorResponse(w, status, code, err.Error(), details)
+}
+
+// ClassifyError resolve status HTTP, código da API e detalhes de um erro do registry.
+// Também usado pela API NATS, que replica os envelopes da API HTTP.
+func ClassifyError(err error, fallbackStatus int) (int, int, map[string]interface{}) {
+	var details map[string]interface{}
+	// O sentinela do erro de domínio tem precedência sobre o da causa que ele embrulha
+	// (ex.: referência inexistente é schema inválido, não subject inexistente)
+	sentinel := err
+	var registryErr *schema.RegistryError
+	if errors.As(err, &registryErr) {
+		details = registryErr.Details
+		sentinel = registryErr.Err
+	}
+
+	for _, target := range []error{sentinel, err} {
+		for _, mapping := range registryErrors {
+			if errors.Is(target, mapping.err) {
+				return mapping.status, mapping.code, details
+			}
+		}
+	}
+
+	if fallbackStatus >= http.StatusInternalServerError {
+		log.Printf("Internal error: %v", err)
+	}
+	return fallbackStatus, defaultErrorCodes[fallbackStatus], details
+}
+
+func writeErrorResponse(w http.ResponseWriter, status, code int, message string, details map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if code == 0 {
+		code = status * 100
+	}
+
+	response := models.SchemaResponse{
+		Success:   false,
+		Error:     message,
+		ErrorCode: code,
+		Details:   details,
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+)
+
+func TestWriteRegistryError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		fallback    int
+		wantStatus  int
+		wantCode    int
+		wantDetails bool
+	}{
+		{
+			name:        "should map subject not found",
+			err:         &schema.RegistryError{Err: schema.ErrSubjectNotFound, Message: "subject a not found", Details: map[string]interface{}{"subject": "a"}},
+			fallback:    http.StatusInternalServerError,
+			wantStatus:  http.StatusNotFound,
+			wantCode:    models.ErrorCodeSubjectNotFound,
+			wantDetails: true,
+		},
+		{
+			name:       "should map wrapped incompatible schema",
+			err:        fmt.Errorf("line 3: %w", &schema.RegistryError{Err: schema.ErrIncompatible, Message: "compatibility check failed"}),
+			fallback:   http.StatusBadRequest,
+			wantStatus: http.StatusConflict,
+			wantCode:   models.ErrorCodeIncompatibleSchema,
+		},
+		{
+			name: "should prefer the domain sentinel over the wrapped cause",
+			err: fmt.Errorf("%w: %w", &schema.RegistryError{Err: schema.ErrInvalidSchema, Message: "reference Address not found"},
+				schema.ErrSubjectNotFound),
+			fallback:   http.StatusInternalServerError,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   models.ErrorCodeInvalidSchema,
+		},
+		{
+			name:       "should map plain sentinel",
+			err:        fmt.Errorf("restore: %w", schema.ErrVersionLimit),
+			fallback:   http.StatusInternalServerError,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   models.ErrorCodeVersionLimit,
+		},
+		{
+			name:       "should use fallback for unknown errors",
+			err:        errors.New("kv unavailable"),
+			fallback:   http.StatusInternalServerError,
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   models.ErrorCodeInternal,
+		},
+		{
+			name:       "should use fallback status code for bad requests",
+			err:        errors.New("empty backup"),
+			fallback:   http.StatusBadRequest,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   models.ErrorCodeBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeRegistryError(rec, tt.err, tt.fallback)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			var response models.SchemaResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("invalid response body: %v", err)
+			}
+			if response.Success || response.ErrorCode != tt.wantCode || response.Error != tt.err.Error() {
+				t.Errorf("unexpected response %+v", response)
+			}
+			if tt.wantDetails && response.Details == nil {
+				t.Error("expected details")
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/events"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// DefaultHeartbeat intervalo dos comentários de keep-alive enviados em streams sem eventos
+const DefaultHeartbeat = 15 * time.Second
+
+// sseRetry intervalo de reconexão sugerido aos clientes (ms)
+const sseRetry = 3000
+
+type EventHandlers struct {
+	source     *events.Source
+	authorizer Authorizer
+	heartbeat  time.Duration
+
+	// closing encerra os streams abertos no shutdown do servidor
+	closing context.Context
+	cancel  context.CancelFunc
+}
+
+func NewEventHandlers(source *events.Source) *EventHandlers {
+	closing, cancel := context.WithCancel(context.Background())
+	return &EventHandlers{source: source, heartbeat: DefaultHeartbeat, closing: closing, cancel: cancel}
+}
+
+// Shutdown encerra os streams abertos; http.Server.Shutdown não interrompe conexões ativas
+func (h *EventHandlers) Shutdown() {
+	h.cancel()
+}
+
+// SetAuthorizer habilita o controle de acesso
+func (h *EventHandlers) SetAuthorizer(authorizer Authorizer) {
+	h.authorizer = authorizer
+}
+
+// SetHeartbeat define o intervalo de keep-alive do stream
+func (h *EventHandlers) SetHeartbeat(interval time.Duration) {
+	if interval > 0 {
+		h.heartbeat = interval
+	}
+}
+
+// StreamEventsHandler transmite os eventos de schema via Server-Sent Events
+// (?subject=<prefixo>, repetível; retomada por Last-Event-ID ou ?after=<sequência>).
+// Eventos de subjects sem permissão de leitura são omitidos.
+func (h *EventHandlers) StreamEventsHandler(w http.ResponseWriter, r *http.Request) {
+	prefixes := r.URL.Query()["subject"]
+
+	// Sem Last-Event-ID/after, apenas eventos novos; after=0 transmite desde o início do stream
+	var start uint64
+	after := r.Header.Get("Last-Event-ID")
+	if after == "" {
+		after = r.URL.Query().Get("after")
+	}
+	if after != "" {
+		sequence, err := strconv.ParseUint(after, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid event id, expected stream sequence")
+			return
+		}
+		start = sequence + 1
+	}
+
+	sub, err := h.source.Subscribe(start)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer sub.Close()
+
+	// O stream é longo: remove o WriteTimeout do servidor para esta resposta
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetry)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	// O keep-alive é enviado quando nada foi escrito no intervalo, mesmo que eventos filtrados cheguem
+	deadline := time.Now().Add(h.heartbeat)
+	streamCtx, stop := context.WithCancel(r.Context())
+	defer stop()
+	go func() {
+		select {
+		case <-h.closing.Done():
+			stop()
+		case <-streamCtx.Done():
+		}
+	}()
+
+	for {
+		ctx, cancel := context.WithDeadline(streamCtx, deadline)
+		event, err := sub.Next(ctx)
+		cancel()
+
+		switch {
+		case streamCtx.Err() != nil:
+			return
+		case errors.Is(err, context.DeadlineExceeded):
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case err != nil:
+			log.Printf("Warning: event stream interrupted: %v", err)
+			return
+		case !matchPrefix(event.Subject, prefixes) || !allowed(r, h.authorizer, acl.OpRead, event.Subject):
+			continue
+		default:
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+		deadline = time.Now().Add(h.heartbeat)
+	}
+}
+
+// writeEvent escreve o evento no formato SSE, com a sequência do stream como id
+func writeEvent(w http.ResponseWriter, event *models.SchemaEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, data)
+	return err
+}
+
+// matchPrefix indica se o subject começa com algum dos prefixos (sem prefixos, todos)
+func matchPrefix(subject string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
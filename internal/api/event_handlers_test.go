@@ -0,0 +1,25 @@
+package api
+
+import "testing"
+
+func TestMatchPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		subject  string
+		prefixes []string
+		want     bool
+	}{
+		{name: "should match any subject without prefixes", subject: "users", want: true},
+		{name: "should match prefix", subject: "payments.order", prefixes: []string{"payments."}, want: true},
+		{name: "should match any of the prefixes", subject: "users", prefixes: []string{"payments.", "users"}, want: true},
+		{name: "should not match other subjects", subject: "users", prefixes: []string{"payments."}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchPrefix(tt.subject, tt.prefixes); got != tt.want {
+				t.Errorf("matchPrefix(%q, %v) = %v, want %v", tt.subject, tt.prefixes, got, tt.want)
+			}
+		})
+	}
+}
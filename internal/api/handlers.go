@@ -2,9 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
 	"github.com/rodrigues-daniel/data-platform/internal/dtos"
 	"github.com/rodrigues-daniel/data-platform/internal/mappers"
 	"github.com/rodrigues-daniel/data-platform/internal/schema"
@@ -15,13 +18,27 @@ import (
 )
 
 type Handlers struct {
-	registry *schema.Registry
+	registry   *schema.Registry
+	authorizer Authorizer
+
+	// latestMaxAge validade em cache de /versions/latest
+	latestMaxAge time.Duration
 }
 
 func NewHandlers(registry *schema.Registry) *Handlers {
 	return &Handlers{registry: registry}
 }
 
+// SetLatestMaxAge define por quanto tempo caches podem servir /versions/latest sem revalidar
+func (h *Handlers) SetLatestMaxAge(maxAge time.Duration) {
+	h.latestMaxAge = maxAge
+}
+
+// SetAuthorizer habilita o controle de acesso por subject
+func (h *Handlers) SetAuthorizer(authorizer Authorizer) {
+	h.authorizer = authorizer
+}
+
 // RegisterSchemaHandler registra novo schema
 func (h *Handlers) RegisterSchemaHandler(w http.ResponseWriter, r *http.Request) {
 	var schemaDTO dtos.CreateSchemaRequest
@@ -30,15 +47,46 @@ func (h *Handlers) RegisterSchemaHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var schema models.Schema
-	schema = mappers.MapCreateSchemaRequestToModel(schemaDTO)
-	registeredSchema, err := h.registry.RegisterSchema(r.Context(), &schema)
+	// O subject da rota prevalece; um subject diferente no corpo é recusado
+	subject := mux.Vars(r)["subject"]
+	if schemaDTO.Subject != "" && schemaDTO.Subject != subject {
+		h.sendError(w, http.StatusBadRequest, fmt.Sprintf("Subject %q in body does not match %q in path", schemaDTO.Subject, subject))
+		return
+	}
+	schemaDTO.Subject = subject
+
+	if !authorize(w, r, h.authorizer, acl.OpRegister, subject) {
+		return
+	}
+
+	model := mappers.MapCreateSchemaRequestToModel(schemaDTO)
+
+	// normalize grava também a forma canônica, usada no fingerprint e na deduplicação
+	if normalize, _ := strconv.ParseBool(r.URL.Query().Get("normalize")); normalize {
+		if err := schema.Normalize(&model); err != nil {
+			writeRegistryError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// dryRun executa as verificações do registro sem gravar
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun")); dryRun {
+		check, err := h.registry.DryRunRegistration(r.Context(), &model)
+		if err != nil {
+			writeRegistryError(w, err, http.StatusInternalServerError)
+			return
+		}
+		h.sendSuccess(w, http.StatusOK, check)
+		return
+	}
+
+	registeredSchema, err := h.registry.RegisterSchema(r.Context(), &model)
 	if err != nil {
-		h.sendError(w, http.StatusBadRequest, err.Error())
+		writeRegistryError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	h.sendSuccess(w, http.StatusCreated, registeredSchema)
+	h.sendSuccess(w, http.StatusCreated, schemaResponse(r, registeredSchema))
 }
 
 // GetSchemaHandler obtém schema
@@ -47,51 +95,235 @@ func (h *Handlers) GetSchemaHandler(w http.ResponseWriter, r *http.Request) {
 	subject := vars["subject"]
 	versionStr := vars["version"]
 
-	var schema *models.Schema
-	var err error
+	if !authorize(w, r, h.authorizer, acl.OpRead, subject) {
+		return
+	}
 
-	if versionStr == "latest" {
-		schema, err = h.registry.GetLatestSchema(r.Context(), subject)
-	} else {
-		version, parseErr := strconv.Atoi(versionStr)
-		if parseErr != nil {
-			h.sendError(w, http.StatusBadRequest, "Invalid version")
+	includeDeleted, _ := strconv.ParseBool(r.URL.Query().Get("deleted"))
+	schema, ok := h.resolveVersion(w, r, subject, versionStr, includeDeleted)
+	if !ok {
+		return
+	}
+
+	if writeCacheHeaders(w, r, schema, versionStr == "latest", h.latestMaxAge) {
+		return
+	}
+	h.sendSuccess(w, http.StatusOK, schemaResponse(r, schema))
+}
+
+// GetSchemaByIDHandler obtém um schema pelo ID global
+func (h *Handlers) GetSchemaByIDHandler(w http.ResponseWriter, r *http.Request) {
+	includeDeleted, _ := strconv.ParseBool(r.URL.Query().Get("deleted"))
+	// Sem permissão de leitura no subject, o ID é tratado como inexistente
+	schema, err := h.registry.FindVisibleSchemaByID(r.Context(), mux.Vars(r)["id"], includeDeleted,
+		func(subject string) bool {
+			return allowed(r, h.authorizer, acl.OpRead, subject)
+		})
+	if err != nil {
+		writeRegistryError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if writeCacheHeaders(w, r, schema, false, 0) {
+		return
+	}
+	h.sendSuccess(w, http.StatusOK, schemaResponse(r, schema))
+}
+
+// DeleteSchemaHandler remove uma versão de schema (soft delete; ?permanent=true remove definitivamente)
+func (h *Handlers) DeleteSchemaHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	subject := vars["subject"]
+
+	if !authorize(w, r, h.authorizer, acl.OpDelete, subject) {
+		return
+	}
+
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil {
+		writeInvalidVersion(w, vars["version"])
+		return
+	}
+
+	permanent := false
+	if value := r.URL.Query().Get("permanent"); value != "" {
+		if permanent, err = strconv.ParseBool(value); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid permanent")
 			return
 		}
-		schema, err = h.registry.GetSchema(r.Context(), subject, version)
 	}
 
-	if err != nil {
-		h.sendError(w, http.StatusNotFound, err.Error())
+	if err := h.registry.DeleteSchema(r.Context(), subject, version, permanent); err != nil {
+		writeRegistryError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	h.sendSuccess(w, http.StatusOK, schema)
+	if isLegacy(r) {
+		h.sendSuccess(w, http.StatusOK, version)
+		return
+	}
+	h.sendSuccess(w, http.StatusOK, dtos.DeleteSchemaResponse{Subject: subject, Version: version, Permanent: permanent})
 }
 
-// ListSubjectsHandler lista subjects
+// ListSubjectsHandler lista subjects com filtros e paginação por cursor
 func (h *Handlers) ListSubjectsHandler(w http.ResponseWriter, r *http.Request) {
-	subjects, err := h.registry.ListSubjects(r.Context())
+	query, err := parseSubjectQuery(r)
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, err.Error())
+		h.sendError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	h.sendSuccess(w, http.StatusOK, subjects)
+	// Listar apenas os subjects que o principal pode ler; o filtro roda antes do limite
+	query.Visible = func(subject string) bool {
+		return allowed(r, h.authorizer, acl.OpRead, subject)
+	}
+	page, err := h.registry.QuerySubjects(r.Context(), query)
+	if err != nil {
+		writeRegistryError(w, err, http.StatusInternalServerError)
+		return
+	}
+	visible := page.Subjects
+
+	switch {
+	case query.Verbose && isLegacy(r):
+		h.sendSuccess(w, http.StatusOK, visible)
+	case query.Verbose:
+		details := make([]dtos.SubjectDetail, 0, len(visible))
+		for _, info := range visible {
+			details = append(details, mappers.MapSubjectInfoToDetail(info))
+		}
+		h.sendSuccess(w, http.StatusOK, dtos.SubjectDetailListResponse{Subjects: details, Total: len(details), NextCursor: page.NextCursor})
+	default:
+		names := make([]string, 0, len(visible))
+		for _, info := range visible {
+			names = append(names, info.Subject)
+		}
+		if isLegacy(r) {
+			h.sendSuccess(w, http.StatusOK, names)
+			return
+		}
+		h.sendSuccess(w, http.StatusOK, dtos.SubjectListResponse{Subjects: names, Total: len(names), NextCursor: page.NextCursor})
+	}
 }
 
-// ListVersionsHandler lista versões
+// ListVersionsHandler lista versões de um subject com paginação
 func (h *Handlers) ListVersionsHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	subject := vars["subject"]
 
-	versions, err := h.registry.ListVersions(r.Context(), subject)
+	if !authorize(w, r, h.authorizer, acl.OpRead, subject) {
+		return
+	}
+
+	query, err := parseVersionQuery(r)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.registry.QueryVersions(r.Context(), subject, query)
 	if err != nil {
-		h.sendError(w, http.StatusNotFound, err.Error())
+		writeRegistryError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	h.sendSuccess(w, http.StatusOK, versions)
+	if isLegacy(r) {
+		h.sendSuccess(w, http.StatusOK, page.Versions)
+		return
+	}
+	h.sendSuccess(w, http.StatusOK, dtos.VersionListResponse{Subject: subject, Versions: page.Versions, NextCursor: page.NextCursor})
+}
+
+// LookupSchemaHandler procura no subject a versão com o conteúdo informado. Com ?normalize=true
+// compara as formas canônicas; ?deleted=true inclui versões removidas.
+func (h *Handlers) LookupSchemaHandler(w http.ResponseWriter, r *http.Request) {
+	subject := mux.Vars(r)["subject"]
+
+	if !authorize(w, r, h.authorizer, acl.OpRead, subject) {
+		return
+	}
+
+	var req dtos.LookupSchemaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	params := r.URL.Query()
+	candidate := mappers.MapLookupSchemaRequestToModel(subject, req)
+	if normalize, _ := strconv.ParseBool(params.Get("normalize")); normalize {
+		if err := schema.Normalize(&candidate); err != nil {
+			writeRegistryError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+	includeDeleted, _ := strconv.ParseBool(params.Get("deleted"))
+
+	found, err := h.registry.LookupSchema(r.Context(), &candidate, includeDeleted)
+	if err != nil {
+		writeRegistryError(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.sendSuccess(w, http.StatusOK, schemaResponse(r, found))
+}
+
+// DiffHandler compara duas versões de um subject (?from=<versão>&to=<versão|latest>, to padrão latest)
+func (h *Handlers) DiffHandler(w http.ResponseWriter, r *http.Request) {
+	subject := mux.Vars(r)["subject"]
+
+	if !authorize(w, r, h.authorizer, acl.OpRead, subject) {
+		return
+	}
+
+	params := r.URL.Query()
+	fromStr, toStr := params.Get("from"), params.Get("to")
+	if fromStr == "" {
+		h.sendError(w, http.StatusBadRequest, "Missing from version")
+		return
+	}
+	if toStr == "" {
+		toStr = "latest"
+	}
+	includeDeleted, _ := strconv.ParseBool(params.Get("deleted"))
+
+	from, ok := h.resolveVersion(w, r, subject, fromStr, includeDeleted)
+	if !ok {
+		return
+	}
+	to, ok := h.resolveVersion(w, r, subject, toStr, includeDeleted)
+	if !ok {
+		return
+	}
+
+	diff, err := schema.Diff(from, to)
+	if err != nil {
+		writeRegistryError(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.sendSuccess(w, http.StatusOK, diff)
+}
+
+// resolveVersion obtém a versão informada (número ou "latest"), respondendo o erro quando falha
+func (h *Handlers) resolveVersion(w http.ResponseWriter, r *http.Request, subject, versionStr string, includeDeleted bool) (*models.Schema, bool) {
+	var found *models.Schema
+	var err error
+
+	if versionStr == "latest" {
+		found, err = h.registry.GetLatestSchema(r.Context(), subject)
+	} else {
+		version, parseErr := strconv.Atoi(versionStr)
+		if parseErr != nil {
+			writeInvalidVersion(w, versionStr)
+			return nil, false
+		}
+		found, err = h.registry.FindSchema(r.Context(), subject, version, includeDeleted)
+	}
+
+	if err != nil {
+		writeRegistryError(w, err, http.StatusInternalServerError)
+		return nil, false
+	}
+	return found, true
 }
 
 // ConfigHandler gerencia configurações
@@ -99,30 +331,38 @@ func (h *Handlers) ConfigHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	subject := vars["subject"]
 
+	operation := acl.OpConfig
+	if r.Method == "GET" {
+		operation = acl.OpRead
+	}
+	if !authorize(w, r, h.authorizer, operation, subject) {
+		return
+	}
+
 	switch r.Method {
 	case "PUT":
-		var config models.SchemaConfig
-		if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		var req dtos.SchemaConfigRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			h.sendError(w, http.StatusBadRequest, "Invalid request body")
 			return
 		}
-		config.Subject = subject
+		config := mappers.MapSchemaConfigRequestToModel(subject, req)
 
 		if err := h.registry.SetConfig(r.Context(), &config); err != nil {
-			h.sendError(w, http.StatusBadRequest, err.Error())
+			writeRegistryError(w, err, http.StatusInternalServerError)
 			return
 		}
 
-		h.sendSuccess(w, http.StatusOK, config)
+		h.sendSuccess(w, http.StatusOK, mappers.MapSchemaConfigToResponse(&config))
 
 	case "GET":
 		config, err := h.registry.GetConfig(r.Context(), subject)
 		if err != nil {
-			h.sendError(w, http.StatusInternalServerError, err.Error())
+			writeRegistryError(w, err, http.StatusInternalServerError)
 			return
 		}
 
-		h.sendSuccess(w, http.StatusOK, config)
+		h.sendSuccess(w, http.StatusOK, mappers.MapSchemaConfigToResponse(config))
 
 	case "DELETE":
 		// Reset para padrão
@@ -131,35 +371,119 @@ func (h *Handlers) ConfigHandler(w http.ResponseWriter, r *http.Request) {
 			Compatibility: models.CompatibilityBackward,
 		}
 		if err := h.registry.SetConfig(r.Context(), defaultConfig); err != nil {
-			h.sendError(w, http.StatusInternalServerError, err.Error())
+			writeRegistryError(w, err, http.StatusInternalServerError)
 			return
 		}
 
-		h.sendSuccess(w, http.StatusOK, defaultConfig)
+		h.sendSuccess(w, http.StatusOK, mappers.MapSchemaConfigToResponse(defaultConfig))
 	}
 }
 
-// CompatibilityHandler verifica compatibilidade
+// ModeHandler gerencia o modo de operação de um subject
+func (h *Handlers) ModeHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	subject := vars["subject"]
+
+	operation := acl.OpMode
+	if r.Method == "GET" {
+		operation = acl.OpRead
+	}
+	if !authorize(w, r, h.authorizer, operation, subject) {
+		return
+	}
+
+	switch r.Method {
+	case "PUT":
+		var req dtos.SchemaModeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		mode := mappers.MapSchemaModeRequestToModel(subject, req)
+
+		if err := h.registry.SetMode(r.Context(), &mode); err != nil {
+			writeRegistryError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		h.sendSuccess(w, http.StatusOK, mappers.MapSchemaModeToResponse(&mode))
+
+	case "GET":
+		mode, err := h.registry.GetMode(r.Context(), subject)
+		if err != nil {
+			writeRegistryError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		h.sendSuccess(w, http.StatusOK, mappers.MapSchemaModeToResponse(mode))
+
+	case "DELETE":
+		// Reset para padrão
+		defaultMode := &models.SchemaMode{
+			Subject: subject,
+			Mode:    models.ModeReadWrite,
+		}
+		if err := h.registry.SetMode(r.Context(), defaultMode); err != nil {
+			writeRegistryError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		h.sendSuccess(w, http.StatusOK, mappers.MapSchemaModeToResponse(defaultMode))
+	}
+}
+
+// CompatibilityHandler verifica compatibilidade contra a versão da rota (número, "latest" ou
+// "all"; sem versão, latest). Com ?verbose=true detalha cada incompatibilidade.
 func (h *Handlers) CompatibilityHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	subject := vars["subject"]
 
-	var req struct {
-		Schema string `json:"schema"`
+	if !authorize(w, r, h.authorizer, acl.OpRead, subject) {
+		return
+	}
+
+	version, ok := compatibilityVersion(vars["version"])
+	if !ok {
+		writeInvalidVersion(w, vars["version"])
+		return
 	}
+	verbose, _ := strconv.ParseBool(r.URL.Query().Get("verbose"))
 
+	var req dtos.CompatibilityCheckRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.sendError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	result, err := h.registry.CheckCompatibility(r.Context(), subject, req.Schema)
+	result, err := h.registry.CheckCompatibilityWith(r.Context(), mappers.MapCompatibilityCheckRequestToSchema(subject, req), version, req.Compatibility)
 	if err != nil {
-		h.sendError(w, http.StatusBadRequest, err.Error())
+		writeRegistryError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if isLegacy(r) {
+		if !verbose {
+			result.Incompatibilities = nil
+		}
+		h.sendSuccess(w, http.StatusOK, result)
 		return
 	}
+	h.sendSuccess(w, http.StatusOK, mappers.MapCompatibilityResult(result, verbose))
+}
 
-	h.sendSuccess(w, http.StatusOK, result)
+// compatibilityVersion converte a versão da rota de compatibilidade
+func compatibilityVersion(versionStr string) (int, bool) {
+	switch versionStr {
+	case "", "latest":
+		return schema.LatestVersion, true
+	case "all":
+		return schema.AllVersions, true
+	}
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
 }
 
 // ValidateHandler valida dados
@@ -167,42 +491,64 @@ func (h *Handlers) ValidateHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	subject := vars["subject"]
 
-	var req models.SchemaValidationRequest
+	if !authorize(w, r, h.authorizer, acl.OpRead, subject) {
+		return
+	}
+
+	var req dtos.ValidateDataRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.sendError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
-	req.Subject = subject
+	validation := mappers.MapValidateDataRequestToModel(subject, req)
 
-	result, err := h.registry.ValidateData(r.Context(), &req)
+	result, err := h.registry.ValidateData(r.Context(), &validation)
 	if err != nil {
-		h.sendError(w, http.StatusBadRequest, err.Error())
+		writeRegistryError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	h.sendSuccess(w, http.StatusOK, result)
+	if isLegacy(r) {
+		h.sendSuccess(w, http.StatusOK, result)
+		return
+	}
+	h.sendSuccess(w, http.StatusOK, mappers.MapValidationResult(result))
 }
 
-func (h *Handlers) sendSuccess(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	response := models.SchemaResponse{
-		Success: true,
-		Data:    data,
+// schemaResponse mapeia o schema para o DTO do /v1; rotas legadas retornam o modelo
+func schemaResponse(r *http.Request, schema *models.Schema) interface{} {
+	if isLegacy(r) {
+		return schema
 	}
+	return mappers.MapSchemaToResponse(schema)
+}
 
-	json.NewEncoder(w).Encode(response)
+// writeInvalidVersion responde a versões que não são número nem "latest"
+func writeInvalidVersion(w http.ResponseWriter, version string) {
+	writeErrorResponse(w, http.StatusUnprocessableEntity, models.ErrorCodeInvalidVersion,
+		"Invalid version", map[string]interface{}{"version": version})
+}
+
+func (h *Handlers) sendSuccess(w http.ResponseWriter, status int, data interface{}) {
+	writeSuccess(w, status, data)
 }
 
 func (h *Handlers) sendError(w http.ResponseWriter, status int, message string) {
+	writeError(w, status, message)
+}
+
+func writeSuccess(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
 	response := models.SchemaResponse{
-		Success: false,
-		Error:   message,
+		Success: true,
+		Data:    data,
 	}
 
 	json.NewEncoder(w).Encode(response)
 }
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeErrorResponse(w, status, defaultErrorCodes[status], message, nil)
+}
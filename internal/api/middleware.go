@@ -0,0 +1,88 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/rodrigues-daniel/data-platform/internal/audit"
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+
+	"github.com/gorilla/mux"
+)
+
+// AuthMiddleware autentica cada requisição com o primeiro autenticador que reconhecer
+// as credenciais apresentadas. Caminhos em public não exigem autenticação.
+func AuthMiddleware(authenticators []auth.Authenticator, public ...string) mux.MiddlewareFunc {
+	publicPaths := make(map[string]bool, len(public))
+	for _, path := range public {
+		publicPaths[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if publicPaths[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := auth.Authenticate(r, authenticators)
+			switch {
+			case errors.Is(err, auth.ErrInvalidCredentials):
+				w.Header().Set("WWW-Authenticate", `Bearer realm="schema-registry"`)
+				writeError(w, http.StatusUnauthorized, "Invalid credentials")
+				return
+			case err != nil:
+				// Falha do autenticador não é culpa das credenciais
+				log.Printf("Authentication error: %v", err)
+				writeError(w, http.StatusInternalServerError, "Authentication unavailable")
+				return
+			case principal == nil:
+				w.Header().Set("WWW-Authenticate", `Bearer realm="schema-registry"`)
+				writeError(w, http.StatusUnauthorized, "Authentication required")
+				return
+			}
+
+			ctx := auth.WithPrincipal(r.Context(), principal)
+			ctx = audit.WithActor(ctx, principal.Name)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Authorizer decide se o principal pode executar a operação no subject
+type Authorizer interface {
+	Authorize(principal *auth.Principal, operation, subject string) error
+}
+
+// authorize responde 403 quando o principal autenticado não tem permissão.
+// Sem autorizador ou sem principal (autenticação desabilitada) a requisição é liberada.
+func authorize(w http.ResponseWriter, r *http.Request, authorizer Authorizer, operation, subject string) bool {
+	if authorizer == nil {
+		return true
+	}
+
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		return true
+	}
+
+	if err := authorizer.Authorize(principal, operation, subject); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return false
+	}
+	return true
+}
+
+// allowed indica, sem responder, se o principal pode executar a operação no subject
+func allowed(r *http.Request, authorizer Authorizer, operation, subject string) bool {
+	if authorizer == nil {
+		return true
+	}
+
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		return true
+	}
+	return authorizer.Authorize(principal, operation, subject) == nil
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+)
+
+// keyAuthenticator aceita a chave "secret"; "outage" simula falha do armazenamento de chaves
+type keyAuthenticator struct{}
+
+func (keyAuthenticator) Authenticate(r *http.Request) (*auth.Principal, error) {
+	switch r.Header.Get("X-API-Key") {
+	case "":
+		return nil, nil
+	case "secret":
+		return &auth.Principal{Name: "ci", Method: auth.MethodAPIKey}, nil
+	case "outage":
+		return nil, errors.New("failed to lookup api key: kv unavailable")
+	default:
+		return nil, auth.ErrInvalidCredentials
+	}
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	handler := AuthMiddleware([]auth.Authenticator{keyAuthenticator{}}, "/health")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		path       string
+		key        string
+		wantStatus int
+	}{
+		{name: "should allow public path", path: "/health", wantStatus: http.StatusOK},
+		{name: "should authenticate valid key", path: "/subjects", key: "secret", wantStatus: http.StatusOK},
+		{name: "should reject invalid key", path: "/subjects", key: "wrong", wantStatus: http.StatusUnauthorized},
+		{name: "should require credentials", path: "/subjects", wantStatus: http.StatusUnauthorized},
+		{name: "should report authenticator failures as server errors", path: "/subjects", key: "outage", wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
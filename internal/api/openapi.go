@@ -0,0 +1,32 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// OpenAPISpec contrato da API (OpenAPI 3.1), mantido em sincronia com as rotas por teste
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// OpenAPIHandler serve a especificação OpenAPI
+func OpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(OpenAPISpec)
+}
+
+// docsPolicy restringe a página de documentação aos recursos embutidos e ao openapi.json local
+const docsPolicy = "default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'"
+
+// DocsHandler serve a página de documentação, autocontida e sem recursos de terceiros
+func DocsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", docsPolicy)
+	w.WriteHeader(http.StatusOK)
+	w.Write(docsPage)
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestDocsHandlerIsSelfContained(t *testing.T) {
+	rec := httptest.NewRecorder()
+	DocsHandler(rec, httptest.NewRequest("GET", "/docs", nil))
+
+	if rec.Header().Get("Content-Security-Policy") != docsPolicy {
+		t.Errorf("expected content security policy, got %q", rec.Header().Get("Content-Security-Policy"))
+	}
+	if external := regexp.MustCompile(`(src|href)="(https?:)?//`).FindString(rec.Body.String()); external != "" {
+		t.Errorf("expected no external resources, found %s", external)
+	}
+}
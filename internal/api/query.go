@@ -0,0 +1,127 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+)
+
+// parseSubjectQuery lê os filtros de /subjects
+// (?prefix=&contains=&type=&label=k=v&deleted=&order=&after=&limit=&verbose=)
+func parseSubjectQuery(r *http.Request) (models.SubjectQuery, error) {
+	params := r.URL.Query()
+	query := models.SubjectQuery{
+		Prefix:   params.Get("prefix"),
+		Contains: params.Get("contains"),
+		After:    params.Get("after"),
+	}
+
+	if schemaType := params.Get("type"); schemaType != "" {
+		query.SchemaType = strings.ToUpper(schemaType)
+		switch query.SchemaType {
+		case models.SchemaTypeAVRO, models.SchemaTypeJSON, models.SchemaTypeProtobuf:
+		default:
+			return query, fmt.Errorf("invalid type %q", schemaType)
+		}
+	}
+
+	for _, label := range params["label"] {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			return query, fmt.Errorf("invalid label %q, expected key=value", label)
+		}
+		if query.Labels == nil {
+			query.Labels = map[string]string{}
+		}
+		query.Labels[key] = value
+	}
+
+	var err error
+	if query.Deleted, err = parseDeleted(params); err != nil {
+		return query, err
+	}
+	if query.Order, err = parseOrder(params); err != nil {
+		return query, err
+	}
+	if query.Limit, err = parseLimit(r, params); err != nil {
+		return query, err
+	}
+
+	if verbose := params.Get("verbose"); verbose != "" {
+		if query.Verbose, err = strconv.ParseBool(verbose); err != nil {
+			return query, fmt.Errorf("invalid verbose %q", verbose)
+		}
+	}
+
+	return query, nil
+}
+
+// parseVersionQuery lê os filtros de /subjects/{subject}/versions (?deleted=&order=&after=&limit=)
+func parseVersionQuery(r *http.Request) (models.VersionQuery, error) {
+	params := r.URL.Query()
+	var query models.VersionQuery
+
+	if after := params.Get("after"); after != "" {
+		cursor, err := strconv.Atoi(after)
+		if err != nil || cursor < 0 {
+			return query, fmt.Errorf("invalid after cursor %q", after)
+		}
+		query.After = cursor
+	}
+
+	var err error
+	if query.Deleted, err = parseDeleted(params); err != nil {
+		return query, err
+	}
+	if query.Order, err = parseOrder(params); err != nil {
+		return query, err
+	}
+	if query.Limit, err = parseLimit(r, params); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+func parseDeleted(params url.Values) (string, error) {
+	switch deleted := params.Get("deleted"); deleted {
+	case "", models.DeletedExclude:
+		return models.DeletedExclude, nil
+	case models.DeletedInclude, models.DeletedOnly:
+		return deleted, nil
+	default:
+		return "", fmt.Errorf("invalid deleted %q, expected exclude, include or only", deleted)
+	}
+}
+
+func parseOrder(params url.Values) (string, error) {
+	switch order := params.Get("order"); order {
+	case "", models.OrderAsc:
+		return models.OrderAsc, nil
+	case models.OrderDesc:
+		return order, nil
+	default:
+		return "", fmt.Errorf("invalid order %q, expected asc or desc", order)
+	}
+}
+
+// parseLimit aplica o limite padrão no /v1; rotas legadas listam tudo quando limit não é informado
+func parseLimit(r *http.Request, params url.Values) (int, error) {
+	limit := params.Get("limit")
+	if limit == "" {
+		if isLegacy(r) {
+			return 0, nil
+		}
+		return schema.DefaultListLimit, nil
+	}
+
+	parsed, err := strconv.Atoi(limit)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("invalid limit %q", limit)
+	}
+	return min(parsed, schema.MaxListLimit), nil
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	rateClassRead  = "read"
+	rateClassWrite = "write"
+)
+
+// RateLimitedRequests requisições rejeitadas por excesso de taxa
+var RateLimitedRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "schema_registry_rate_limited_requests_total",
+		Help: "Requisições rejeitadas por limite de taxa.",
+	},
+	[]string{"class"},
+)
+
+// RateLimitConfig limites em requisições por segundo, por principal (ou IP).
+// Taxa zero desabilita o limite da classe.
+type RateLimitConfig struct {
+	ReadRate   float64
+	ReadBurst  int
+	WriteRate  float64
+	WriteBurst int
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter mantém um token bucket por cliente e classe de rota (leitura/escrita)
+type RateLimiter struct {
+	config   RateLimitConfig
+	mu       sync.Mutex
+	limiters map[string]*limiterEntry
+}
+
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	return &RateLimiter{config: config, limiters: make(map[string]*limiterEntry)}
+}
+
+// allow consome um token; quando não há, retorna o tempo até o próximo disponível
+func (l *RateLimiter) allow(class, client string) (bool, time.Duration) {
+	limit, burst := l.config.ReadRate, l.config.ReadBurst
+	if class == rateClassWrite {
+		limit, burst = l.config.WriteRate, l.config.WriteBurst
+	}
+	if limit <= 0 {
+		return true, 0
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(limit))
+	}
+
+	key := class + "|" + client
+	now := time.Now()
+
+	l.mu.Lock()
+	entry, ok := l.limiters[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(limit), burst)}
+		l.limiters[key] = entry
+	}
+	entry.lastSeen = now
+	l.mu.Unlock()
+
+	reservation := entry.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// Cleanup descarta periodicamente os buckets de clientes inativos
+func (l *RateLimiter) Cleanup(ctx context.Context, idle time.Duration) {
+	ticker := time.NewTicker(idle)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.mu.Lock()
+			for key, entry := range l.limiters {
+				if now.Sub(entry.lastSeen) > idle {
+					delete(l.limiters, key)
+				}
+			}
+			l.mu.Unlock()
+		}
+	}
+}
+
+// RateLimitMiddleware limita as requisições por principal autenticado ou IP do cliente,
+// com limites separados para leitura (GET/HEAD) e escrita. Caminhos em exempt não são limitados.
+func RateLimitMiddleware(limiter *RateLimiter, exempt ...string) mux.MiddlewareFunc {
+	exemptPaths := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		exemptPaths[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if exemptPaths[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			class := rateClassWrite
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				class = rateClassRead
+			}
+
+			ok, delay := limiter.allow(class, clientKey(r))
+			if !ok {
+				RateLimitedRequests.WithLabelValues(class).Inc()
+				retryAfter := int(math.Ceil(delay.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				writeErrorResponse(w, http.StatusTooManyRequests, models.ErrorCodeRateLimited, "Rate limit exceeded",
+					map[string]interface{}{"class": class, "retry_after_seconds": retryAfter})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientKey identifica o cliente pelo principal autenticado ou, na falta dele, pelo IP
+func clientKey(r *http.Request) string {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		return "principal:" + principal.Name
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
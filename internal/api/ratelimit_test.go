@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{ReadRate: 1, ReadBurst: 2, WriteRate: 0.5, WriteBurst: 1})
+	handler := RateLimitMiddleware(limiter, "/health")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(method, path, remoteAddr, principal string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = remoteAddr
+		if principal != "" {
+			req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Name: principal}))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		remoteAddr string
+		principal  string
+		wantStatus int
+	}{
+		{"should allow first read", "GET", "/subjects", "10.0.0.1:1000", "", http.StatusOK},
+		{"should allow read within burst", "GET", "/subjects", "10.0.0.1:1001", "", http.StatusOK},
+		{"should reject read over burst", "GET", "/subjects", "10.0.0.1:1002", "", http.StatusTooManyRequests},
+		{"should keep write bucket separate", "POST", "/schemas/a/versions", "10.0.0.1:1003", "", http.StatusOK},
+		{"should reject write over burst", "POST", "/schemas/a/versions", "10.0.0.1:1004", "", http.StatusTooManyRequests},
+		{"should limit other clients independently", "GET", "/subjects", "10.0.0.2:1000", "", http.StatusOK},
+		{"should key authenticated requests by principal", "GET", "/subjects", "10.0.0.1:1005", "ci", http.StatusOK},
+		{"should not limit exempt paths", "GET", "/health", "10.0.0.1:1006", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(tt.method, tt.path, tt.remoteAddr, tt.principal)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			if tt.wantStatus == http.StatusTooManyRequests {
+				retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+				if err != nil || retryAfter < 1 {
+					t.Errorf("expected positive Retry-After, got %q", rec.Header().Get("Retry-After"))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+type legacyKey struct{}
+
+// LegacyMiddleware marca as rotas sem prefixo de versão. Elas estão deprecated e
+// mantêm o formato de resposta anterior ao /v1.
+func LegacyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "</v1"+r.URL.Path+`>; rel="successor-version"`)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), legacyKey{}, true)))
+	})
+}
+
+// isLegacy indica se a requisição chegou por uma rota sem versão
+func isLegacy(r *http.Request) bool {
+	legacy, _ := r.Context().Value(legacyKey{}).(bool)
+	return legacy
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/webhook"
+
+	"github.com/gorilla/mux"
+)
+
+type WebhookHandlers struct {
+	store      *webhook.Store
+	deliveries *webhook.DeliveryLog
+	authorizer Authorizer
+}
+
+func NewWebhookHandlers(store *webhook.Store, deliveries *webhook.DeliveryLog) *WebhookHandlers {
+	return &WebhookHandlers{store: store, deliveries: deliveries}
+}
+
+// SetAuthorizer habilita o controle de acesso
+func (h *WebhookHandlers) SetAuthorizer(authorizer Authorizer) {
+	h.authorizer = authorizer
+}
+
+// ListWebhooksHandler lista os webhooks cadastrados
+func (h *WebhookHandlers) ListWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, acl.OpAdmin, "") {
+		return
+	}
+
+	webhooks := h.store.List()
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	writeSuccess(w, http.StatusOK, webhooks)
+}
+
+// CreateWebhookHandler cadastra um destino de eventos
+func (h *WebhookHandlers) CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, acl.OpAdmin, "") {
+		return
+	}
+
+	var request models.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	created, err := h.store.Create(&request)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	// O secret gerado pelo registry é exibido apenas nesta resposta
+	if request.Secret != "" {
+		redactWebhook(created)
+	}
+	writeSuccess(w, http.StatusCreated, created)
+}
+
+// GetWebhookHandler retorna um webhook
+func (h *WebhookHandlers) GetWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, acl.OpAdmin, "") {
+		return
+	}
+
+	found, err := h.store.Get(mux.Vars(r)["id"])
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, redactWebhook(found))
+}
+
+// UpdateWebhookHandler altera um webhook; enabled=true reabilita um webhook desabilitado por falhas
+func (h *WebhookHandlers) UpdateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, acl.OpAdmin, "") {
+		return
+	}
+
+	var request models.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	updated, err := h.store.Update(mux.Vars(r)["id"], &request)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, redactWebhook(updated))
+}
+
+// DeleteWebhookHandler remove um webhook e interrompe suas entregas
+func (h *WebhookHandlers) DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, acl.OpAdmin, "") {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if err := h.store.Delete(id); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, id)
+}
+
+// ListDeliveriesHandler lista as tentativas de entrega de um webhook (?after=&limit=)
+func (h *WebhookHandlers) ListDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, acl.OpAdmin, "") {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if _, err := h.store.Get(id); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	params := r.URL.Query()
+	var after uint64
+	if value := params.Get("after"); value != "" {
+		cursor, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid after cursor")
+			return
+		}
+		after = cursor
+	}
+
+	var limit int
+	if value := params.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := h.deliveries.Query(r.Context(), id, after, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, page)
+}
+
+// redactWebhook remove o segredo da resposta
+func redactWebhook(found *models.Webhook) *models.Webhook {
+	found.Secret = ""
+	return found
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, webhook.ErrInvalid):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
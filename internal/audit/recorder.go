@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// StreamName stream dedicado aos registros de auditoria
+	StreamName = "SCHEMA_AUDIT"
+	// SubjectPrefix prefixo dos subjects NATS de auditoria
+	SubjectPrefix = "schema.audit."
+
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// ErrInvalidSubject subject de consulta com curingas NATS ou tokens vazios
+var ErrInvalidSubject = errors.New("invalid subject")
+
+// ValidateSubject rejeita subjects que, concatenados ao prefixo, formariam um filtro NATS mais
+// amplo que o próprio subject (ex.: ">" leria o trail completo)
+func ValidateSubject(subject string) error {
+	if strings.ContainsAny(subject, "*> \t") {
+		return fmt.Errorf("%w: %q contains wildcards or whitespace", ErrInvalidSubject, subject)
+	}
+	for _, token := range strings.Split(subject, ".") {
+		if token == "" {
+			return fmt.Errorf("%w: %q contains empty tokens", ErrInvalidSubject, subject)
+		}
+	}
+	return nil
+}
+
+type actorKey struct{}
+
+// WithActor associa ao contexto a identidade de quem executa a operação
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext obtém a identidade associada ao contexto
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
+
+// StreamConfig configuração do stream de auditoria
+func StreamConfig() *nats.StreamConfig {
+	return &nats.StreamConfig{
+		Name:      StreamName,
+		Subjects:  []string{SubjectPrefix + ">"},
+		Storage:   nats.FileStorage,
+		Retention: nats.LimitsPolicy,
+		Replicas:  1,
+	}
+}
+
+type Recorder struct {
+	js nats.JetStreamContext
+}
+
+func NewRecorder(js nats.JetStreamContext) *Recorder {
+	return &Recorder{js: js}
+}
+
+// Record persiste um registro de auditoria no stream
+func (r *Recorder) Record(ctx context.Context, record *models.AuditRecord) error {
+	if record.Actor == "" {
+		record.Actor = ActorFromContext(ctx)
+	}
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	if _, err := r.js.Publish(SubjectPrefix+record.Subject, data, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("failed to publish audit record: %w", err)
+	}
+	return nil
+}
+
+// Query lista registros de auditoria em ordem cronológica, paginados pela sequência do stream
+func (r *Recorder) Query(ctx context.Context, query models.AuditQuery) (*models.AuditPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	filter := SubjectPrefix + ">"
+	if query.Subject != "" {
+		if err := ValidateSubject(query.Subject); err != nil {
+			return nil, err
+		}
+		filter = SubjectPrefix + query.Subject
+	}
+
+	page := &models.AuditPage{Records: []models.AuditRecord{}}
+
+	// A última mensagem do filtro delimita a leitura
+	last, err := r.js.GetLastMsg(StreamName, filter, nats.Context(ctx))
+	if err != nil {
+		if err == nats.ErrMsgNotFound {
+			return page, nil
+		}
+		return nil, fmt.Errorf("failed to get last audit record: %w", err)
+	}
+	if last.Sequence <= query.After || (!query.Since.IsZero() && last.Time.Before(query.Since)) {
+		return page, nil
+	}
+
+	opts := []nats.SubOpt{nats.OrderedConsumer(), nats.BindStream(StreamName)}
+	switch {
+	case query.After > 0:
+		opts = append(opts, nats.StartSequence(query.After+1))
+	case !query.Since.IsZero():
+		opts = append(opts, nats.StartTime(query.Since))
+	default:
+		opts = append(opts, nats.DeliverAll())
+	}
+
+	sub, err := r.js.SubscribeSync(filter, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to audit stream: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	for len(page.Records) < limit {
+		msg, err := sub.NextMsgWithContext(readCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit stream: %w", err)
+		}
+
+		meta, err := msg.Metadata()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit metadata: %w", err)
+		}
+
+		var record models.AuditRecord
+		if err := json.Unmarshal(msg.Data, &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audit record: %w", err)
+		}
+		record.Sequence = meta.Sequence.Stream
+		page.Records = append(page.Records, record)
+
+		if record.Sequence >= last.Sequence {
+			return page, nil
+		}
+	}
+
+	page.NextCursor = page.Records[len(page.Records)-1].Sequence
+	return page, nil
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/natstest"
+
+	"github.com/nats-io/nats.go"
+)
+
+func newTestJetStream(t *testing.T) nats.JetStreamContext {
+	t.Helper()
+	return natstest.Start(t, natstest.Options{Streams: []*nats.StreamConfig{StreamConfig()}}).JS
+}
+
+func TestRecorderQuery(t *testing.T) {
+	recorder := NewRecorder(newTestJetStream(t))
+	ctx := WithActor(context.Background(), "alice")
+
+	for _, subject := range []string{"payments.order", "user", "payments.order"} {
+		err := recorder.Record(ctx, &models.AuditRecord{
+			Action:  models.AuditConfigChanged,
+			Subject: subject,
+		})
+		if err != nil {
+			t.Fatalf("failed to record: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      models.AuditQuery
+		wantCount  int
+		wantCursor bool
+	}{
+		{
+			name:      "should list all records",
+			query:     models.AuditQuery{},
+			wantCount: 3,
+		},
+		{
+			name:      "should filter by subject",
+			query:     models.AuditQuery{Subject: "payments.order"},
+			wantCount: 2,
+		},
+		{
+			name:       "should paginate with cursor",
+			query:      models.AuditQuery{Limit: 2},
+			wantCount:  2,
+			wantCursor: true,
+		},
+		{
+			name:      "should resume after cursor",
+			query:     models.AuditQuery{After: 2},
+			wantCount: 1,
+		},
+		{
+			name:      "should return empty page for unknown subject",
+			query:     models.AuditQuery{Subject: "unknown"},
+			wantCount: 0,
+		},
+	}
+
+	for _, subject := range []string{">", "*", "payments.>", "payments..order", "payments order"} {
+		t.Run("should reject subject "+subject, func(t *testing.T) {
+			_, err := recorder.Query(context.Background(), models.AuditQuery{Subject: subject})
+			if !errors.Is(err, ErrInvalidSubject) {
+				t.Fatalf("expected ErrInvalidSubject, got %v", err)
+			}
+		})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := recorder.Query(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(page.Records) != tt.wantCount {
+				t.Fatalf("expected %d records, got %d", tt.wantCount, len(page.Records))
+			}
+
+			if tt.wantCursor != (page.NextCursor != 0) {
+				t.Errorf("unexpected cursor %d", page.NextCursor)
+			}
+
+			for _, record := range page.Records {
+				if record.Actor != "alice" {
+					t.Errorf("expected actor alice, got %s", record.Actor)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// apiKeyRecord registro de uma API key; apenas o hash da chave é persistido
+type apiKeyRecord struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// APIKeyStore autentica API keys armazenadas (como hash SHA-256) no bucket KV
+type APIKeyStore struct {
+	kv nats.KeyValue
+}
+
+func NewAPIKeyStore(kv nats.KeyValue) *APIKeyStore {
+	return &APIKeyStore{kv: kv}
+}
+
+// HashKey calcula o hash persistido para uma API key
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Put grava uma API key associada ao nome do principal
+func (s *APIKeyStore) Put(name, key string) error {
+	if name == "" || key == "" {
+		return fmt.Errorf("api key name and value are required")
+	}
+
+	data, err := json.Marshal(apiKeyRecord{Name: name, CreatedAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal api key: %w", err)
+	}
+
+	if _, err := s.kv.Put(apiKeyEntry(key), data); err != nil {
+		return fmt.Errorf("failed to save api key: %w", err)
+	}
+	return nil
+}
+
+// Delete revoga uma API key
+func (s *APIKeyStore) Delete(key string) error {
+	return s.kv.Delete(apiKeyEntry(key))
+}
+
+// Bootstrap grava as chaves no formato "nome:chave,nome2:chave2"
+func (s *APIKeyStore) Bootstrap(spec string) error {
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, key, ok := strings.Cut(pair, ":")
+		if !ok {
+			return fmt.Errorf("invalid api key entry %q, expected name:key", pair)
+		}
+		if err := s.Put(name, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Authenticate valida o header X-API-Key (ou Authorization: ApiKey <chave>)
+func (s *APIKeyStore) Authenticate(r *http.Request) (*Principal, error) {
+	key := r.Header.Get("X-API-Key")
+	if key == "" {
+		if value, ok := strings.CutPrefix(r.Header.Get("Authorization"), "ApiKey "); ok {
+			key = strings.TrimSpace(value)
+		}
+	}
+	if key == "" {
+		return nil, nil
+	}
+
+	entry, err := s.kv.Get(apiKeyEntry(key))
+	if err != nil {
+		if err == nats.ErrKeyNotFound {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to lookup api key: %w", err)
+	}
+
+	var record apiKeyRecord
+	if err := json.Unmarshal(entry.Value(), &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal api key: %w", err)
+	}
+
+	return &Principal{Name: record.Name, Method: MethodAPIKey}, nil
+}
+
+func apiKeyEntry(key string) string {
+	return "auth.apikeys." + HashKey(key)
+}
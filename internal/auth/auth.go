@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+	MethodMTLS   = "mtls"
+)
+
+// ErrInvalidCredentials credenciais apresentadas, porém inválidas
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Principal identidade autenticada de quem faz a requisição
+type Principal struct {
+	Name   string `json:"name"`
+	Method string `json:"method"`
+}
+
+// Authenticator identifica o principal de uma requisição.
+// Retorna nil, nil quando a requisição não traz credenciais do seu tipo.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// Authenticate identifica o principal com o primeiro autenticador que reconhecer as credenciais.
+// Retorna nil, nil quando nenhum reconhece; erros que não são ErrInvalidCredentials indicam
+// falha do próprio autenticador (ex.: KV indisponível).
+func Authenticate(r *http.Request, authenticators []Authenticator) (*Principal, error) {
+	for _, authenticator := range authenticators {
+		principal, err := authenticator.Authenticate(r)
+		if err != nil || principal != nil {
+			return principal, err
+		}
+	}
+	return nil, nil
+}
+
+type principalKey struct{}
+
+// WithPrincipal associa o principal autenticado ao contexto
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext obtém o principal autenticado, se houver
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	input := encodeSegment(t, map[string]string{"alg": "RS256", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(input))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest.Sum(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, claims map[string]interface{}) string {
+	t.Helper()
+	input := encodeSegment(t, map[string]string{"alg": "ES256"}) + "." + encodeSegment(t, claims)
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(input))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest.Sum(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa-1",
+				"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec-1",
+				"crv": "P-256",
+				"x":   base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()),
+				"y":   base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()),
+			},
+		},
+	}
+	data, _ := json.Marshal(jwks)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := NewJWTAuthenticator(JWTConfig{JWKSFile: path, Issuer: "idp", Audience: "schema-registry"})
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub": "payments-ci",
+			"iss": "idp",
+			"aud": []string{"schema-registry"},
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		header        string
+		wantPrincipal string
+		wantErr       bool
+	}{
+		{
+			name:          "should accept RS256 token",
+			header:        "Bearer " + signRS256(t, rsaKey, "rsa-1", valid()),
+			wantPrincipal: "payments-ci",
+		},
+		{
+			name:          "should accept ES256 token",
+			header:        "Bearer " + signES256(t, ecKey, valid()),
+			wantPrincipal: "payments-ci",
+		},
+		{
+			name:   "should ignore requests without bearer token",
+			header: "",
+		},
+		{
+			name:    "should reject token signed by unknown key",
+			header:  "Bearer " + signRS256(t, otherKey, "rsa-1", valid()),
+			wantErr: true,
+		},
+		{
+			name: "should reject expired token",
+			header: "Bearer " + signRS256(t, rsaKey, "rsa-1", func() map[string]interface{} {
+				claims := valid()
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return claims
+			}()),
+			wantErr: true,
+		},
+		{
+			name: "should reject token without exp",
+			header: "Bearer " + signRS256(t, rsaKey, "rsa-1", func() map[string]interface{} {
+				claims := valid()
+				delete(claims, "exp")
+				return claims
+			}()),
+			wantErr: true,
+		},
+		{
+			name: "should reject unexpected audience",
+			header: "Bearer " + signRS256(t, rsaKey, "rsa-1", func() map[string]interface{} {
+				claims := valid()
+				claims["aud"] = "other"
+				return claims
+			}()),
+			wantErr: true,
+		},
+		{
+			name:    "should reject malformed token",
+			header:  "Bearer abc.def",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/subjects", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			principal, err := authenticator.Authenticate(req)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Fatalf("expected invalid credentials, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantPrincipal == "" {
+				if principal != nil {
+					t.Errorf("expected no principal, got %+v", principal)
+				}
+				return
+			}
+			if principal == nil || principal.Name != tt.wantPrincipal || principal.Method != MethodJWT {
+				t.Errorf("unexpected principal %+v", principal)
+			}
+		})
+	}
+}
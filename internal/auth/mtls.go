@@ -0,0 +1,24 @@
+package auth
+
+import "net/http"
+
+// CertAuthenticator identifica o principal pelo certificado de cliente (mTLS)
+type CertAuthenticator struct{}
+
+func NewCertAuthenticator() *CertAuthenticator {
+	return &CertAuthenticator{}
+}
+
+// Authenticate usa o CommonName do certificado verificado pelo handshake TLS
+func (a *CertAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+
+	cert := r.TLS.VerifiedChains[0][0]
+	if cert.Subject.CommonName == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{Name: cert.Subject.CommonName, Method: MethodMTLS}, nil
+}
@@ -0,0 +1,307 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+const (
+	// Format identifica o arquivo de backup
+	Format = "schema-registry-backup"
+	// FormatVersion versão do formato NDJSON gerado por Export; a partir da 2 o arquivo termina
+	// com um trailer de contagens
+	FormatVersion = 2
+
+	KindHeader  = "header"
+	KindSchema  = "schema"
+	KindConfig  = "config"
+	KindMode    = "mode"
+	KindTrailer = "trailer"
+)
+
+// Record uma linha do arquivo NDJSON de backup
+type Record struct {
+	Kind      string                `json:"kind"`
+	Format    string                `json:"format,omitempty"`
+	Version   int                   `json:"version,omitempty"`
+	CreatedAt *time.Time            `json:"created_at,omitempty"`
+	Schema    *models.Schema        `json:"schema,omitempty"`
+	Config    *models.SchemaConfig  `json:"config,omitempty"`
+	Mode      *models.SchemaMode    `json:"mode,omitempty"`
+	Counts    *models.RestoreReport `json:"counts,omitempty"`
+}
+
+// Source operações de leitura usadas pelo export
+type Source interface {
+	ListSubjects(ctx context.Context) ([]string, error)
+	ListVersions(ctx context.Context, subject string) ([]int, error)
+	GetSchema(ctx context.Context, subject string, version int) (*models.Schema, error)
+	ListConfigs(ctx context.Context) ([]*models.SchemaConfig, error)
+	ListModes(ctx context.Context) ([]*models.SchemaMode, error)
+}
+
+// Target operações usadas pelo restore
+type Target interface {
+	ListSubjects(ctx context.Context) ([]string, error)
+	ListVersions(ctx context.Context, subject string) ([]int, error)
+	GetSchema(ctx context.Context, subject string, version int) (*models.Schema, error)
+	ImportSchema(ctx context.Context, schema *models.Schema) (*models.Schema, error)
+	SetConfig(ctx context.Context, config *models.SchemaConfig) error
+	SetMode(ctx context.Context, mode *models.SchemaMode) error
+}
+
+// Export escreve todos os subjects, versões, configurações e modos em NDJSON
+func Export(ctx context.Context, source Source, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+
+	now := time.Now().UTC()
+	if err := encoder.Encode(Record{Kind: KindHeader, Format: Format, Version: FormatVersion, CreatedAt: &now}); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	subjects, err := source.ListSubjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list subjects: %w", err)
+	}
+
+	counts := &models.RestoreReport{}
+	for _, subject := range subjects {
+		versions, err := source.ListVersions(ctx, subject)
+		if err != nil {
+			return fmt.Errorf("failed to list versions of %s: %w", subject, err)
+		}
+
+		for _, version := range versions {
+			schema, err := source.GetSchema(ctx, subject, version)
+			if err != nil {
+				return fmt.Errorf("failed to get %s version %d: %w", subject, version, err)
+			}
+			if err := encoder.Encode(Record{Kind: KindSchema, Schema: schema}); err != nil {
+				return fmt.Errorf("failed to write schema: %w", err)
+			}
+			counts.Schemas++
+		}
+	}
+
+	configs, err := source.ListConfigs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list configs: %w", err)
+	}
+	for _, config := range configs {
+		if err := encoder.Encode(Record{Kind: KindConfig, Config: config}); err != nil {
+			return fmt.Errorf("failed to write config: %w", err)
+		}
+		counts.Configs++
+	}
+
+	modes, err := source.ListModes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list modes: %w", err)
+	}
+	for _, mode := range modes {
+		if err := encoder.Encode(Record{Kind: KindMode, Mode: mode}); err != nil {
+			return fmt.Errorf("failed to write mode: %w", err)
+		}
+		counts.Modes++
+	}
+
+	// O trailer permite ao restore detectar arquivos truncados
+	if err := encoder.Encode(Record{Kind: KindTrailer, Counts: counts}); err != nil {
+		return fmt.Errorf("failed to write trailer: %w", err)
+	}
+	return nil
+}
+
+// archive conteúdo de um backup lido e validado por inteiro
+type archive struct {
+	schemas []*models.Schema
+	configs []*models.SchemaConfig
+	modes   []*models.SchemaMode
+}
+
+// Restore importa um backup em um registry vazio, preservando IDs e versões. O arquivo é lido e
+// validado por inteiro antes de qualquer escrita. Com resume, o registry pode conter versões de
+// uma restauração anterior interrompida, desde que idênticas às do arquivo; elas são puladas.
+func Restore(ctx context.Context, target Target, r io.Reader, resume bool) (*models.RestoreReport, error) {
+	content, err := readArchive(r)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := existingSchemas(ctx, target, content, resume)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.RestoreReport{}
+	for _, schema := range content.schemas {
+		if existing[schemaKey(schema)] {
+			report.Skipped++
+			continue
+		}
+		if _, err := target.ImportSchema(ctx, schema); err != nil {
+			return report, fmt.Errorf("failed to restore %s version %d: %w", schema.Subject, schema.Version, err)
+		}
+		report.Schemas++
+	}
+
+	for _, config := range content.configs {
+		if err := target.SetConfig(ctx, config); err != nil {
+			return report, fmt.Errorf("failed to restore config of %s: %w", config.Subject, err)
+		}
+		report.Configs++
+	}
+
+	// Modos são aplicados por último para não bloquear a importação
+	for _, mode := range content.modes {
+		if err := target.SetMode(ctx, mode); err != nil {
+			return report, fmt.Errorf("failed to restore mode of %s: %w", mode.Subject, err)
+		}
+		report.Modes++
+	}
+
+	return report, nil
+}
+
+// readArchive lê o arquivo inteiro, exigindo header, registros válidos e um trailer final com as
+// contagens corretas
+func readArchive(r io.Reader) (*archive, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	content := &archive{}
+	seen := make(map[string]bool)
+	var trailer *models.RestoreReport
+	headerSeen := false
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: invalid record: %w", line, err)
+		}
+
+		if !headerSeen {
+			if record.Kind != KindHeader || record.Format != Format {
+				return nil, fmt.Errorf("line %d: not a %s archive", line, Format)
+			}
+			if record.Version > FormatVersion {
+				return nil, fmt.Errorf("unsupported backup version %d", record.Version)
+			}
+			headerSeen = true
+			continue
+		}
+		if trailer != nil {
+			return nil, fmt.Errorf("line %d: record after trailer", line)
+		}
+
+		switch record.Kind {
+		case KindSchema:
+			if record.Schema == nil {
+				return nil, fmt.Errorf("line %d: missing schema", line)
+			}
+			if err := record.Schema.Validate(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if record.Schema.ID == "" || record.Schema.Version <= 0 {
+				return nil, fmt.Errorf("line %d: schema requires id and version", line)
+			}
+			key := schemaKey(record.Schema)
+			if seen[key] {
+				return nil, fmt.Errorf("line %d: duplicate %s version %d", line, record.Schema.Subject, record.Schema.Version)
+			}
+			seen[key] = true
+			content.schemas = append(content.schemas, record.Schema)
+		case KindConfig:
+			if record.Config == nil {
+				return nil, fmt.Errorf("line %d: missing config", line)
+			}
+			if err := record.Config.Validate(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			content.configs = append(content.configs, record.Config)
+		case KindMode:
+			if record.Mode == nil {
+				return nil, fmt.Errorf("line %d: missing mode", line)
+			}
+			if err := record.Mode.Validate(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			content.modes = append(content.modes, record.Mode)
+		case KindTrailer:
+			if record.Counts == nil {
+				return nil, fmt.Errorf("line %d: missing counts", line)
+			}
+			trailer = record.Counts
+		default:
+			return nil, fmt.Errorf("line %d: unknown record kind %q", line, record.Kind)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	if !headerSeen {
+		return nil, fmt.Errorf("empty backup")
+	}
+	if trailer == nil {
+		return nil, fmt.Errorf("truncated backup: missing trailer")
+	}
+	if trailer.Schemas != len(content.schemas) || trailer.Configs != len(content.configs) || trailer.Modes != len(content.modes) {
+		return nil, fmt.Errorf("truncated backup: trailer expects %d schemas, %d configs and %d modes, found %d, %d and %d",
+			trailer.Schemas, trailer.Configs, trailer.Modes, len(content.schemas), len(content.configs), len(content.modes))
+	}
+
+	return content, nil
+}
+
+// existingSchemas verifica o registry de destino. Sem resume ele deve estar vazio; com resume,
+// cada versão existente deve ser idêntica à do arquivo
+func existingSchemas(ctx context.Context, target Target, content *archive, resume bool) (map[string]bool, error) {
+	subjects, err := target.ListSubjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subjects: %w", err)
+	}
+	if len(subjects) > 0 && !resume {
+		return nil, fmt.Errorf("restore requires an empty registry, found %d subjects", len(subjects))
+	}
+
+	expected := make(map[string]*models.Schema, len(content.schemas))
+	for _, schema := range content.schemas {
+		expected[schemaKey(schema)] = schema
+	}
+
+	existing := make(map[string]bool)
+	for _, subject := range subjects {
+		versions, err := target.ListVersions(ctx, subject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions of %s: %w", subject, err)
+		}
+		for _, version := range versions {
+			schema, err := target.GetSchema(ctx, subject, version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s version %d: %w", subject, version, err)
+			}
+			want, ok := expected[schemaKey(schema)]
+			if !ok || want.ID != schema.ID || want.Fingerprint() != schema.Fingerprint() {
+				return nil, fmt.Errorf("cannot resume restore: %s version %d differs from the backup", subject, version)
+			}
+			existing[schemaKey(schema)] = true
+		}
+	}
+	return existing, nil
+}
+
+func schemaKey(schema *models.Schema) string {
+	return fmt.Sprintf("%s/%d", schema.Subject, schema.Version)
+}
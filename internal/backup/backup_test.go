@@ -0,0 +1,210 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// memoryRegistry implementa Source e Target em memória
+type memoryRegistry struct {
+	schemas map[string]map[int]*models.Schema
+	configs []*models.SchemaConfig
+	modes   []*models.SchemaMode
+}
+
+func newMemoryRegistry() *memoryRegistry {
+	return &memoryRegistry{schemas: make(map[string]map[int]*models.Schema)}
+}
+
+func (m *memoryRegistry) ListSubjects(ctx context.Context) ([]string, error) {
+	subjects := []string{}
+	for subject := range m.schemas {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+	return subjects, nil
+}
+
+func (m *memoryRegistry) ListVersions(ctx context.Context, subject string) ([]int, error) {
+	versions := []int{}
+	for version := range m.schemas[subject] {
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+	return versions, nil
+}
+
+func (m *memoryRegistry) GetSchema(ctx context.Context, subject string, version int) (*models.Schema, error) {
+	schema, ok := m.schemas[subject][version]
+	if !ok {
+		return nil, fmt.Errorf("schema not found: %s version %d", subject, version)
+	}
+	return schema, nil
+}
+
+func (m *memoryRegistry) ListConfigs(ctx context.Context) ([]*models.SchemaConfig, error) {
+	return m.configs, nil
+}
+
+func (m *memoryRegistry) ListModes(ctx context.Context) ([]*models.SchemaMode, error) {
+	return m.modes, nil
+}
+
+func (m *memoryRegistry) ImportSchema(ctx context.Context, schema *models.Schema) (*models.Schema, error) {
+	if m.schemas[schema.Subject] == nil {
+		m.schemas[schema.Subject] = make(map[int]*models.Schema)
+	}
+	m.schemas[schema.Subject][schema.Version] = schema
+	return schema, nil
+}
+
+func (m *memoryRegistry) SetConfig(ctx context.Context, config *models.SchemaConfig) error {
+	m.configs = append(m.configs, config)
+	return nil
+}
+
+func (m *memoryRegistry) SetMode(ctx context.Context, mode *models.SchemaMode) error {
+	m.modes = append(m.modes, mode)
+	return nil
+}
+
+func TestExportRestore(t *testing.T) {
+	ctx := context.Background()
+	source := newMemoryRegistry()
+	for _, schema := range []*models.Schema{
+		{ID: "a-1", Subject: "payments.order", Version: 1, Schema: `{"type":"object"}`, SchemaType: models.SchemaTypeJSON},
+		{ID: "a-2", Subject: "payments.order", Version: 2, Schema: `{"type":"object"}`, SchemaType: models.SchemaTypeJSON},
+		{ID: "b-7", Subject: "user", Version: 7, Schema: `{"type":"string"}`, SchemaType: models.SchemaTypeAVRO},
+	} {
+		source.ImportSchema(ctx, schema)
+	}
+	source.configs = []*models.SchemaConfig{{Subject: "user", Compatibility: models.CompatibilityFull}}
+	source.modes = []*models.SchemaMode{{Subject: "user", Mode: models.ModeReadOnly}}
+
+	var archive bytes.Buffer
+	if err := Export(ctx, source, &archive); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	target := newMemoryRegistry()
+	report, err := Restore(ctx, target, bytes.NewReader(archive.Bytes()), false)
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+
+	if report.Schemas != 3 || report.Configs != 1 || report.Modes != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	restored, err := target.GetSchema(ctx, "user", 7)
+	if err != nil {
+		t.Fatalf("expected user version 7 to be restored: %v", err)
+	}
+	if restored.ID != "b-7" {
+		t.Errorf("expected id b-7 to be preserved, got %s", restored.ID)
+	}
+
+	// Retomada de uma restauração interrompida após a primeira versão
+	partial := newMemoryRegistry()
+	partial.ImportSchema(ctx, source.schemas["payments.order"][1])
+	report, err = Restore(ctx, partial, bytes.NewReader(archive.Bytes()), true)
+	if err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	if report.Schemas != 2 || report.Skipped != 1 {
+		t.Errorf("unexpected resume report: %+v", report)
+	}
+
+	// Não retoma sobre conteúdo diferente do arquivo
+	diverged := newMemoryRegistry()
+	diverged.ImportSchema(ctx, &models.Schema{ID: "x-1", Subject: "payments.order", Version: 1, Schema: `{"type":"string"}`, SchemaType: models.SchemaTypeJSON})
+	if _, err := Restore(ctx, diverged, bytes.NewReader(archive.Bytes()), true); err == nil || !strings.Contains(err.Error(), "differs from the backup") {
+		t.Errorf("expected resume over different content to fail, got %v", err)
+	}
+}
+
+func TestRestoreErrors(t *testing.T) {
+	header := `{"kind":"header","format":"schema-registry-backup","version":2}`
+	schemaRecord := `{"kind":"schema","schema":{"id":"a-1","subject":"user","version":1,"schema_type":"JSON","schema":"{}"}}`
+
+	tests := []struct {
+		name    string
+		archive string
+		target  func() *memoryRegistry
+		wantErr string
+	}{
+		{
+			name:    "should reject non empty registry",
+			archive: header + "\n" + `{"kind":"trailer","counts":{"schemas":0,"configs":0,"modes":0}}`,
+			target: func() *memoryRegistry {
+				m := newMemoryRegistry()
+				m.ImportSchema(context.Background(), &models.Schema{Subject: "user", Version: 1})
+				return m
+			},
+			wantErr: "empty registry",
+		},
+		{
+			name:    "should reject missing header",
+			archive: `{"kind":"schema","schema":{"subject":"user","version":1}}`,
+			target:  newMemoryRegistry,
+			wantErr: "not a schema-registry-backup archive",
+		},
+		{
+			name:    "should reject newer format version",
+			archive: `{"kind":"header","format":"schema-registry-backup","version":99}`,
+			target:  newMemoryRegistry,
+			wantErr: "unsupported backup version",
+		},
+		{
+			name:    "should reject empty archive",
+			archive: "",
+			target:  newMemoryRegistry,
+			wantErr: "empty backup",
+		},
+		{
+			name:    "should reject archive without trailer",
+			archive: header + "\n" + schemaRecord,
+			target:  newMemoryRegistry,
+			wantErr: "truncated backup: missing trailer",
+		},
+		{
+			name:    "should reject archive with fewer records than the trailer",
+			archive: header + "\n" + schemaRecord + "\n" + `{"kind":"trailer","counts":{"schemas":2,"configs":0,"modes":0}}`,
+			target:  newMemoryRegistry,
+			wantErr: "trailer expects 2 schemas",
+		},
+		{
+			name:    "should reject records after trailer",
+			archive: header + "\n" + `{"kind":"trailer","counts":{"schemas":0,"configs":0,"modes":0}}` + "\n" + schemaRecord,
+			target:  newMemoryRegistry,
+			wantErr: "record after trailer",
+		},
+		{
+			name: "should reject invalid records before writing",
+			archive: header + "\n" + schemaRecord + "\n" + `{"kind":"config","config":{"subject":"user","compatibility":"SIDEWAYS"}}` + "\n" +
+				`{"kind":"trailer","counts":{"schemas":1,"configs":1,"modes":0}}`,
+			target:  newMemoryRegistry,
+			wantErr: "invalid compatibility",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target()
+			before := len(target.schemas)
+			_, err := Restore(context.Background(), target, strings.NewReader(tt.archive), false)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(target.schemas) != before || len(target.configs) != 0 {
+				t.Errorf("expected nothing to be written, got %d subjects and %d configs", len(target.schemas), len(target.configs))
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/dtos"
+	"github.com/rodrigues-daniel/data-platform/internal/mappers"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// apiVersion prefixo da versão da API usada pelo cliente
+const apiVersion = "/v1"
+
+// Client cliente HTTP da API do Schema Registry
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	apiKey     string
+	token      string
+}
+
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// SetCredentials define a API key ou o bearer token enviados em cada requisição
+func (c *Client) SetCredentials(apiKey, token string) {
+	c.apiKey = apiKey
+	c.token = token
+}
+
+// Backup grava em w o backup NDJSON do registry
+func (c *Client) Backup(ctx context.Context, w io.Writer) error {
+	resp, err := c.send(ctx, http.MethodGet, "/admin/backup", nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// Restore envia um backup NDJSON para o registry; com resume retoma uma restauração interrompida
+func (c *Client) Restore(ctx context.Context, r io.Reader, resume bool) (*models.RestoreReport, error) {
+	path := "/admin/restore"
+	if resume {
+		path += "?resume=true"
+	}
+
+	var report models.RestoreReport
+	if err := c.do(ctx, http.MethodPost, path, r, "application/x-ndjson", &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ImportConfluent envia um export do Confluent Schema Registry para importação
+func (c *Client) ImportConfluent(ctx context.Context, r io.Reader) (*models.ImportReport, error) {
+	var report models.ImportReport
+	if err := c.do(ctx, http.MethodPost, "/admin/import/confluent", r, "text/plain", &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetLatestSchema obtém a última versão de um subject; retorna nil se o subject não existir
+func (c *Client) GetLatestSchema(ctx context.Context, subject string) (*models.Schema, error) {
+	var resp dtos.SchemaResponse
+	err := c.do(ctx, http.MethodGet, "/schemas/"+url.PathEscape(subject)+"/versions/latest", nil, "", &resp)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	schema := mappers.MapSchemaResponseToModel(resp)
+	return &schema, nil
+}
+
+// GetConfig obtém a configuração de compatibilidade de um subject
+func (c *Client) GetConfig(ctx context.Context, subject string) (*models.SchemaConfig, error) {
+	var resp dtos.SchemaConfigResponse
+	if err := c.do(ctx, http.MethodGet, "/config/"+url.PathEscape(subject), nil, "", &resp); err != nil {
+		return nil, err
+	}
+	return &models.SchemaConfig{Subject: resp.Subject, Compatibility: resp.Compatibility, MaxVersions: resp.MaxVersions}, nil
+}
+
+// SetConfig define a configuração de compatibilidade de um subject
+func (c *Client) SetConfig(ctx context.Context, config *models.SchemaConfig) error {
+	body, err := json.Marshal(dtos.SchemaConfigRequest{
+		Compatibility: config.Compatibility,
+		MaxVersions:   config.MaxVersions,
+	})
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPut, "/config/"+url.PathEscape(config.Subject), bytes.NewReader(body), "application/json", nil)
+}
+
+// CheckCompatibility verifica o schema contra a última versão do subject no nível informado
+// (vazio = configurado no subject)
+func (c *Client) CheckCompatibility(ctx context.Context, subject, schemaType, schema, compatibility string) (*models.SchemaValidationResult, error) {
+	body, err := json.Marshal(dtos.CompatibilityCheckRequest{
+		SchemaType:    schemaType,
+		Schema:        mappers.SchemaToRaw(schemaType, schema),
+		Compatibility: compatibility,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp dtos.CompatibilityResponse
+	path := "/compatibility/subjects/" + url.PathEscape(subject) + "/versions"
+	if err := c.do(ctx, http.MethodPost, path, bytes.NewReader(body), "application/json", &resp); err != nil {
+		return nil, err
+	}
+	return &models.SchemaValidationResult{Valid: resp.IsCompatible, Errors: resp.Errors, Warnings: resp.Warnings}, nil
+}
+
+// RegisterSchema registra uma nova versão de schema
+func (c *Client) RegisterSchema(ctx context.Context, schema *models.Schema) (*models.Schema, error) {
+	body, err := json.Marshal(dtos.CreateSchemaRequest{
+		Subject:    schema.Subject,
+		SchemaType: schema.SchemaType,
+		Schema:     mappers.SchemaToRaw(schema.SchemaType, schema.Schema),
+		References: schema.References,
+		Metadata:   schema.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp dtos.SchemaResponse
+	path := "/schemas/" + url.PathEscape(schema.Subject) + "/versions"
+	if err := c.do(ctx, http.MethodPost, path, bytes.NewReader(body), "application/json", &resp); err != nil {
+		return nil, err
+	}
+	registered := mappers.MapSchemaResponseToModel(resp)
+	return &registered, nil
+}
+
+// do executa a requisição e decodifica o campo data do envelope de resposta
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader, contentType string, out interface{}) error {
+	resp, err := c.send(ctx, method, path, body, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+
+	envelope := models.SchemaResponse{Data: out}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+apiVersion+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// APIError erro retornado pela API
+type APIError struct {
+	StatusCode int
+	Code       int
+	Message    string
+	Details    map[string]interface{}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func decodeError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: "unexpected response"}
+
+	var envelope models.SchemaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err == nil && envelope.Error != "" {
+		apiErr.Message = envelope.Error
+		apiErr.Code = envelope.ErrorCode
+		apiErr.Details = envelope.Details
+	}
+	return apiErr
+}
@@ -0,0 +1,333 @@
+package confluent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// Target operações do registry usadas na importação
+type Target interface {
+	GetSchema(ctx context.Context, subject string, version int) (*models.Schema, error)
+	GetSchemaByID(ctx context.Context, schemaID string) (*models.Schema, error)
+	ImportSchema(ctx context.Context, schema *models.Schema) (*models.Schema, error)
+	SetConfig(ctx context.Context, config *models.SchemaConfig) error
+	SetMode(ctx context.Context, mode *models.SchemaMode) error
+}
+
+// schemaValue valor de uma mensagem SCHEMA do tópico _schemas (ou item da listagem REST)
+type schemaValue struct {
+	Subject    string             `json:"subject"`
+	Version    int                `json:"version"`
+	ID         int                `json:"id"`
+	SchemaType string             `json:"schemaType"`
+	Schema     string             `json:"schema"`
+	References []models.Reference `json:"references"`
+	Deleted    bool               `json:"deleted"`
+}
+
+type messageKey struct {
+	KeyType string `json:"keytype"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type versionKey struct {
+	subject string
+	version int
+}
+
+// snapshot estado final obtido ao reproduzir o export
+type snapshot struct {
+	schemas  map[versionKey]*schemaValue
+	configs  map[string]string
+	modes    map[string]string
+	warnings []string
+}
+
+func newSnapshot() *snapshot {
+	return &snapshot{
+		schemas: make(map[versionKey]*schemaValue),
+		configs: make(map[string]string),
+		modes:   make(map[string]string),
+	}
+}
+
+// Import lê um dump do tópico _schemas ou a listagem JSON da API REST e reproduz no registry.
+// Conflitos são reportados e a importação continua com os itens seguintes.
+func Import(ctx context.Context, target Target, r io.Reader) (*models.ImportReport, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+
+	var snap *snapshot
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		snap, err = parseRESTListing(trimmed)
+	} else {
+		snap, err = parseTopicDump(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return snap.apply(ctx, target), nil
+}
+
+// parseRESTListing interpreta a resposta de GET /schemas do Confluent Schema Registry
+func parseRESTListing(data []byte) (*snapshot, error) {
+	var values []schemaValue
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("invalid REST listing: %w", err)
+	}
+
+	snap := newSnapshot()
+	for i := range values {
+		value := values[i]
+		snap.schemas[versionKey{value.Subject, value.Version}] = &value
+	}
+	return snap, nil
+}
+
+// parseTopicDump interpreta linhas "chave<TAB>valor" consumidas do tópico _schemas
+func parseTopicDump(data []byte) (*snapshot, error) {
+	snap := newSnapshot()
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		rawKey, rawValue, ok := strings.Cut(text, "\t")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key and value separated by tab", line)
+		}
+
+		var key messageKey
+		if err := json.Unmarshal([]byte(rawKey), &key); err != nil {
+			return nil, fmt.Errorf("line %d: invalid key: %w", line, err)
+		}
+		tombstone := rawValue == "" || rawValue == "null"
+
+		switch key.KeyType {
+		case "SCHEMA":
+			if tombstone {
+				delete(snap.schemas, versionKey{key.Subject, key.Version})
+				continue
+			}
+			var value schemaValue
+			if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+				return nil, fmt.Errorf("line %d: invalid schema value: %w", line, err)
+			}
+			snap.schemas[versionKey{value.Subject, value.Version}] = &value
+
+		case "CONFIG":
+			if tombstone {
+				delete(snap.configs, key.Subject)
+				continue
+			}
+			var value struct {
+				CompatibilityLevel string `json:"compatibilityLevel"`
+			}
+			if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+				return nil, fmt.Errorf("line %d: invalid config value: %w", line, err)
+			}
+			snap.configs[key.Subject] = value.CompatibilityLevel
+
+		case "MODE":
+			if tombstone {
+				delete(snap.modes, key.Subject)
+				continue
+			}
+			var value struct {
+				Mode string `json:"mode"`
+			}
+			if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+				return nil, fmt.Errorf("line %d: invalid mode value: %w", line, err)
+			}
+			snap.modes[key.Subject] = value.Mode
+
+		case "DELETE_SUBJECT", "CLEAR_SUBJECT":
+			if tombstone {
+				continue
+			}
+			var value struct {
+				Subject string `json:"subject"`
+				Version int    `json:"version"`
+			}
+			if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+				return nil, fmt.Errorf("line %d: invalid delete value: %w", line, err)
+			}
+			for k, schema := range snap.schemas {
+				if k.subject == value.Subject && k.version <= value.Version {
+					schema.Deleted = true
+				}
+			}
+
+		case "NOOP":
+			continue
+
+		default:
+			snap.warnings = append(snap.warnings, fmt.Sprintf("line %d: ignored unknown keytype %q", line, key.KeyType))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read topic dump: %w", err)
+	}
+
+	return snap, nil
+}
+
+// apply grava o snapshot no registry: schemas por subject/versão, depois configurações e modos
+func (s *snapshot) apply(ctx context.Context, target Target) *models.ImportReport {
+	report := &models.ImportReport{Warnings: s.warnings}
+
+	keys := make([]versionKey, 0, len(s.schemas))
+	for k := range s.schemas {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].subject != keys[j].subject {
+			return keys[i].subject < keys[j].subject
+		}
+		return keys[i].version < keys[j].version
+	})
+
+	for _, k := range keys {
+		value := s.schemas[k]
+		if value.Deleted {
+			report.Skipped++
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s version %d is soft-deleted and was not imported", k.subject, k.version))
+			continue
+		}
+
+		schema := &models.Schema{
+			ID:         strconv.Itoa(value.ID),
+			Subject:    value.Subject,
+			Version:    value.Version,
+			Schema:     value.Schema,
+			SchemaType: mapSchemaType(value.SchemaType),
+			References: value.References,
+		}
+
+		if conflict, skip := checkExisting(ctx, target, schema); conflict != nil {
+			report.Conflicts = append(report.Conflicts, *conflict)
+			continue
+		} else if skip {
+			report.Skipped++
+			continue
+		}
+
+		if _, err := target.ImportSchema(ctx, schema); err != nil {
+			report.Conflicts = append(report.Conflicts, models.ImportConflict{
+				Subject: schema.Subject,
+				Version: schema.Version,
+				ID:      schema.ID,
+				Reason:  err.Error(),
+			})
+			continue
+		}
+		report.Imported++
+	}
+
+	for _, subject := range sortedKeys(s.configs) {
+		if subject == "" {
+			report.Warnings = append(report.Warnings, "global compatibility config is not supported and was not imported")
+			continue
+		}
+
+		compatibility, warning := mapCompatibility(s.configs[subject])
+		if warning != "" {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s: %s", subject, warning))
+		}
+
+		if err := target.SetConfig(ctx, &models.SchemaConfig{Subject: subject, Compatibility: compatibility}); err != nil {
+			report.Conflicts = append(report.Conflicts, models.ImportConflict{Subject: subject, Reason: err.Error()})
+			continue
+		}
+		report.Configs++
+	}
+
+	for _, subject := range sortedKeys(s.modes) {
+		if subject == "" {
+			report.Warnings = append(report.Warnings, "global mode is not supported and was not imported")
+			continue
+		}
+
+		if err := target.SetMode(ctx, &models.SchemaMode{Subject: subject, Mode: s.modes[subject]}); err != nil {
+			report.Conflicts = append(report.Conflicts, models.ImportConflict{Subject: subject, Reason: err.Error()})
+			continue
+		}
+		report.Modes++
+	}
+
+	return report
+}
+
+// checkExisting detecta schemas já importados (skip) ou que colidem com o estado atual (conflito)
+func checkExisting(ctx context.Context, target Target, schema *models.Schema) (*models.ImportConflict, bool) {
+	if existing, err := target.GetSchema(ctx, schema.Subject, schema.Version); err == nil {
+		if existing.ID == schema.ID && existing.Schema == schema.Schema {
+			return nil, true
+		}
+		return &models.ImportConflict{
+			Subject: schema.Subject,
+			Version: schema.Version,
+			ID:      schema.ID,
+			Reason:  fmt.Sprintf("version already registered with different content (id %s)", existing.ID),
+		}, false
+	}
+
+	// O Confluent reutiliza o ID de um conteúdo idêntico registrado em outro subject
+	if existing, err := target.GetSchemaByID(ctx, schema.ID); err == nil && !existing.SameContent(schema) {
+		return &models.ImportConflict{
+			Subject: schema.Subject,
+			Version: schema.Version,
+			ID:      schema.ID,
+			Reason:  fmt.Sprintf("id already used by %s version %d with different content", existing.Subject, existing.Version),
+		}, false
+	}
+
+	return nil, false
+}
+
+// mapSchemaType converte o schemaType do Confluent, onde ausência significa AVRO
+func mapSchemaType(schemaType string) string {
+	if schemaType == "" {
+		return models.SchemaTypeAVRO
+	}
+	return strings.ToUpper(schemaType)
+}
+
+// mapCompatibility converte níveis de compatibilidade; variantes transitivas são rebaixadas
+func mapCompatibility(level string) (string, string) {
+	switch level {
+	case "BACKWARD_TRANSITIVE", "FORWARD_TRANSITIVE", "FULL_TRANSITIVE":
+		base := strings.TrimSuffix(level, "_TRANSITIVE")
+		return base, fmt.Sprintf("%s imported as %s", level, base)
+	}
+	return level, ""
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,139 @@
+package confluent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+type memoryTarget struct {
+	schemas map[string]*models.Schema
+	configs map[string]string
+	modes   map[string]string
+}
+
+func newMemoryTarget() *memoryTarget {
+	return &memoryTarget{
+		schemas: make(map[string]*models.Schema),
+		configs: make(map[string]string),
+		modes:   make(map[string]string),
+	}
+}
+
+func (m *memoryTarget) GetSchema(ctx context.Context, subject string, version int) (*models.Schema, error) {
+	if schema, ok := m.schemas[fmt.Sprintf("%s/%d", subject, version)]; ok {
+		return schema, nil
+	}
+	return nil, fmt.Errorf("schema not found: %s version %d", subject, version)
+}
+
+func (m *memoryTarget) GetSchemaByID(ctx context.Context, schemaID string) (*models.Schema, error) {
+	for _, schema := range m.schemas {
+		if schema.ID == schemaID {
+			return schema, nil
+		}
+	}
+	return nil, fmt.Errorf("schema not found: %s", schemaID)
+}
+
+func (m *memoryTarget) ImportSchema(ctx context.Context, schema *models.Schema) (*models.Schema, error) {
+	m.schemas[fmt.Sprintf("%s/%d", schema.Subject, schema.Version)] = schema
+	return schema, nil
+}
+
+func (m *memoryTarget) SetConfig(ctx context.Context, config *models.SchemaConfig) error {
+	m.configs[config.Subject] = config.Compatibility
+	return nil
+}
+
+func (m *memoryTarget) SetMode(ctx context.Context, mode *models.SchemaMode) error {
+	m.modes[mode.Subject] = mode.Mode
+	return nil
+}
+
+func TestImportTopicDump(t *testing.T) {
+	dump := strings.Join([]string{
+		`{"keytype":"SCHEMA","subject":"orders-value","version":1,"magic":1}` + "\t" + `{"subject":"orders-value","version":1,"id":100,"schema":"{\"type\":\"string\"}"}`,
+		`{"keytype":"SCHEMA","subject":"orders-value","version":2,"magic":1}` + "\t" + `{"subject":"orders-value","version":2,"id":101,"schemaType":"JSON","schema":"{\"type\":\"object\"}"}`,
+		`{"keytype":"SCHEMA","subject":"legacy","version":1,"magic":1}` + "\t" + `{"subject":"legacy","version":1,"id":50,"schema":"{\"type\":\"int\"}"}`,
+		`{"keytype":"DELETE_SUBJECT","subject":"legacy","magic":0}` + "\t" + `{"subject":"legacy","version":1}`,
+		`{"keytype":"CONFIG","subject":"orders-value","magic":0}` + "\t" + `{"compatibilityLevel":"FULL_TRANSITIVE"}`,
+		`{"keytype":"CONFIG","subject":null,"magic":0}` + "\t" + `{"compatibilityLevel":"BACKWARD"}`,
+		`{"keytype":"MODE","subject":"orders-value","magic":0}` + "\t" + `{"mode":"READONLY"}`,
+		`{"keytype":"NOOP","magic":0}` + "\t" + `null`,
+	}, "\n")
+
+	target := newMemoryTarget()
+	report, err := Import(context.Background(), target, strings.NewReader(dump))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Imported != 2 || report.Skipped != 1 || report.Configs != 1 || report.Modes != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	v1, err := target.GetSchema(context.Background(), "orders-value", 1)
+	if err != nil {
+		t.Fatalf("expected orders-value version 1: %v", err)
+	}
+	if v1.ID != "100" || v1.SchemaType != models.SchemaTypeAVRO {
+		t.Errorf("expected id 100 and AVRO type, got %s/%s", v1.ID, v1.SchemaType)
+	}
+
+	if target.configs["orders-value"] != models.CompatibilityFull {
+		t.Errorf("expected FULL compatibility, got %s", target.configs["orders-value"])
+	}
+	if len(report.Warnings) != 3 {
+		t.Errorf("expected 3 warnings, got %v", report.Warnings)
+	}
+}
+
+func TestImportRESTListingConflicts(t *testing.T) {
+	listing := `[
+		{"subject":"user","version":1,"id":1,"schemaType":"JSON","schema":"{}"},
+		{"subject":"user","version":2,"id":2,"schemaType":"JSON","schema":"{\"type\":\"object\"}"},
+		{"subject":"other","version":1,"id":3,"schemaType":"JSON","schema":"{}"}
+	]`
+
+	target := newMemoryTarget()
+	target.ImportSchema(context.Background(), &models.Schema{ID: "1", Subject: "user", Version: 1, Schema: "{}"})
+	target.ImportSchema(context.Background(), &models.Schema{ID: "9", Subject: "user", Version: 2, Schema: "{}"})
+	target.ImportSchema(context.Background(), &models.Schema{ID: "3", Subject: "taken", Version: 1, Schema: "{}"})
+
+	report, err := Import(context.Background(), target, strings.NewReader(listing))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Imported != 0 || report.Skipped != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if len(report.Conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts, got %+v", report.Conflicts)
+	}
+}
+
+func TestImportSharedID(t *testing.T) {
+	listing := `[
+		{"subject":"orders-value","version":1,"id":7,"schema":"{\"type\":\"string\"}"},
+		{"subject":"refunds-value","version":1,"id":7,"schema":"{\"type\":\"string\"}"},
+		{"subject":"users-value","version":1,"id":7,"schema":"{\"type\":\"int\"}"}
+	]`
+
+	target := newMemoryTarget()
+	report, err := Import(context.Background(), target, strings.NewReader(listing))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Imported != 2 {
+		t.Errorf("expected identical content to share id 7, got %+v", report)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Subject != "users-value" {
+		t.Errorf("expected conflict only for different content, got %+v", report.Conflicts)
+	}
+}
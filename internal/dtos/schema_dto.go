@@ -23,8 +23,18 @@ type UpdateSchemaRequest struct {
 	Metadata   map[string]string `json:"metadata,omitempty"`
 }
 
+type LookupSchemaRequest struct {
+	SchemaType string             `json:"schema_type"`
+	Schema     json.RawMessage    `json:"schema" validate:"required"`
+	References []models.Reference `json:"references,omitempty"`
+}
+
 type CompatibilityCheckRequest struct {
-	Schema json.RawMessage `json:"schema" validate:"required"`
+	// SchemaType vazio: detectado pelo conteúdo
+	SchemaType string          `json:"schema_type,omitempty"`
+	Schema     json.RawMessage `json:"schema" validate:"required"`
+	// Compatibility vazio: nível configurado no subject
+	Compatibility string `json:"compatibility,omitempty"`
 }
 
 type ValidateDataRequest struct {
@@ -32,21 +42,100 @@ type ValidateDataRequest struct {
 	Data   interface{}     `json:"data" validate:"required"`
 }
 
+// Requisições da API NATS; equivalem aos parâmetros de query das rotas /v1
+type SchemaLookupRequest struct {
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+type SubjectQueryRequest struct {
+	Prefix   string            `json:"prefix,omitempty"`
+	Contains string            `json:"contains,omitempty"`
+	Type     string            `json:"type,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Deleted  string            `json:"deleted,omitempty"`
+	Order    string            `json:"order,omitempty"`
+	After    string            `json:"after,omitempty"`
+	Limit    int               `json:"limit,omitempty"`
+	Verbose  bool              `json:"verbose,omitempty"`
+}
+
+type VersionQueryRequest struct {
+	Deleted string `json:"deleted,omitempty"`
+	Order   string `json:"order,omitempty"`
+	After   int    `json:"after,omitempty"`
+	Limit   int    `json:"limit,omitempty"`
+}
+
 // Config DTOs
 type SchemaConfigRequest struct {
 	Compatibility string `json:"compatibility" validate:"required,oneof=BACKWARD FORWARD FULL NONE"`
+	MaxVersions   int    `json:"max_versions,omitempty" validate:"min=0"`
+}
+
+type SchemaConfigResponse struct {
+	Subject       string `json:"subject"`
+	Compatibility string `json:"compatibility"`
+	MaxVersions   int    `json:"max_versions,omitempty"`
+}
+
+// Mode DTOs
+type SchemaModeRequest struct {
+	Mode string `json:"mode" validate:"required,oneof=READWRITE READONLY IMPORT"`
+}
+
+type SchemaModeResponse struct {
+	Subject string `json:"subject"`
+	Mode    string `json:"mode"`
 }
 
 // Response DTOs
 type SchemaResponse struct {
-	ID         string            `json:"id"`
-	Subject    string            `json:"subject"`
-	Version    int               `json:"version"`
-	Schema     json.RawMessage   `json:"schema"`
-	SchemaType string            `json:"schema_type"`
-	References []Reference       `json:"references,omitempty"`
-	Metadata   map[string]string `json:"metadata,omitempty"`
-	CreatedAt  time.Time         `json:"created_at"`
+	ID               string            `json:"id"`
+	Subject          string            `json:"subject"`
+	Version          int               `json:"version"`
+	Schema           json.RawMessage   `json:"schema"`
+	SchemaType       string            `json:"schema_type"`
+	NormalizedSchema json.RawMessage   `json:"normalized_schema,omitempty"`
+	Fingerprint      string            `json:"fingerprint,omitempty"`
+	References       []Reference       `json:"references,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	Deleted          bool              `json:"deleted,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+}
+
+// Listagens paginadas: next_cursor é enviado em after para obter a próxima página.
+// Total é o número de subjects nesta página (já filtrados pelo ACL), não o total do registry.
+type SubjectListResponse struct {
+	Subjects   []string `json:"subjects"`
+	Total      int      `json:"total"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+type SubjectDetailListResponse struct {
+	Subjects   []SubjectDetail `json:"subjects"`
+	Total      int             `json:"total"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+type SubjectDetail struct {
+	Subject       string                `json:"subject"`
+	LatestVersion int                   `json:"latest_version"`
+	SchemaType    string                `json:"schema_type"`
+	Metadata      map[string]string     `json:"metadata,omitempty"`
+	Deleted       bool                  `json:"deleted,omitempty"`
+	Config        *SchemaConfigResponse `json:"config,omitempty"`
+}
+
+type VersionListResponse struct {
+	Subject    string `json:"subject"`
+	Versions   []int  `json:"versions"`
+	NextCursor int    `json:"next_cursor,omitempty"`
+}
+
+type DeleteSchemaResponse struct {
+	Subject   string `json:"subject"`
+	Version   int    `json:"version"`
+	Permanent bool   `json:"permanent"`
 }
 
 type SchemaListResponse struct {
@@ -58,6 +147,8 @@ type CompatibilityResponse struct {
 	IsCompatible bool     `json:"is_compatible"`
 	Errors       []string `json:"errors,omitempty"`
 	Warnings     []string `json:"warnings,omitempty"`
+	// Incompatibilities presente apenas no modo verbose
+	Incompatibilities []models.SchemaIncompatibility `json:"incompatibilities,omitempty"`
 }
 
 type ValidationResponse struct {
@@ -66,6 +157,47 @@ type ValidationResponse struct {
 	Warnings []string `json:"warnings,omitempty"`
 }
 
+// Batch DTOs
+type BatchRegisterRequest struct {
+	Atomic  bool                  `json:"atomic,omitempty"`
+	Schemas []CreateSchemaRequest `json:"schemas"`
+}
+
+type BatchGetRequest struct {
+	Items []BatchGetItem `json:"items"`
+}
+
+type BatchGetItem struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version,omitempty"` // 0 = latest
+}
+
+type BatchCompatibilityRequest struct {
+	Items []BatchCompatibilityItem `json:"items"`
+}
+
+type BatchCompatibilityItem struct {
+	Subject    string          `json:"subject"`
+	SchemaType string          `json:"schema_type,omitempty"`
+	Schema     json.RawMessage `json:"schema"`
+}
+
+type BatchResponse struct {
+	Results   []BatchItemResult `json:"results"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+type BatchItemResult struct {
+	Index     int                    `json:"index"`
+	Subject   string                 `json:"subject"`
+	Status    int                    `json:"status"`
+	Data      interface{}            `json:"data,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	ErrorCode int                    `json:"error_code,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
 // Common structs
 type Reference struct {
 	Name    string `json:"name"`
@@ -0,0 +1,177 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"github.com/nats-io/nats.go"
+)
+
+// DefaultBatch eventos lidos por chamada de Fetch quando batch não é informado
+const DefaultBatch = 100
+
+// ConsumerConfig configuração de um consumer durável do stream SCHEMA_EVENTS
+type ConsumerConfig struct {
+	// Durable nome do consumer; a posição de leitura é mantida pelo servidor entre reinícios
+	Durable string
+	// Subject filtro de subjects do registry (ex.: "payments.order" ou "payments.>"); vazio = todos
+	Subject string
+	// StartSequence primeira sequência lida na criação do consumer (0 = início do stream)
+	StartSequence uint64
+	// StartTime lê a partir dos eventos publicados neste instante, quando StartSequence não é informada
+	StartTime time.Time
+	// MaxAckPending eventos entregues sem ack; 1 garante processamento em ordem (0 = padrão do servidor)
+	MaxAckPending int
+	// AckWait prazo para o ack antes da reentrega (0 = padrão do servidor)
+	AckWait time.Duration
+}
+
+// filter subject NATS correspondente ao filtro de subjects do registry
+func (c ConsumerConfig) filter() string {
+	if c.Subject == "" {
+		return schema.EventSubjectPrefix + ">"
+	}
+	return schema.EventSubjectPrefix + c.Subject
+}
+
+// Event evento lido do stream; Ack confirma o processamento
+type Event struct {
+	models.SchemaEvent
+	// Attempt número da entrega deste evento ao consumer (1 na primeira)
+	Attempt int
+	msg     *nats.Msg
+}
+
+// Ack confirma o evento; eventos sem ack são reentregues após o AckWait do consumer
+func (e *Event) Ack() error {
+	return e.msg.Ack()
+}
+
+// Nak pede a reentrega imediata do evento
+func (e *Event) Nak() error {
+	return e.msg.Nak()
+}
+
+// Retry pede a reentrega do evento após delay
+func (e *Event) Retry(delay time.Duration) error {
+	return e.msg.NakWithDelay(delay)
+}
+
+// Consumer lê eventos de schema por um consumer durável (pull) com ack explícito
+type Consumer struct {
+	sub *nats.Subscription
+}
+
+// NewConsumer cria o consumer durável ou retoma o existente a partir do último ack
+func NewConsumer(js nats.JetStreamContext, config ConsumerConfig) (*Consumer, error) {
+	if config.Durable == "" {
+		return nil, errors.New("durable name is required")
+	}
+
+	_, err := js.ConsumerInfo(schema.EventStreamName, config.Durable)
+	if errors.Is(err, nats.ErrConsumerNotFound) {
+		consumer := &nats.ConsumerConfig{
+			Durable:       config.Durable,
+			FilterSubject: config.filter(),
+			AckPolicy:     nats.AckExplicitPolicy,
+			DeliverPolicy: nats.DeliverAllPolicy,
+			MaxAckPending: config.MaxAckPending,
+			AckWait:       config.AckWait,
+		}
+		switch {
+		case config.StartSequence > 0:
+			consumer.DeliverPolicy = nats.DeliverByStartSequencePolicy
+			consumer.OptStartSeq = config.StartSequence
+		case !config.StartTime.IsZero():
+			consumer.DeliverPolicy = nats.DeliverByStartTimePolicy
+			consumer.OptStartTime = &config.StartTime
+		}
+		_, err = js.AddConsumer(schema.EventStreamName, consumer)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer %s: %w", config.Durable, err)
+	}
+
+	// Bind não remove o consumer ao encerrar a inscrição
+	sub, err := js.PullSubscribe(config.filter(), config.Durable, nats.Bind(schema.EventStreamName, config.Durable))
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe consumer %s: %w", config.Durable, err)
+	}
+	return &Consumer{sub: sub}, nil
+}
+
+// Replay recria o consumer para ler novamente a partir de StartSequence (0 = início do stream),
+// usado para reconstruir caches downstream
+func Replay(js nats.JetStreamContext, config ConsumerConfig) (*Consumer, error) {
+	if config.Durable == "" {
+		return nil, errors.New("durable name is required")
+	}
+
+	if err := Remove(js, config.Durable); err != nil {
+		return nil, err
+	}
+	return NewConsumer(js, config)
+}
+
+// Fetch aguarda até batch eventos; retorna vazio quando ctx expira sem novos eventos
+func (c *Consumer) Fetch(ctx context.Context, batch int) ([]*Event, error) {
+	if batch <= 0 {
+		batch = DefaultBatch
+	}
+
+	msgs, err := c.sub.Fetch(batch, nats.Context(ctx))
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, nats.ErrTimeout) {
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
+	}
+
+	events := make([]*Event, 0, len(msgs))
+	for _, msg := range msgs {
+		event, err := Decode(msg)
+		if err != nil {
+			// Evento ilegível não deve bloquear o consumer
+			msg.Term()
+			continue
+		}
+		attempt := 1
+		if meta, err := msg.Metadata(); err == nil {
+			attempt = int(meta.NumDelivered)
+		}
+		events = append(events, &Event{SchemaEvent: *event, Attempt: attempt, msg: msg})
+	}
+	return events, nil
+}
+
+// Close encerra a inscrição mantendo o consumer e sua posição no servidor
+func (c *Consumer) Close() error {
+	return c.sub.Unsubscribe()
+}
+
+// Decode lê um evento do stream, preenchendo a sequência
+func Decode(msg *nats.Msg) (*models.SchemaEvent, error) {
+	var event models.SchemaEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	meta, err := msg.Metadata()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event metadata: %w", err)
+	}
+	event.Sequence = meta.Sequence.Stream
+	return &event, nil
+}
+
+// Remove apaga o consumer durável e sua posição de leitura; consumer inexistente não é erro
+func Remove(js nats.JetStreamContext, durable string) error {
+	err := js.DeleteConsumer(schema.EventStreamName, durable)
+	if err != nil && !errors.Is(err, nats.ErrConsumerNotFound) {
+		return fmt.Errorf("failed to remove consumer %s: %w", durable, err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/natstest"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"github.com/nats-io/nats.go"
+)
+
+func newTestJetStream(t *testing.T) nats.JetStreamContext {
+	t.Helper()
+	return natstest.Start(t, natstest.Options{
+		Streams: []*nats.StreamConfig{schema.EventStreamConfig(schema.EventRetention{})},
+	}).JS
+}
+
+func publish(t *testing.T, js nats.JetStreamContext, events ...models.SchemaEvent) {
+	t.Helper()
+
+	for _, event := range events {
+		data, _ := json.Marshal(event)
+		if _, err := js.Publish(schema.EventSubjectPrefix+event.Subject, data, nats.MsgId(event.ID)); err != nil {
+			t.Fatalf("failed to publish %s: %v", event.ID, err)
+		}
+	}
+}
+
+// fetchIDs lê os eventos disponíveis, confirmando-os, e retorna seus IDs
+func fetchIDs(t *testing.T, consumer *Consumer) []string {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	events, err := consumer.Fetch(ctx, 10)
+	if err != nil {
+		t.Fatalf("fetch failed: %v", err)
+	}
+
+	ids := []string{}
+	for _, event := range events {
+		if event.Sequence == 0 {
+			t.Errorf("event %s without stream sequence", event.ID)
+		}
+		event.Ack()
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func TestConsumer(t *testing.T) {
+	js := newTestJetStream(t)
+	publish(t, js,
+		models.SchemaEvent{ID: "1", Type: models.EventSchemaCreated, Subject: "payments.order"},
+		models.SchemaEvent{ID: "2", Type: models.EventSchemaCreated, Subject: "users"},
+		models.SchemaEvent{ID: "3", Type: models.EventConfigChanged, Subject: "payments.order"},
+	)
+
+	tests := []struct {
+		name    string
+		config  ConsumerConfig
+		wantIDs []string
+	}{
+		{name: "should read all events from the beginning", config: ConsumerConfig{Durable: "all"}, wantIDs: []string{"1", "2", "3"}},
+		{name: "should filter by subject", config: ConsumerConfig{Durable: "payments", Subject: "payments.>"}, wantIDs: []string{"1", "3"}},
+		{name: "should start at sequence", config: ConsumerConfig{Durable: "tail", StartSequence: 2}, wantIDs: []string{"2", "3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer, err := NewConsumer(js, tt.config)
+			if err != nil {
+				t.Fatalf("failed to create consumer: %v", err)
+			}
+			defer consumer.Close()
+
+			if ids := fetchIDs(t, consumer); !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("events = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestConsumerResumeAndReplay(t *testing.T) {
+	js := newTestJetStream(t)
+	publish(t, js,
+		models.SchemaEvent{ID: "1", Subject: "users"},
+		models.SchemaEvent{ID: "2", Subject: "users"},
+	)
+
+	config := ConsumerConfig{Durable: "cache"}
+	consumer, err := NewConsumer(js, config)
+	if err != nil {
+		t.Fatalf("failed to create consumer: %v", err)
+	}
+	fetchIDs(t, consumer)
+	consumer.Close()
+
+	// Após reabrir, o consumer continua do último ack
+	publish(t, js, models.SchemaEvent{ID: "3", Subject: "users"})
+	consumer, err = NewConsumer(js, config)
+	if err != nil {
+		t.Fatalf("failed to resume consumer: %v", err)
+	}
+	if ids := fetchIDs(t, consumer); !reflect.DeepEqual(ids, []string{"3"}) {
+		t.Errorf("resumed events = %v, want [3]", ids)
+	}
+	consumer.Close()
+
+	// Replay volta ao início do stream
+	consumer, err = Replay(js, config)
+	if err != nil {
+		t.Fatalf("failed to replay: %v", err)
+	}
+	defer consumer.Close()
+	if ids := fetchIDs(t, consumer); !reflect.DeepEqual(ids, []string{"1", "2", "3"}) {
+		t.Errorf("replayed events = %v, want [1 2 3]", ids)
+	}
+}
+
+func TestNewConsumerRequiresDurable(t *testing.T) {
+	if _, err := NewConsumer(nil, ConsumerConfig{}); err == nil {
+		t.Fatal("expected error without durable name")
+	}
+}
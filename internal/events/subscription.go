@@ -0,0 +1,72 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Source abre leituras contínuas do stream de eventos, sem estado no servidor (ordered consumer)
+type Source struct {
+	js nats.JetStreamContext
+}
+
+func NewSource(js nats.JetStreamContext) *Source {
+	return &Source{js: js}
+}
+
+// Subscription leitura contínua de eventos
+type Subscription struct {
+	sub *nats.Subscription
+}
+
+// Subscribe lê eventos a partir da sequência start (0 = apenas eventos novos). Sequências já
+// removidas pela retenção são ignoradas e a leitura começa no primeiro evento disponível.
+func (s *Source) Subscribe(start uint64) (*Subscription, error) {
+	opts := []nats.SubOpt{nats.OrderedConsumer(), nats.BindStream(schema.EventStreamName)}
+	if start > 0 {
+		opts = append(opts, nats.StartSequence(start))
+	} else {
+		opts = append(opts, nats.DeliverNew())
+	}
+
+	sub, err := s.js.SubscribeSync(schema.EventSubjectPrefix+">", opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to event stream: %w", err)
+	}
+	return &Subscription{sub: sub}, nil
+}
+
+// Next aguarda o próximo evento; retorna o erro de ctx quando ele expira ou é cancelado
+func (s *Subscription) Next(ctx context.Context) (*models.SchemaEvent, error) {
+	for {
+		msg, err := s.sub.NextMsgWithContext(ctx)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, fmt.Errorf("failed to read event stream: %w", err)
+		}
+
+		event, err := Decode(msg)
+		if err != nil {
+			// Evento ilegível é ignorado para não interromper a leitura
+			continue
+		}
+		return event, nil
+	}
+}
+
+// Close encerra a leitura
+func (s *Subscription) Close() error {
+	err := s.sub.Unsubscribe()
+	if errors.Is(err, nats.ErrConnectionClosed) {
+		return nil
+	}
+	return err
+}
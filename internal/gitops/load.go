@@ -0,0 +1,128 @@
+package gitops
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"go.yaml.in/yaml/v2"
+)
+
+// schemaExtensions mapeia extensões de arquivo para tipos de schema
+var schemaExtensions = map[string]string{
+	".avsc":  models.SchemaTypeAVRO,
+	".avro":  models.SchemaTypeAVRO,
+	".json":  models.SchemaTypeJSON,
+	".proto": models.SchemaTypeProtobuf,
+}
+
+// DesiredSubject estado desejado de um subject, lido do repositório
+type DesiredSubject struct {
+	Subject       string
+	Path          string
+	SchemaType    string
+	Schema        string
+	Compatibility string
+	Metadata      map[string]string
+}
+
+// sidecar configuração opcional em <arquivo>.yaml ao lado do schema
+type sidecar struct {
+	Compatibility string            `yaml:"compatibility"`
+	Metadata      map[string]string `yaml:"metadata"`
+}
+
+// LoadDirectory lê a árvore de schemas: o subject vem do caminho relativo
+// (payments/order.avsc → payments.order) e o tipo da extensão do arquivo.
+func LoadDirectory(root string) ([]DesiredSubject, error) {
+	var desired []DesiredSubject
+	seen := make(map[string]string)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ext := filepath.Ext(path)
+		schemaType, ok := schemaExtensions[ext]
+		if !ok {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		subject := strings.ReplaceAll(filepath.ToSlash(strings.TrimSuffix(rel, ext)), "/", ".")
+
+		if previous, dup := seen[subject]; dup {
+			return fmt.Errorf("subject %s defined by both %s and %s", subject, previous, rel)
+		}
+		seen[subject] = rel
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		spec, err := readSidecar(strings.TrimSuffix(path, ext))
+		if err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+
+		desired = append(desired, DesiredSubject{
+			Subject:       subject,
+			Path:          rel,
+			SchemaType:    schemaType,
+			Schema:        strings.TrimSpace(string(content)),
+			Compatibility: spec.Compatibility,
+			Metadata:      spec.Metadata,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(desired, func(i, j int) bool { return desired[i].Subject < desired[j].Subject })
+	return desired, nil
+}
+
+// readSidecar lê <base>.yaml ou <base>.yml, se existir
+func readSidecar(base string) (*sidecar, error) {
+	spec := &sidecar{}
+
+	for _, ext := range []string{".yaml", ".yml"} {
+		content, err := os.ReadFile(base + ext)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := yaml.UnmarshalStrict(content, spec); err != nil {
+			return nil, fmt.Errorf("invalid sidecar config: %w", err)
+		}
+		if spec.Compatibility != "" {
+			config := models.SchemaConfig{Compatibility: spec.Compatibility}
+			if err := config.Validate(); err != nil {
+				return nil, err
+			}
+		}
+		return spec, nil
+	}
+
+	return spec, nil
+}
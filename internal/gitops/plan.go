@@ -0,0 +1,264 @@
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+const (
+	ActionRegister  = "REGISTER"
+	ActionSetConfig = "SET_CONFIG"
+)
+
+// Target registry ao vivo contra o qual o plano é calculado e aplicado
+type Target interface {
+	// GetLatestSchema retorna nil, nil quando o subject ainda não existe
+	GetLatestSchema(ctx context.Context, subject string) (*models.Schema, error)
+	GetConfig(ctx context.Context, subject string) (*models.SchemaConfig, error)
+	// CheckCompatibility verifica no nível informado (vazio = configurado no subject)
+	CheckCompatibility(ctx context.Context, subject, schemaType, schema, compatibility string) (*models.SchemaValidationResult, error)
+	RegisterSchema(ctx context.Context, schema *models.Schema) (*models.Schema, error)
+	SetConfig(ctx context.Context, config *models.SchemaConfig) error
+}
+
+// Change uma alteração necessária para o registry refletir o repositório
+type Change struct {
+	Action       string
+	Subject      string
+	Path         string
+	SchemaType   string
+	Current      string
+	Desired      string
+	Metadata     map[string]string
+	Incompatible bool
+	Errors       []string
+}
+
+// Plan conjunto de alterações calculado por BuildPlan
+type Plan struct {
+	Changes []Change
+}
+
+// BuildPlan compara o estado desejado com o registry e calcula as alterações
+func BuildPlan(ctx context.Context, target Target, desired []DesiredSubject) (*Plan, error) {
+	plan := &Plan{}
+
+	for _, d := range desired {
+		if d.Compatibility != "" {
+			config, err := target.GetConfig(ctx, d.Subject)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get config of %s: %w", d.Subject, err)
+			}
+			if config.Compatibility != d.Compatibility {
+				plan.Changes = append(plan.Changes, Change{
+					Action:  ActionSetConfig,
+					Subject: d.Subject,
+					Path:    d.Path,
+					Current: config.Compatibility,
+					Desired: d.Compatibility,
+				})
+			}
+		}
+
+		latest, err := target.GetLatestSchema(ctx, d.Subject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest schema of %s: %w", d.Subject, err)
+		}
+		if latest != nil && latest.SchemaType == d.SchemaType && sameSchema(d.SchemaType, latest.Schema, d.Schema) {
+			continue
+		}
+
+		change := Change{
+			Action:     ActionRegister,
+			Subject:    d.Subject,
+			Path:       d.Path,
+			SchemaType: d.SchemaType,
+			Desired:    d.Schema,
+			Metadata:   d.Metadata,
+		}
+
+		// Verifica no nível desejado, que Apply grava antes dos registros: o plano inteiro é
+		// válido ou rejeitado antes de qualquer alteração
+		if latest != nil {
+			change.Current = latest.Schema
+			result, err := target.CheckCompatibility(ctx, d.Subject, d.SchemaType, d.Schema, d.Compatibility)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check compatibility of %s: %w", d.Subject, err)
+			}
+			change.Incompatible = !result.Valid
+			change.Errors = result.Errors
+		}
+
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	return plan, nil
+}
+
+// Empty indica que o registry já reflete o repositório
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Incompatible lista os registros que quebrariam a compatibilidade
+func (p *Plan) Incompatible() []Change {
+	var result []Change
+	for _, change := range p.Changes {
+		if change.Incompatible {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// Print escreve o plano em formato de diff
+func (p *Plan) Print(w io.Writer) {
+	if p.Empty() {
+		fmt.Fprintln(w, "Nenhuma alteração: o registry está sincronizado.")
+		return
+	}
+
+	for _, change := range p.Changes {
+		switch change.Action {
+		case ActionSetConfig:
+			fmt.Fprintf(w, "~ config %s: %s -> %s (%s)\n", change.Subject, change.Current, change.Desired, change.Path)
+
+		case ActionRegister:
+			status := ""
+			if change.Incompatible {
+				status = " [INCOMPATÍVEL]"
+			}
+			if change.Current == "" {
+				fmt.Fprintf(w, "+ register %s (%s, novo subject)%s\n", change.Subject, change.SchemaType, status)
+			} else {
+				fmt.Fprintf(w, "~ register %s (%s, nova versão)%s\n", change.Subject, change.SchemaType, status)
+			}
+			for _, line := range lineDiff(prettySchema(change.Current), prettySchema(change.Desired)) {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+			for _, err := range change.Errors {
+				fmt.Fprintf(w, "    ! %s\n", err)
+			}
+		}
+	}
+}
+
+// Apply aplica o plano; nada é alterado se houver registros incompatíveis
+func Apply(ctx context.Context, target Target, plan *Plan) error {
+	if incompatible := plan.Incompatible(); len(incompatible) > 0 {
+		subjects := make([]string, 0, len(incompatible))
+		for _, change := range incompatible {
+			subjects = append(subjects, change.Subject)
+		}
+		return fmt.Errorf("incompatible changes for: %s", strings.Join(subjects, ", "))
+	}
+
+	// Configurações primeiro, para que os registros sejam validados com o nível desejado
+	for _, change := range plan.Changes {
+		if change.Action != ActionSetConfig {
+			continue
+		}
+		// Preserva os demais campos da configuração atual (ex.: max_versions)
+		config, err := target.GetConfig(ctx, change.Subject)
+		if err != nil {
+			return fmt.Errorf("failed to get config of %s: %w", change.Subject, err)
+		}
+		config.Subject = change.Subject
+		config.Compatibility = change.Desired
+		if err := target.SetConfig(ctx, config); err != nil {
+			return fmt.Errorf("failed to set config of %s: %w", change.Subject, err)
+		}
+	}
+
+	for _, change := range plan.Changes {
+		if change.Action != ActionRegister {
+			continue
+		}
+		schema := &models.Schema{
+			Subject:    change.Subject,
+			Schema:     change.Desired,
+			SchemaType: change.SchemaType,
+			Metadata:   change.Metadata,
+		}
+		if _, err := target.RegisterSchema(ctx, schema); err != nil {
+			return fmt.Errorf("failed to register %s: %w", change.Subject, err)
+		}
+	}
+
+	return nil
+}
+
+// sameSchema compara schemas ignorando formatação quando o conteúdo é JSON
+func sameSchema(schemaType, a, b string) bool {
+	if schemaType == models.SchemaTypeJSON || schemaType == models.SchemaTypeAVRO {
+		var left, right interface{}
+		if json.Unmarshal([]byte(a), &left) == nil && json.Unmarshal([]byte(b), &right) == nil {
+			return reflect.DeepEqual(left, right)
+		}
+	}
+	return strings.TrimSpace(a) == strings.TrimSpace(b)
+}
+
+func prettySchema(schema string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(schema), "", "  "); err == nil {
+		return buf.String()
+	}
+	return schema
+}
+
+// lineDiff gera um diff linha a linha (LCS) com prefixos "+", "-" e " "
+func lineDiff(a, b string) []string {
+	var left, right []string
+	if a != "" {
+		left = strings.Split(a, "\n")
+	}
+	if b != "" {
+		right = strings.Split(b, "\n")
+	}
+
+	lcs := make([][]int, len(left)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(right)+1)
+	}
+	for i := len(left) - 1; i >= 0; i-- {
+		for j := len(right) - 1; j >= 0; j-- {
+			if left[i] == right[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(left) && j < len(right) {
+		switch {
+		case left[i] == right[j]:
+			diff = append(diff, "  "+left[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, "- "+left[i])
+			i++
+		default:
+			diff = append(diff, "+ "+right[j])
+			j++
+		}
+	}
+	for ; i < len(left); i++ {
+		diff = append(diff, "- "+left[i])
+	}
+	for ; j < len(right); j++ {
+		diff = append(diff, "+ "+right[j])
+	}
+	return diff
+}
@@ -0,0 +1,189 @@
+package gitops
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+type fakeTarget struct {
+	latest     map[string]*models.Schema
+	configs    map[string]string
+	compatible bool
+	// rejectLevel nível em que a verificação falha mesmo com compatible
+	rejectLevel string
+	registered  []string
+}
+
+func (f *fakeTarget) GetLatestSchema(ctx context.Context, subject string) (*models.Schema, error) {
+	return f.latest[subject], nil
+}
+
+func (f *fakeTarget) GetConfig(ctx context.Context, subject string) (*models.SchemaConfig, error) {
+	compatibility, ok := f.configs[subject]
+	if !ok {
+		compatibility = models.CompatibilityBackward
+	}
+	return &models.SchemaConfig{Subject: subject, Compatibility: compatibility}, nil
+}
+
+func (f *fakeTarget) CheckCompatibility(ctx context.Context, subject, schemaType, schema, compatibility string) (*models.SchemaValidationResult, error) {
+	if compatibility == "" {
+		config, _ := f.GetConfig(ctx, subject)
+		compatibility = config.Compatibility
+	}
+	if f.compatible && compatibility != f.rejectLevel {
+		return &models.SchemaValidationResult{Valid: true}, nil
+	}
+	return &models.SchemaValidationResult{Valid: false, Errors: []string{"field removed"}}, nil
+}
+
+func (f *fakeTarget) RegisterSchema(ctx context.Context, schema *models.Schema) (*models.Schema, error) {
+	f.registered = append(f.registered, schema.Subject)
+	return schema, nil
+}
+
+func (f *fakeTarget) SetConfig(ctx context.Context, config *models.SchemaConfig) error {
+	f.configs[config.Subject] = config.Compatibility
+	return nil
+}
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "payments/order.avsc", `{"type":"record","name":"Order","fields":[]}`)
+	writeFile(t, root, "payments/order.yaml", "compatibility: FULL\nmetadata:\n  team: payments\n")
+	writeFile(t, root, "user.json", `{"type":"object"}`)
+	writeFile(t, root, "README.md", "ignored")
+
+	desired, err := LoadDirectory(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(desired) != 2 {
+		t.Fatalf("expected 2 subjects, got %d", len(desired))
+	}
+
+	order := desired[0]
+	if order.Subject != "payments.order" || order.SchemaType != models.SchemaTypeAVRO {
+		t.Errorf("unexpected subject %s (%s)", order.Subject, order.SchemaType)
+	}
+	if order.Compatibility != models.CompatibilityFull || order.Metadata["team"] != "payments" {
+		t.Errorf("sidecar config not loaded: %+v", order)
+	}
+
+	writeFile(t, root, "user.yaml", "compatibility: SOMETIMES\n")
+	if _, err := LoadDirectory(root); err == nil {
+		t.Error("expected invalid compatibility in sidecar to fail")
+	}
+}
+
+func TestBuildPlanAndApply(t *testing.T) {
+	desired := []DesiredSubject{
+		{Subject: "unchanged", SchemaType: models.SchemaTypeJSON, Schema: "{\n  \"type\": \"object\"\n}"},
+		{Subject: "evolved", SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object","required":["id"]}`},
+		{Subject: "created", SchemaType: models.SchemaTypeJSON, Schema: `{"type":"string"}`, Compatibility: models.CompatibilityNone},
+	}
+
+	tests := []struct {
+		name           string
+		compatible     bool
+		wantChanges    int
+		wantApplyError bool
+		wantRegistered int
+	}{
+		{
+			name:           "should register new and changed subjects",
+			compatible:     true,
+			wantChanges:    3,
+			wantRegistered: 2,
+		},
+		{
+			name:           "should refuse to apply incompatible changes",
+			compatible:     false,
+			wantChanges:    3,
+			wantApplyError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &fakeTarget{
+				latest: map[string]*models.Schema{
+					"unchanged": {Subject: "unchanged", SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object"}`},
+					"evolved":   {Subject: "evolved", SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object"}`},
+				},
+				configs:    map[string]string{},
+				compatible: tt.compatible,
+			}
+
+			plan, err := BuildPlan(context.Background(), target, desired)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(plan.Changes) != tt.wantChanges {
+				t.Fatalf("expected %d changes, got %+v", tt.wantChanges, plan.Changes)
+			}
+
+			var out strings.Builder
+			plan.Print(&out)
+			if !strings.Contains(out.String(), `+   "required": [`) {
+				t.Errorf("expected diff in plan output, got:\n%s", out.String())
+			}
+
+			err = Apply(context.Background(), target, plan)
+			if (err != nil) != tt.wantApplyError {
+				t.Fatalf("unexpected apply error: %v", err)
+			}
+			if len(target.registered) != tt.wantRegistered {
+				t.Errorf("expected %d registrations, got %v", tt.wantRegistered, target.registered)
+			}
+		})
+	}
+}
+
+func TestBuildPlanChecksDesiredCompatibility(t *testing.T) {
+	desired := []DesiredSubject{
+		{Subject: "created", SchemaType: models.SchemaTypeJSON, Schema: `{"type":"string"}`, Compatibility: models.CompatibilityFull},
+		{Subject: "evolved", SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object","required":["id"]}`, Compatibility: models.CompatibilityFull},
+	}
+	target := &fakeTarget{
+		latest: map[string]*models.Schema{
+			"evolved": {Subject: "evolved", SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object"}`},
+		},
+		configs:     map[string]string{},
+		compatible:  true,
+		rejectLevel: models.CompatibilityFull,
+	}
+
+	plan, err := BuildPlan(context.Background(), target, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if incompatible := plan.Incompatible(); len(incompatible) != 1 || incompatible[0].Subject != "evolved" {
+		t.Fatalf("expected evolved to be incompatible at the desired level, got %+v", plan.Changes)
+	}
+
+	// Nada é alterado: nem configurações nem registros de outros subjects
+	if err := Apply(context.Background(), target, plan); err == nil {
+		t.Fatal("expected apply to fail")
+	}
+	if len(target.configs) != 0 || len(target.registered) != 0 {
+		t.Errorf("expected no changes, got configs %v and registrations %v", target.configs, target.registered)
+	}
+}
@@ -0,0 +1,108 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain domínio informado em ErrorInfo
+const errorDomain = "schema-registry"
+
+// errorMapping associa um erro sentinela ao código gRPC e ao código numérico da API HTTP
+type errorMapping struct {
+	err     error
+	code    codes.Code
+	apiCode int
+}
+
+var registryErrors = []errorMapping{
+	{schema.ErrSubjectNotFound, codes.NotFound, models.ErrorCodeSubjectNotFound},
+	{schema.ErrVersionNotFound, codes.NotFound, models.ErrorCodeVersionNotFound},
+	{schema.ErrSchemaNotFound, codes.NotFound, models.ErrorCodeSchemaNotFound},
+	{schema.ErrIncompatible, codes.FailedPrecondition, models.ErrorCodeIncompatibleSchema},
+	{schema.ErrConflict, codes.AlreadyExists, models.ErrorCodeConflict},
+	{schema.ErrInvalidSchema, codes.InvalidArgument, models.ErrorCodeInvalidSchema},
+	{schema.ErrInvalidConfig, codes.InvalidArgument, models.ErrorCodeInvalidConfig},
+	{schema.ErrInvalidMode, codes.InvalidArgument, models.ErrorCodeInvalidMode},
+	{schema.ErrOperationNotPermitted, codes.FailedPrecondition, models.ErrorCodeOperationNotPermitted},
+	{schema.ErrVersionLimit, codes.ResourceExhausted, models.ErrorCodeVersionLimit},
+	{schema.ErrPolicyViolation, codes.FailedPrecondition, models.ErrorCodePolicyViolation},
+}
+
+// registryStatus converte um erro do registry em status gRPC.
+// Erros sem sentinela conhecido viram Internal.
+func registryStatus(err error) error {
+	var metadata map[string]string
+	// O sentinela do erro de domínio tem precedência sobre o da causa embrulhada
+	sentinel := err
+	var registryErr *schema.RegistryError
+	if errors.As(err, &registryErr) {
+		metadata = detailsMetadata(registryErr.Details)
+		sentinel = registryErr.Err
+	}
+
+	for _, target := range []error{sentinel, err} {
+		for _, mapping := range registryErrors {
+			if errors.Is(target, mapping.err) {
+				return newStatus(mapping.code, mapping.apiCode, err.Error(), metadata)
+			}
+		}
+	}
+
+	log.Printf("Internal error: %v", err)
+	return newStatus(codes.Internal, models.ErrorCodeInternal, err.Error(), metadata)
+}
+
+// statusError cria um status gRPC sem detalhes estruturados
+func statusError(code codes.Code, apiCode int, message string) error {
+	return newStatus(code, apiCode, message, nil)
+}
+
+// newStatus anexa ErrorInfo com o código da API (metadata error_code) e os detalhes do erro
+func newStatus(code codes.Code, apiCode int, message string, metadata map[string]string) error {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata["error_code"] = strconv.Itoa(apiCode)
+
+	st := status.New(code, message)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   code.String(),
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// detailsMetadata achata os detalhes do erro; valores não textuais são serializados em JSON
+func detailsMetadata(details map[string]interface{}) map[string]string {
+	if len(details) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(details))
+	for key, value := range details {
+		if text, ok := value.(string); ok {
+			metadata[key] = text
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		metadata[key] = string(encoded)
+	}
+	return metadata
+}
@@ -0,0 +1,122 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rodrigues-daniel/data-platform/internal/audit"
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// RequestsTotal chamadas gRPC por método e código de status
+	RequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_requests_total",
+			Help: "Número total de chamadas gRPC recebidas.",
+		},
+		[]string{"method", "code"},
+	)
+
+	// RequestDuration duração das chamadas gRPC por método
+	RequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_request_duration_seconds",
+			Help:    "Duração das chamadas gRPC.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method"},
+	)
+)
+
+// MetricsInterceptor registra contagem e duração de cada chamada
+func MetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		RequestsTotal.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		RequestDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		log.Printf("gRPC %s %s %v", info.FullMethod, status.Code(err), time.Since(start))
+		return resp, err
+	}
+}
+
+// RecoveryInterceptor converte panics em Internal
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				log.Printf("Panic recovered: %v", recovered)
+				err = statusError(codes.Internal, models.ErrorCodeInternal, "Internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// ActorInterceptor identifica o autor das alterações (auditoria) pelo endereço do cliente; com a
+// autenticação habilitada, o principal o substitui
+func ActorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		actor := ""
+		if p, ok := peer.FromContext(ctx); ok {
+			actor = p.Addr.String()
+		}
+		return handler(audit.WithActor(ctx, actor), req)
+	}
+}
+
+// AuthInterceptor autentica cada chamada com os mesmos autenticadores da API HTTP.
+// As credenciais vêm do metadata (authorization, x-api-key) e do certificado de cliente.
+func AuthInterceptor(authenticators []auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		principal, err := auth.Authenticate(credentialsRequest(ctx), authenticators)
+		switch {
+		case errors.Is(err, auth.ErrInvalidCredentials):
+			return nil, statusError(codes.Unauthenticated, models.ErrorCodeUnauthorized, "Invalid credentials")
+		case err != nil:
+			log.Printf("Authentication error: %v", err)
+			return nil, statusError(codes.Internal, models.ErrorCodeInternal, "Authentication unavailable")
+		case principal == nil:
+			return nil, statusError(codes.Unauthenticated, models.ErrorCodeUnauthorized, "Authentication required")
+		}
+
+		ctx = auth.WithPrincipal(ctx, principal)
+		ctx = audit.WithActor(ctx, principal.Name)
+		return handler(ctx, req)
+	}
+}
+
+// credentialsRequest adapta o metadata e o peer da chamada para a interface dos autenticadores HTTP
+func credentialsRequest(ctx context.Context) *http.Request {
+	r := (&http.Request{Method: http.MethodPost, Header: http.Header{}}).WithContext(ctx)
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		for _, value := range values {
+			r.Header.Add(key, value)
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		r.RemoteAddr = p.Addr.String()
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state := info.State
+			r.TLS = &state
+		}
+	}
+	return r
+}
@@ -0,0 +1,69 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// headerAuthenticator aceita a chave "secret" no header X-API-Key
+type headerAuthenticator struct{}
+
+func (headerAuthenticator) Authenticate(r *http.Request) (*auth.Principal, error) {
+	switch r.Header.Get("X-API-Key") {
+	case "":
+		return nil, nil
+	case "secret":
+		return &auth.Principal{Name: "ci", Method: auth.MethodAPIKey}, nil
+	case "outage":
+		return nil, errors.New("failed to lookup api key: kv unavailable")
+	default:
+		return nil, auth.ErrInvalidCredentials
+	}
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	interceptor := AuthInterceptor([]auth.Authenticator{headerAuthenticator{}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/schemaregistry.v1.SchemaRegistry/GetSchema"}
+
+	tests := []struct {
+		name          string
+		metadata      metadata.MD
+		wantCode      codes.Code
+		wantPrincipal string
+	}{
+		{name: "should authenticate with api key metadata", metadata: metadata.Pairs("x-api-key", "secret"), wantCode: codes.OK, wantPrincipal: "ci"},
+		{name: "should reject invalid key", metadata: metadata.Pairs("x-api-key", "wrong"), wantCode: codes.Unauthenticated},
+		{name: "should require credentials", metadata: metadata.MD{}, wantCode: codes.Unauthenticated},
+		{name: "should report authenticator failures as internal", metadata: metadata.Pairs("x-api-key", "outage"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.metadata)
+
+			var principal string
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				if p, ok := auth.PrincipalFromContext(ctx); ok {
+					principal = p.Name
+				}
+				return nil, nil
+			})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if principal != tt.wantPrincipal {
+				t.Errorf("principal = %q, want %q", principal, tt.wantPrincipal)
+			}
+		})
+	}
+}
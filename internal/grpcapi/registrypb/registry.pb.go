@@ -0,0 +1,1547 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v5.28.3
+// source: schemaregistry/v1/registry.proto
+
+// API gRPC do Schema Registry, equivalente às rotas /v1 da API HTTP.
+// Código Go gerado em internal/grpcapi/registrypb (ver "make proto").
+
+package registrypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Filtro de versões removidas (soft delete)
+type DeletedFilter int32
+
+const (
+	DeletedFilter_DELETED_FILTER_UNSPECIFIED DeletedFilter = 0 // equivale a EXCLUDE
+	DeletedFilter_DELETED_FILTER_EXCLUDE     DeletedFilter = 1
+	DeletedFilter_DELETED_FILTER_INCLUDE     DeletedFilter = 2
+	DeletedFilter_DELETED_FILTER_ONLY        DeletedFilter = 3
+)
+
+// Enum value maps for DeletedFilter.
+var (
+	DeletedFilter_name = map[int32]string{
+		0: "DELETED_FILTER_UNSPECIFIED",
+		1: "DELETED_FILTER_EXCLUDE",
+		2: "DELETED_FILTER_INCLUDE",
+		3: "DELETED_FILTER_ONLY",
+	}
+	DeletedFilter_value = map[string]int32{
+		"DELETED_FILTER_UNSPECIFIED": 0,
+		"DELETED_FILTER_EXCLUDE":     1,
+		"DELETED_FILTER_INCLUDE":     2,
+		"DELETED_FILTER_ONLY":        3,
+	}
+)
+
+func (x DeletedFilter) Enum() *DeletedFilter {
+	p := new(DeletedFilter)
+	*p = x
+	return p
+}
+
+func (x DeletedFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeletedFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemaregistry_v1_registry_proto_enumTypes[0].Descriptor()
+}
+
+func (DeletedFilter) Type() protoreflect.EnumType {
+	return &file_schemaregistry_v1_registry_proto_enumTypes[0]
+}
+
+func (x DeletedFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeletedFilter.Descriptor instead.
+func (DeletedFilter) EnumDescriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{0}
+}
+
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0 // equivale a ASC
+	SortOrder_SORT_ORDER_ASC         SortOrder = 1
+	SortOrder_SORT_ORDER_DESC        SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_ASC",
+		2: "SORT_ORDER_DESC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_ASC":         1,
+		"SORT_ORDER_DESC":        2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemaregistry_v1_registry_proto_enumTypes[1].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_schemaregistry_v1_registry_proto_enumTypes[1]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{1}
+}
+
+type Reference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reference) Reset() {
+	*x = Reference{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reference) ProtoMessage() {}
+
+func (x *Reference) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reference.ProtoReflect.Descriptor instead.
+func (*Reference) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Reference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Reference) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Reference) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Schema struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Subject string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Version int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Definição do schema como texto (JSON para AVRO/JSON, .proto para PROTOBUF)
+	Schema        string                 `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	SchemaType    string                 `protobuf:"bytes,5,opt,name=schema_type,json=schemaType,proto3" json:"schema_type,omitempty"`
+	Fingerprint   string                 `protobuf:"bytes,6,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	References    []*Reference           `protobuf:"bytes,7,rep,name=references,proto3" json:"references,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Deleted       bool                   `protobuf:"varint,9,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schema) Reset() {
+	*x = Schema{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schema) ProtoMessage() {}
+
+func (x *Schema) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schema.ProtoReflect.Descriptor instead.
+func (*Schema) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Schema) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Schema) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Schema) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Schema) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *Schema) GetSchemaType() string {
+	if x != nil {
+		return x.SchemaType
+	}
+	return ""
+}
+
+func (x *Schema) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *Schema) GetReferences() []*Reference {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+func (x *Schema) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Schema) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Schema) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type RegisterSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	SchemaType    string                 `protobuf:"bytes,2,opt,name=schema_type,json=schemaType,proto3" json:"schema_type,omitempty"`
+	Schema        string                 `protobuf:"bytes,3,opt,name=schema,proto3" json:"schema,omitempty"`
+	References    []*Reference           `protobuf:"bytes,4,rep,name=references,proto3" json:"references,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterSchemaRequest) Reset() {
+	*x = RegisterSchemaRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterSchemaRequest) ProtoMessage() {}
+
+func (x *RegisterSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterSchemaRequest.ProtoReflect.Descriptor instead.
+func (*RegisterSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterSchemaRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *RegisterSchemaRequest) GetSchemaType() string {
+	if x != nil {
+		return x.SchemaType
+	}
+	return ""
+}
+
+func (x *RegisterSchemaRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *RegisterSchemaRequest) GetReferences() []*Reference {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+func (x *RegisterSchemaRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetSchemaRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Subject string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// 0 = latest
+	Version int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Inclui versões removidas via soft delete (ignorado para latest)
+	IncludeDeleted bool `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSchemaRequest) Reset() {
+	*x = GetSchemaRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaRequest) ProtoMessage() {}
+
+func (x *GetSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaRequest.ProtoReflect.Descriptor instead.
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSchemaRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *GetSchemaRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetSchemaRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type GetSchemaByIDRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSchemaByIDRequest) Reset() {
+	*x = GetSchemaByIDRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaByIDRequest) ProtoMessage() {}
+
+func (x *GetSchemaByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetSchemaByIDRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSchemaByIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetSchemaByIDRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListSubjectsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Prefix     string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Contains   string                 `protobuf:"bytes,2,opt,name=contains,proto3" json:"contains,omitempty"`
+	SchemaType string                 `protobuf:"bytes,3,opt,name=schema_type,json=schemaType,proto3" json:"schema_type,omitempty"`
+	// Metadata da última versão (todas precisam coincidir)
+	Labels  map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Deleted DeletedFilter     `protobuf:"varint,5,opt,name=deleted,proto3,enum=schemaregistry.v1.DeletedFilter" json:"deleted,omitempty"`
+	Order   SortOrder         `protobuf:"varint,6,opt,name=order,proto3,enum=schemaregistry.v1.SortOrder" json:"order,omitempty"`
+	// next_page_token da página anterior
+	PageToken string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// 0 = limite padrão (100); máximo 1000
+	PageSize      int32 `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubjectsRequest) Reset() {
+	*x = ListSubjectsRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubjectsRequest) ProtoMessage() {}
+
+func (x *ListSubjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubjectsRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListSubjectsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListSubjectsRequest) GetContains() string {
+	if x != nil {
+		return x.Contains
+	}
+	return ""
+}
+
+func (x *ListSubjectsRequest) GetSchemaType() string {
+	if x != nil {
+		return x.SchemaType
+	}
+	return ""
+}
+
+func (x *ListSubjectsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListSubjectsRequest) GetDeleted() DeletedFilter {
+	if x != nil {
+		return x.Deleted
+	}
+	return DeletedFilter_DELETED_FILTER_UNSPECIFIED
+}
+
+func (x *ListSubjectsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListSubjectsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListSubjectsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type SubjectInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	LatestVersion int32                  `protobuf:"varint,2,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+	SchemaType    string                 `protobuf:"bytes,3,opt,name=schema_type,json=schemaType,proto3" json:"schema_type,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Deleted       bool                   `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubjectInfo) Reset() {
+	*x = SubjectInfo{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubjectInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubjectInfo) ProtoMessage() {}
+
+func (x *SubjectInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubjectInfo.ProtoReflect.Descriptor instead.
+func (*SubjectInfo) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SubjectInfo) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SubjectInfo) GetLatestVersion() int32 {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return 0
+}
+
+func (x *SubjectInfo) GetSchemaType() string {
+	if x != nil {
+		return x.SchemaType
+	}
+	return ""
+}
+
+func (x *SubjectInfo) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SubjectInfo) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type ListSubjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subjects      []*SubjectInfo         `protobuf:"bytes,1,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubjectsResponse) Reset() {
+	*x = ListSubjectsResponse{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubjectsResponse) ProtoMessage() {}
+
+func (x *ListSubjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubjectsResponse) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSubjectsResponse) GetSubjects() []*SubjectInfo {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *ListSubjectsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListVersionsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Subject string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Deleted DeletedFilter          `protobuf:"varint,2,opt,name=deleted,proto3,enum=schemaregistry.v1.DeletedFilter" json:"deleted,omitempty"`
+	Order   SortOrder              `protobuf:"varint,3,opt,name=order,proto3,enum=schemaregistry.v1.SortOrder" json:"order,omitempty"`
+	// next_page_token da página anterior
+	PageToken     int32 `protobuf:"varint,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageSize      int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVersionsRequest) Reset() {
+	*x = ListVersionsRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVersionsRequest) ProtoMessage() {}
+
+func (x *ListVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListVersionsRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ListVersionsRequest) GetDeleted() DeletedFilter {
+	if x != nil {
+		return x.Deleted
+	}
+	return DeletedFilter_DELETED_FILTER_UNSPECIFIED
+}
+
+func (x *ListVersionsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListVersionsRequest) GetPageToken() int32 {
+	if x != nil {
+		return x.PageToken
+	}
+	return 0
+}
+
+func (x *ListVersionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Versions      []int32                `protobuf:"varint,2,rep,packed,name=versions,proto3" json:"versions,omitempty"`
+	NextPageToken int32                  `protobuf:"varint,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVersionsResponse) Reset() {
+	*x = ListVersionsResponse{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVersionsResponse) ProtoMessage() {}
+
+func (x *ListVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListVersionsResponse) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ListVersionsResponse) GetVersions() []int32 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *ListVersionsResponse) GetNextPageToken() int32 {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return 0
+}
+
+type Config struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Subject string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// BACKWARD, FORWARD, FULL ou NONE
+	Compatibility string `protobuf:"bytes,2,opt,name=compatibility,proto3" json:"compatibility,omitempty"`
+	MaxVersions   int32  `protobuf:"varint,3,opt,name=max_versions,json=maxVersions,proto3" json:"max_versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Config) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Config) GetCompatibility() string {
+	if x != nil {
+		return x.Compatibility
+	}
+	return ""
+}
+
+func (x *Config) GetMaxVersions() int32 {
+	if x != nil {
+		return x.MaxVersions
+	}
+	return 0
+}
+
+type GetConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetConfigRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type UpdateConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Compatibility string                 `protobuf:"bytes,2,opt,name=compatibility,proto3" json:"compatibility,omitempty"`
+	MaxVersions   int32                  `protobuf:"varint,3,opt,name=max_versions,json=maxVersions,proto3" json:"max_versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateConfigRequest) Reset() {
+	*x = UpdateConfigRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConfigRequest) ProtoMessage() {}
+
+func (x *UpdateConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConfigRequest.ProtoReflect.Descriptor instead.
+func (*UpdateConfigRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateConfigRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *UpdateConfigRequest) GetCompatibility() string {
+	if x != nil {
+		return x.Compatibility
+	}
+	return ""
+}
+
+func (x *UpdateConfigRequest) GetMaxVersions() int32 {
+	if x != nil {
+		return x.MaxVersions
+	}
+	return 0
+}
+
+type DeleteConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConfigRequest) Reset() {
+	*x = DeleteConfigRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConfigRequest) ProtoMessage() {}
+
+func (x *DeleteConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConfigRequest.ProtoReflect.Descriptor instead.
+func (*DeleteConfigRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteConfigRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type CheckCompatibilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Schema        string                 `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckCompatibilityRequest) Reset() {
+	*x = CheckCompatibilityRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCompatibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCompatibilityRequest) ProtoMessage() {}
+
+func (x *CheckCompatibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCompatibilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckCompatibilityRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CheckCompatibilityRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *CheckCompatibilityRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+type CompatibilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsCompatible  bool                   `protobuf:"varint,1,opt,name=is_compatible,json=isCompatible,proto3" json:"is_compatible,omitempty"`
+	Errors        []string               `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	Warnings      []string               `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompatibilityResponse) Reset() {
+	*x = CompatibilityResponse{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompatibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompatibilityResponse) ProtoMessage() {}
+
+func (x *CompatibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompatibilityResponse.ProtoReflect.Descriptor instead.
+func (*CompatibilityResponse) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CompatibilityResponse) GetIsCompatible() bool {
+	if x != nil {
+		return x.IsCompatible
+	}
+	return false
+}
+
+func (x *CompatibilityResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *CompatibilityResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type ValidateDataRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Subject string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Data    *structpb.Value        `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Opcional: valida contra este schema em vez da última versão do subject
+	Schema        string `protobuf:"bytes,3,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateDataRequest) Reset() {
+	*x = ValidateDataRequest{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateDataRequest) ProtoMessage() {}
+
+func (x *ValidateDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateDataRequest.ProtoReflect.Descriptor instead.
+func (*ValidateDataRequest) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ValidateDataRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ValidateDataRequest) GetData() *structpb.Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ValidateDataRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+type ValidationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsValid       bool                   `protobuf:"varint,1,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	Errors        []string               `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	Warnings      []string               `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationResponse) Reset() {
+	*x = ValidationResponse{}
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResponse) ProtoMessage() {}
+
+func (x *ValidationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemaregistry_v1_registry_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResponse.ProtoReflect.Descriptor instead.
+func (*ValidationResponse) Descriptor() ([]byte, []int) {
+	return file_schemaregistry_v1_registry_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ValidationResponse) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *ValidationResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ValidationResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+var File_schemaregistry_v1_registry_proto protoreflect.FileDescriptor
+
+const file_schemaregistry_v1_registry_proto_rawDesc = "" +
+	"\n" +
+	" schemaregistry/v1/registry.proto\x12\x11schemaregistry.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"S\n" +
+	"\tReference\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\"\xbc\x03\n" +
+	"\x06Schema\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12\x16\n" +
+	"\x06schema\x18\x04 \x01(\tR\x06schema\x12\x1f\n" +
+	"\vschema_type\x18\x05 \x01(\tR\n" +
+	"schemaType\x12 \n" +
+	"\vfingerprint\x18\x06 \x01(\tR\vfingerprint\x12<\n" +
+	"\n" +
+	"references\x18\a \x03(\v2\x1c.schemaregistry.v1.ReferenceR\n" +
+	"references\x12C\n" +
+	"\bmetadata\x18\b \x03(\v2'.schemaregistry.v1.Schema.MetadataEntryR\bmetadata\x12\x18\n" +
+	"\adeleted\x18\t \x01(\bR\adeleted\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb9\x02\n" +
+	"\x15RegisterSchemaRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1f\n" +
+	"\vschema_type\x18\x02 \x01(\tR\n" +
+	"schemaType\x12\x16\n" +
+	"\x06schema\x18\x03 \x01(\tR\x06schema\x12<\n" +
+	"\n" +
+	"references\x18\x04 \x03(\v2\x1c.schemaregistry.v1.ReferenceR\n" +
+	"references\x12R\n" +
+	"\bmetadata\x18\x05 \x03(\v26.schemaregistry.v1.RegisterSchemaRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"o\n" +
+	"\x10GetSchemaRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12'\n" +
+	"\x0finclude_deleted\x18\x03 \x01(\bR\x0eincludeDeleted\"O\n" +
+	"\x14GetSchemaByIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\"\x9d\x03\n" +
+	"\x13ListSubjectsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x1a\n" +
+	"\bcontains\x18\x02 \x01(\tR\bcontains\x12\x1f\n" +
+	"\vschema_type\x18\x03 \x01(\tR\n" +
+	"schemaType\x12J\n" +
+	"\x06labels\x18\x04 \x03(\v22.schemaregistry.v1.ListSubjectsRequest.LabelsEntryR\x06labels\x12:\n" +
+	"\adeleted\x18\x05 \x01(\x0e2 .schemaregistry.v1.DeletedFilterR\adeleted\x122\n" +
+	"\x05order\x18\x06 \x01(\x0e2\x1c.schemaregistry.v1.SortOrderR\x05order\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x02\n" +
+	"\vSubjectInfo\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12%\n" +
+	"\x0elatest_version\x18\x02 \x01(\x05R\rlatestVersion\x12\x1f\n" +
+	"\vschema_type\x18\x03 \x01(\tR\n" +
+	"schemaType\x12H\n" +
+	"\bmetadata\x18\x04 \x03(\v2,.schemaregistry.v1.SubjectInfo.MetadataEntryR\bmetadata\x12\x18\n" +
+	"\adeleted\x18\x05 \x01(\bR\adeleted\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"z\n" +
+	"\x14ListSubjectsResponse\x12:\n" +
+	"\bsubjects\x18\x01 \x03(\v2\x1e.schemaregistry.v1.SubjectInfoR\bsubjects\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xdb\x01\n" +
+	"\x13ListVersionsRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12:\n" +
+	"\adeleted\x18\x02 \x01(\x0e2 .schemaregistry.v1.DeletedFilterR\adeleted\x122\n" +
+	"\x05order\x18\x03 \x01(\x0e2\x1c.schemaregistry.v1.SortOrderR\x05order\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\x05R\tpageToken\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\"t\n" +
+	"\x14ListVersionsResponse\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1a\n" +
+	"\bversions\x18\x02 \x03(\x05R\bversions\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\x05R\rnextPageToken\"k\n" +
+	"\x06Config\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12$\n" +
+	"\rcompatibility\x18\x02 \x01(\tR\rcompatibility\x12!\n" +
+	"\fmax_versions\x18\x03 \x01(\x05R\vmaxVersions\",\n" +
+	"\x10GetConfigRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\"x\n" +
+	"\x13UpdateConfigRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12$\n" +
+	"\rcompatibility\x18\x02 \x01(\tR\rcompatibility\x12!\n" +
+	"\fmax_versions\x18\x03 \x01(\x05R\vmaxVersions\"/\n" +
+	"\x13DeleteConfigRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\"M\n" +
+	"\x19CheckCompatibilityRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x16\n" +
+	"\x06schema\x18\x02 \x01(\tR\x06schema\"p\n" +
+	"\x15CompatibilityResponse\x12#\n" +
+	"\ris_compatible\x18\x01 \x01(\bR\fisCompatible\x12\x16\n" +
+	"\x06errors\x18\x02 \x03(\tR\x06errors\x12\x1a\n" +
+	"\bwarnings\x18\x03 \x03(\tR\bwarnings\"s\n" +
+	"\x13ValidateDataRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12*\n" +
+	"\x04data\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x04data\x12\x16\n" +
+	"\x06schema\x18\x03 \x01(\tR\x06schema\"c\n" +
+	"\x12ValidationResponse\x12\x19\n" +
+	"\bis_valid\x18\x01 \x01(\bR\aisValid\x12\x16\n" +
+	"\x06errors\x18\x02 \x03(\tR\x06errors\x12\x1a\n" +
+	"\bwarnings\x18\x03 \x03(\tR\bwarnings*\x80\x01\n" +
+	"\rDeletedFilter\x12\x1e\n" +
+	"\x1aDELETED_FILTER_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16DELETED_FILTER_EXCLUDE\x10\x01\x12\x1a\n" +
+	"\x16DELETED_FILTER_INCLUDE\x10\x02\x12\x17\n" +
+	"\x13DELETED_FILTER_ONLY\x10\x03*P\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSORT_ORDER_ASC\x10\x01\x12\x13\n" +
+	"\x0fSORT_ORDER_DESC\x10\x022\x8b\a\n" +
+	"\x0eSchemaRegistry\x12U\n" +
+	"\x0eRegisterSchema\x12(.schemaregistry.v1.RegisterSchemaRequest\x1a\x19.schemaregistry.v1.Schema\x12K\n" +
+	"\tGetSchema\x12#.schemaregistry.v1.GetSchemaRequest\x1a\x19.schemaregistry.v1.Schema\x12S\n" +
+	"\rGetSchemaByID\x12'.schemaregistry.v1.GetSchemaByIDRequest\x1a\x19.schemaregistry.v1.Schema\x12_\n" +
+	"\fListSubjects\x12&.schemaregistry.v1.ListSubjectsRequest\x1a'.schemaregistry.v1.ListSubjectsResponse\x12_\n" +
+	"\fListVersions\x12&.schemaregistry.v1.ListVersionsRequest\x1a'.schemaregistry.v1.ListVersionsResponse\x12K\n" +
+	"\tGetConfig\x12#.schemaregistry.v1.GetConfigRequest\x1a\x19.schemaregistry.v1.Config\x12Q\n" +
+	"\fUpdateConfig\x12&.schemaregistry.v1.UpdateConfigRequest\x1a\x19.schemaregistry.v1.Config\x12Q\n" +
+	"\fDeleteConfig\x12&.schemaregistry.v1.DeleteConfigRequest\x1a\x19.schemaregistry.v1.Config\x12l\n" +
+	"\x12CheckCompatibility\x12,.schemaregistry.v1.CheckCompatibilityRequest\x1a(.schemaregistry.v1.CompatibilityResponse\x12]\n" +
+	"\fValidateData\x12&.schemaregistry.v1.ValidateDataRequest\x1a%.schemaregistry.v1.ValidationResponseBGZEgithub.com/rodrigues-daniel/data-platform/internal/grpcapi/registrypbb\x06proto3"
+
+var (
+	file_schemaregistry_v1_registry_proto_rawDescOnce sync.Once
+	file_schemaregistry_v1_registry_proto_rawDescData []byte
+)
+
+func file_schemaregistry_v1_registry_proto_rawDescGZIP() []byte {
+	file_schemaregistry_v1_registry_proto_rawDescOnce.Do(func() {
+		file_schemaregistry_v1_registry_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemaregistry_v1_registry_proto_rawDesc), len(file_schemaregistry_v1_registry_proto_rawDesc)))
+	})
+	return file_schemaregistry_v1_registry_proto_rawDescData
+}
+
+var file_schemaregistry_v1_registry_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_schemaregistry_v1_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_schemaregistry_v1_registry_proto_goTypes = []any{
+	(DeletedFilter)(0),                // 0: schemaregistry.v1.DeletedFilter
+	(SortOrder)(0),                    // 1: schemaregistry.v1.SortOrder
+	(*Reference)(nil),                 // 2: schemaregistry.v1.Reference
+	(*Schema)(nil),                    // 3: schemaregistry.v1.Schema
+	(*RegisterSchemaRequest)(nil),     // 4: schemaregistry.v1.RegisterSchemaRequest
+	(*GetSchemaRequest)(nil),          // 5: schemaregistry.v1.GetSchemaRequest
+	(*GetSchemaByIDRequest)(nil),      // 6: schemaregistry.v1.GetSchemaByIDRequest
+	(*ListSubjectsRequest)(nil),       // 7: schemaregistry.v1.ListSubjectsRequest
+	(*SubjectInfo)(nil),               // 8: schemaregistry.v1.SubjectInfo
+	(*ListSubjectsResponse)(nil),      // 9: schemaregistry.v1.ListSubjectsResponse
+	(*ListVersionsRequest)(nil),       // 10: schemaregistry.v1.ListVersionsRequest
+	(*ListVersionsResponse)(nil),      // 11: schemaregistry.v1.ListVersionsResponse
+	(*Config)(nil),                    // 12: schemaregistry.v1.Config
+	(*GetConfigRequest)(nil),          // 13: schemaregistry.v1.GetConfigRequest
+	(*UpdateConfigRequest)(nil),       // 14: schemaregistry.v1.UpdateConfigRequest
+	(*DeleteConfigRequest)(nil),       // 15: schemaregistry.v1.DeleteConfigRequest
+	(*CheckCompatibilityRequest)(nil), // 16: schemaregistry.v1.CheckCompatibilityRequest
+	(*CompatibilityResponse)(nil),     // 17: schemaregistry.v1.CompatibilityResponse
+	(*ValidateDataRequest)(nil),       // 18: schemaregistry.v1.ValidateDataRequest
+	(*ValidationResponse)(nil),        // 19: schemaregistry.v1.ValidationResponse
+	nil,                               // 20: schemaregistry.v1.Schema.MetadataEntry
+	nil,                               // 21: schemaregistry.v1.RegisterSchemaRequest.MetadataEntry
+	nil,                               // 22: schemaregistry.v1.ListSubjectsRequest.LabelsEntry
+	nil,                               // 23: schemaregistry.v1.SubjectInfo.MetadataEntry
+	(*timestamppb.Timestamp)(nil),     // 24: google.protobuf.Timestamp
+	(*structpb.Value)(nil),            // 25: google.protobuf.Value
+}
+var file_schemaregistry_v1_registry_proto_depIdxs = []int32{
+	2,  // 0: schemaregistry.v1.Schema.references:type_name -> schemaregistry.v1.Reference
+	20, // 1: schemaregistry.v1.Schema.metadata:type_name -> schemaregistry.v1.Schema.MetadataEntry
+	24, // 2: schemaregistry.v1.Schema.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: schemaregistry.v1.RegisterSchemaRequest.references:type_name -> schemaregistry.v1.Reference
+	21, // 4: schemaregistry.v1.RegisterSchemaRequest.metadata:type_name -> schemaregistry.v1.RegisterSchemaRequest.MetadataEntry
+	22, // 5: schemaregistry.v1.ListSubjectsRequest.labels:type_name -> schemaregistry.v1.ListSubjectsRequest.LabelsEntry
+	0,  // 6: schemaregistry.v1.ListSubjectsRequest.deleted:type_name -> schemaregistry.v1.DeletedFilter
+	1,  // 7: schemaregistry.v1.ListSubjectsRequest.order:type_name -> schemaregistry.v1.SortOrder
+	23, // 8: schemaregistry.v1.SubjectInfo.metadata:type_name -> schemaregistry.v1.SubjectInfo.MetadataEntry
+	8,  // 9: schemaregistry.v1.ListSubjectsResponse.subjects:type_name -> schemaregistry.v1.SubjectInfo
+	0,  // 10: schemaregistry.v1.ListVersionsRequest.deleted:type_name -> schemaregistry.v1.DeletedFilter
+	1,  // 11: schemaregistry.v1.ListVersionsRequest.order:type_name -> schemaregistry.v1.SortOrder
+	25, // 12: schemaregistry.v1.ValidateDataRequest.data:type_name -> google.protobuf.Value
+	4,  // 13: schemaregistry.v1.SchemaRegistry.RegisterSchema:input_type -> schemaregistry.v1.RegisterSchemaRequest
+	5,  // 14: schemaregistry.v1.SchemaRegistry.GetSchema:input_type -> schemaregistry.v1.GetSchemaRequest
+	6,  // 15: schemaregistry.v1.SchemaRegistry.GetSchemaByID:input_type -> schemaregistry.v1.GetSchemaByIDRequest
+	7,  // 16: schemaregistry.v1.SchemaRegistry.ListSubjects:input_type -> schemaregistry.v1.ListSubjectsRequest
+	10, // 17: schemaregistry.v1.SchemaRegistry.ListVersions:input_type -> schemaregistry.v1.ListVersionsRequest
+	13, // 18: schemaregistry.v1.SchemaRegistry.GetConfig:input_type -> schemaregistry.v1.GetConfigRequest
+	14, // 19: schemaregistry.v1.SchemaRegistry.UpdateConfig:input_type -> schemaregistry.v1.UpdateConfigRequest
+	15, // 20: schemaregistry.v1.SchemaRegistry.DeleteConfig:input_type -> schemaregistry.v1.DeleteConfigRequest
+	16, // 21: schemaregistry.v1.SchemaRegistry.CheckCompatibility:input_type -> schemaregistry.v1.CheckCompatibilityRequest
+	18, // 22: schemaregistry.v1.SchemaRegistry.ValidateData:input_type -> schemaregistry.v1.ValidateDataRequest
+	3,  // 23: schemaregistry.v1.SchemaRegistry.RegisterSchema:output_type -> schemaregistry.v1.Schema
+	3,  // 24: schemaregistry.v1.SchemaRegistry.GetSchema:output_type -> schemaregistry.v1.Schema
+	3,  // 25: schemaregistry.v1.SchemaRegistry.GetSchemaByID:output_type -> schemaregistry.v1.Schema
+	9,  // 26: schemaregistry.v1.SchemaRegistry.ListSubjects:output_type -> schemaregistry.v1.ListSubjectsResponse
+	11, // 27: schemaregistry.v1.SchemaRegistry.ListVersions:output_type -> schemaregistry.v1.ListVersionsResponse
+	12, // 28: schemaregistry.v1.SchemaRegistry.GetConfig:output_type -> schemaregistry.v1.Config
+	12, // 29: schemaregistry.v1.SchemaRegistry.UpdateConfig:output_type -> schemaregistry.v1.Config
+	12, // 30: schemaregistry.v1.SchemaRegistry.DeleteConfig:output_type -> schemaregistry.v1.Config
+	17, // 31: schemaregistry.v1.SchemaRegistry.CheckCompatibility:output_type -> schemaregistry.v1.CompatibilityResponse
+	19, // 32: schemaregistry.v1.SchemaRegistry.ValidateData:output_type -> schemaregistry.v1.ValidationResponse
+	23, // [23:33] is the sub-list for method output_type
+	13, // [13:23] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_schemaregistry_v1_registry_proto_init() }
+func file_schemaregistry_v1_registry_proto_init() {
+	if File_schemaregistry_v1_registry_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemaregistry_v1_registry_proto_rawDesc), len(file_schemaregistry_v1_registry_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_schemaregistry_v1_registry_proto_goTypes,
+		DependencyIndexes: file_schemaregistry_v1_registry_proto_depIdxs,
+		EnumInfos:         file_schemaregistry_v1_registry_proto_enumTypes,
+		MessageInfos:      file_schemaregistry_v1_registry_proto_msgTypes,
+	}.Build()
+	File_schemaregistry_v1_registry_proto = out.File
+	file_schemaregistry_v1_registry_proto_goTypes = nil
+	file_schemaregistry_v1_registry_proto_depIdxs = nil
+}
@@ -0,0 +1,486 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: schemaregistry/v1/registry.proto
+
+// API gRPC do Schema Registry, equivalente às rotas /v1 da API HTTP.
+// Código Go gerado em internal/grpcapi/registrypb (ver "make proto").
+
+package registrypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SchemaRegistry_RegisterSchema_FullMethodName     = "/schemaregistry.v1.SchemaRegistry/RegisterSchema"
+	SchemaRegistry_GetSchema_FullMethodName          = "/schemaregistry.v1.SchemaRegistry/GetSchema"
+	SchemaRegistry_GetSchemaByID_FullMethodName      = "/schemaregistry.v1.SchemaRegistry/GetSchemaByID"
+	SchemaRegistry_ListSubjects_FullMethodName       = "/schemaregistry.v1.SchemaRegistry/ListSubjects"
+	SchemaRegistry_ListVersions_FullMethodName       = "/schemaregistry.v1.SchemaRegistry/ListVersions"
+	SchemaRegistry_GetConfig_FullMethodName          = "/schemaregistry.v1.SchemaRegistry/GetConfig"
+	SchemaRegistry_UpdateConfig_FullMethodName       = "/schemaregistry.v1.SchemaRegistry/UpdateConfig"
+	SchemaRegistry_DeleteConfig_FullMethodName       = "/schemaregistry.v1.SchemaRegistry/DeleteConfig"
+	SchemaRegistry_CheckCompatibility_FullMethodName = "/schemaregistry.v1.SchemaRegistry/CheckCompatibility"
+	SchemaRegistry_ValidateData_FullMethodName       = "/schemaregistry.v1.SchemaRegistry/ValidateData"
+)
+
+// SchemaRegistryClient is the client API for SchemaRegistry service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SchemaRegistryClient interface {
+	// Registra uma nova versão de schema no subject
+	RegisterSchema(ctx context.Context, in *RegisterSchemaRequest, opts ...grpc.CallOption) (*Schema, error)
+	// Obtém uma versão do subject (version 0 = latest)
+	GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*Schema, error)
+	// Obtém um schema pelo ID global
+	GetSchemaByID(ctx context.Context, in *GetSchemaByIDRequest, opts ...grpc.CallOption) (*Schema, error)
+	// Lista subjects com filtros e paginação por cursor
+	ListSubjects(ctx context.Context, in *ListSubjectsRequest, opts ...grpc.CallOption) (*ListSubjectsResponse, error)
+	// Lista as versões de um subject com paginação por cursor
+	ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error)
+	// Obtém a configuração de compatibilidade do subject
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	// Altera a configuração de compatibilidade do subject
+	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	// Restaura a configuração padrão do subject
+	DeleteConfig(ctx context.Context, in *DeleteConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	// Verifica se um schema é compatível com a última versão do subject
+	CheckCompatibility(ctx context.Context, in *CheckCompatibilityRequest, opts ...grpc.CallOption) (*CompatibilityResponse, error)
+	// Valida dados contra a última versão do subject ou contra o schema informado
+	ValidateData(ctx context.Context, in *ValidateDataRequest, opts ...grpc.CallOption) (*ValidationResponse, error)
+}
+
+type schemaRegistryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSchemaRegistryClient(cc grpc.ClientConnInterface) SchemaRegistryClient {
+	return &schemaRegistryClient{cc}
+}
+
+func (c *schemaRegistryClient) RegisterSchema(ctx context.Context, in *RegisterSchemaRequest, opts ...grpc.CallOption) (*Schema, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Schema)
+	err := c.cc.Invoke(ctx, SchemaRegistry_RegisterSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaRegistryClient) GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*Schema, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Schema)
+	err := c.cc.Invoke(ctx, SchemaRegistry_GetSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaRegistryClient) GetSchemaByID(ctx context.Context, in *GetSchemaByIDRequest, opts ...grpc.CallOption) (*Schema, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Schema)
+	err := c.cc.Invoke(ctx, SchemaRegistry_GetSchemaByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaRegistryClient) ListSubjects(ctx context.Context, in *ListSubjectsRequest, opts ...grpc.CallOption) (*ListSubjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubjectsResponse)
+	err := c.cc.Invoke(ctx, SchemaRegistry_ListSubjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaRegistryClient) ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVersionsResponse)
+	err := c.cc.Invoke(ctx, SchemaRegistry_ListVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaRegistryClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Config)
+	err := c.cc.Invoke(ctx, SchemaRegistry_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaRegistryClient) UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Config)
+	err := c.cc.Invoke(ctx, SchemaRegistry_UpdateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaRegistryClient) DeleteConfig(ctx context.Context, in *DeleteConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Config)
+	err := c.cc.Invoke(ctx, SchemaRegistry_DeleteConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaRegistryClient) CheckCompatibility(ctx context.Context, in *CheckCompatibilityRequest, opts ...grpc.CallOption) (*CompatibilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompatibilityResponse)
+	err := c.cc.Invoke(ctx, SchemaRegistry_CheckCompatibility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaRegistryClient) ValidateData(ctx context.Context, in *ValidateDataRequest, opts ...grpc.CallOption) (*ValidationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidationResponse)
+	err := c.cc.Invoke(ctx, SchemaRegistry_ValidateData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchemaRegistryServer is the server API for SchemaRegistry service.
+// All implementations must embed UnimplementedSchemaRegistryServer
+// for forward compatibility.
+type SchemaRegistryServer interface {
+	// Registra uma nova versão de schema no subject
+	RegisterSchema(context.Context, *RegisterSchemaRequest) (*Schema, error)
+	// Obtém uma versão do subject (version 0 = latest)
+	GetSchema(context.Context, *GetSchemaRequest) (*Schema, error)
+	// Obtém um schema pelo ID global
+	GetSchemaByID(context.Context, *GetSchemaByIDRequest) (*Schema, error)
+	// Lista subjects com filtros e paginação por cursor
+	ListSubjects(context.Context, *ListSubjectsRequest) (*ListSubjectsResponse, error)
+	// Lista as versões de um subject com paginação por cursor
+	ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error)
+	// Obtém a configuração de compatibilidade do subject
+	GetConfig(context.Context, *GetConfigRequest) (*Config, error)
+	// Altera a configuração de compatibilidade do subject
+	UpdateConfig(context.Context, *UpdateConfigRequest) (*Config, error)
+	// Restaura a configuração padrão do subject
+	DeleteConfig(context.Context, *DeleteConfigRequest) (*Config, error)
+	// Verifica se um schema é compatível com a última versão do subject
+	CheckCompatibility(context.Context, *CheckCompatibilityRequest) (*CompatibilityResponse, error)
+	// Valida dados contra a última versão do subject ou contra o schema informado
+	ValidateData(context.Context, *ValidateDataRequest) (*ValidationResponse, error)
+	mustEmbedUnimplementedSchemaRegistryServer()
+}
+
+// UnimplementedSchemaRegistryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSchemaRegistryServer struct{}
+
+func (UnimplementedSchemaRegistryServer) RegisterSchema(context.Context, *RegisterSchemaRequest) (*Schema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterSchema not implemented")
+}
+func (UnimplementedSchemaRegistryServer) GetSchema(context.Context, *GetSchemaRequest) (*Schema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchema not implemented")
+}
+func (UnimplementedSchemaRegistryServer) GetSchemaByID(context.Context, *GetSchemaByIDRequest) (*Schema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchemaByID not implemented")
+}
+func (UnimplementedSchemaRegistryServer) ListSubjects(context.Context, *ListSubjectsRequest) (*ListSubjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubjects not implemented")
+}
+func (UnimplementedSchemaRegistryServer) ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedSchemaRegistryServer) GetConfig(context.Context, *GetConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedSchemaRegistryServer) UpdateConfig(context.Context, *UpdateConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfig not implemented")
+}
+func (UnimplementedSchemaRegistryServer) DeleteConfig(context.Context, *DeleteConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConfig not implemented")
+}
+func (UnimplementedSchemaRegistryServer) CheckCompatibility(context.Context, *CheckCompatibilityRequest) (*CompatibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckCompatibility not implemented")
+}
+func (UnimplementedSchemaRegistryServer) ValidateData(context.Context, *ValidateDataRequest) (*ValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateData not implemented")
+}
+func (UnimplementedSchemaRegistryServer) mustEmbedUnimplementedSchemaRegistryServer() {}
+func (UnimplementedSchemaRegistryServer) testEmbeddedByValue()                        {}
+
+// UnsafeSchemaRegistryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchemaRegistryServer will
+// result in compilation errors.
+type UnsafeSchemaRegistryServer interface {
+	mustEmbedUnimplementedSchemaRegistryServer()
+}
+
+func RegisterSchemaRegistryServer(s grpc.ServiceRegistrar, srv SchemaRegistryServer) {
+	// If the following call pancis, it indicates UnimplementedSchemaRegistryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SchemaRegistry_ServiceDesc, srv)
+}
+
+func _SchemaRegistry_RegisterSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).RegisterSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_RegisterSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).RegisterSchema(ctx, req.(*RegisterSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaRegistry_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).GetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_GetSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).GetSchema(ctx, req.(*GetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaRegistry_GetSchemaByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).GetSchemaByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_GetSchemaByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).GetSchemaByID(ctx, req.(*GetSchemaByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaRegistry_ListSubjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).ListSubjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_ListSubjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).ListSubjects(ctx, req.(*ListSubjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaRegistry_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).ListVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_ListVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).ListVersions(ctx, req.(*ListVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaRegistry_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaRegistry_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).UpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_UpdateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).UpdateConfig(ctx, req.(*UpdateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaRegistry_DeleteConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).DeleteConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_DeleteConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).DeleteConfig(ctx, req.(*DeleteConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaRegistry_CheckCompatibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckCompatibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).CheckCompatibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_CheckCompatibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).CheckCompatibility(ctx, req.(*CheckCompatibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaRegistry_ValidateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaRegistryServer).ValidateData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaRegistry_ValidateData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaRegistryServer).ValidateData(ctx, req.(*ValidateDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SchemaRegistry_ServiceDesc is the grpc.ServiceDesc for SchemaRegistry service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SchemaRegistry_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "schemaregistry.v1.SchemaRegistry",
+	HandlerType: (*SchemaRegistryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterSchema",
+			Handler:    _SchemaRegistry_RegisterSchema_Handler,
+		},
+		{
+			MethodName: "GetSchema",
+			Handler:    _SchemaRegistry_GetSchema_Handler,
+		},
+		{
+			MethodName: "GetSchemaByID",
+			Handler:    _SchemaRegistry_GetSchemaByID_Handler,
+		},
+		{
+			MethodName: "ListSubjects",
+			Handler:    _SchemaRegistry_ListSubjects_Handler,
+		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _SchemaRegistry_ListVersions_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _SchemaRegistry_GetConfig_Handler,
+		},
+		{
+			MethodName: "UpdateConfig",
+			Handler:    _SchemaRegistry_UpdateConfig_Handler,
+		},
+		{
+			MethodName: "DeleteConfig",
+			Handler:    _SchemaRegistry_DeleteConfig_Handler,
+		},
+		{
+			MethodName: "CheckCompatibility",
+			Handler:    _SchemaRegistry_CheckCompatibility_Handler,
+		},
+		{
+			MethodName: "ValidateData",
+			Handler:    _SchemaRegistry_ValidateData_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "schemaregistry/v1/registry.proto",
+}
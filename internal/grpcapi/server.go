@@ -0,0 +1,328 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+	"github.com/rodrigues-daniel/data-platform/internal/grpcapi/registrypb"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Authorizer decide se o principal pode executar a operação no subject
+type Authorizer interface {
+	Authorize(principal *auth.Principal, operation, subject string) error
+}
+
+// Server implementa o serviço gRPC SchemaRegistry sobre o schema.Registry
+type Server struct {
+	registrypb.UnimplementedSchemaRegistryServer
+
+	registry   *schema.Registry
+	authorizer Authorizer
+}
+
+func NewServer(registry *schema.Registry) *Server {
+	return &Server{registry: registry}
+}
+
+// SetAuthorizer habilita a verificação de permissões por subject
+func (s *Server) SetAuthorizer(authorizer Authorizer) {
+	s.authorizer = authorizer
+}
+
+// authorize retorna PermissionDenied quando o principal autenticado não tem permissão.
+// Sem autorizador ou sem principal (autenticação desabilitada) a chamada é liberada.
+func (s *Server) authorize(ctx context.Context, operation, subject string) error {
+	if s.authorizer == nil {
+		return nil
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if err := s.authorizer.Authorize(principal, operation, subject); err != nil {
+		return statusError(codes.PermissionDenied, models.ErrorCodeForbidden, err.Error())
+	}
+	return nil
+}
+
+func (s *Server) RegisterSchema(ctx context.Context, req *registrypb.RegisterSchemaRequest) (*registrypb.Schema, error) {
+	if err := s.authorize(ctx, acl.OpRegister, req.GetSubject()); err != nil {
+		return nil, err
+	}
+
+	schema := &models.Schema{
+		Subject:    req.GetSubject(),
+		SchemaType: req.GetSchemaType(),
+		Schema:     req.GetSchema(),
+		References: referencesFromProto(req.GetReferences()),
+		Metadata:   req.GetMetadata(),
+	}
+	registered, err := s.registry.RegisterSchema(ctx, schema)
+	if err != nil {
+		return nil, registryStatus(err)
+	}
+	return schemaToProto(registered), nil
+}
+
+func (s *Server) GetSchema(ctx context.Context, req *registrypb.GetSchemaRequest) (*registrypb.Schema, error) {
+	if req.GetVersion() < 0 {
+		return nil, statusError(codes.InvalidArgument, models.ErrorCodeInvalidVersion,
+			fmt.Sprintf("invalid version %d", req.GetVersion()))
+	}
+	if err := s.authorize(ctx, acl.OpRead, req.GetSubject()); err != nil {
+		return nil, err
+	}
+
+	var schema *models.Schema
+	var err error
+	if req.GetVersion() == 0 {
+		schema, err = s.registry.GetLatestSchema(ctx, req.GetSubject())
+	} else {
+		schema, err = s.registry.FindSchema(ctx, req.GetSubject(), int(req.GetVersion()), req.GetIncludeDeleted())
+	}
+	if err != nil {
+		return nil, registryStatus(err)
+	}
+	return schemaToProto(schema), nil
+}
+
+func (s *Server) GetSchemaByID(ctx context.Context, req *registrypb.GetSchemaByIDRequest) (*registrypb.Schema, error) {
+	// Sem permissão de leitura no subject, o ID é tratado como inexistente
+	schema, err := s.registry.FindVisibleSchemaByID(ctx, req.GetId(), req.GetIncludeDeleted(),
+		func(subject string) bool {
+			return s.authorize(ctx, acl.OpRead, subject) == nil
+		})
+	if err != nil {
+		return nil, registryStatus(err)
+	}
+	return schemaToProto(schema), nil
+}
+
+func (s *Server) ListSubjects(ctx context.Context, req *registrypb.ListSubjectsRequest) (*registrypb.ListSubjectsResponse, error) {
+	limit, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.registry.QuerySubjects(ctx, models.SubjectQuery{
+		Prefix:     req.GetPrefix(),
+		Contains:   req.GetContains(),
+		SchemaType: req.GetSchemaType(),
+		Labels:     req.GetLabels(),
+		Deleted:    deletedFromProto(req.GetDeleted()),
+		Order:      orderFromProto(req.GetOrder()),
+		After:      req.GetPageToken(),
+		Limit:      limit,
+		// Subjects sem permissão de leitura são omitidos antes do limite, como na API HTTP
+		Visible: func(subject string) bool {
+			return s.authorize(ctx, acl.OpRead, subject) == nil
+		},
+	})
+	if err != nil {
+		return nil, registryStatus(err)
+	}
+
+	resp := &registrypb.ListSubjectsResponse{NextPageToken: page.NextCursor}
+	for _, info := range page.Subjects {
+		resp.Subjects = append(resp.Subjects, &registrypb.SubjectInfo{
+			Subject:       info.Subject,
+			LatestVersion: int32(info.LatestVersion),
+			SchemaType:    info.SchemaType,
+			Metadata:      info.Metadata,
+			Deleted:       info.Deleted,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) ListVersions(ctx context.Context, req *registrypb.ListVersionsRequest) (*registrypb.ListVersionsResponse, error) {
+	if req.GetPageToken() < 0 {
+		return nil, statusError(codes.InvalidArgument, models.ErrorCodeBadRequest,
+			fmt.Sprintf("invalid page_token %d", req.GetPageToken()))
+	}
+	limit, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, acl.OpRead, req.GetSubject()); err != nil {
+		return nil, err
+	}
+
+	page, err := s.registry.QueryVersions(ctx, req.GetSubject(), models.VersionQuery{
+		Deleted: deletedFromProto(req.GetDeleted()),
+		Order:   orderFromProto(req.GetOrder()),
+		After:   int(req.GetPageToken()),
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, registryStatus(err)
+	}
+
+	resp := &registrypb.ListVersionsResponse{
+		Subject:       req.GetSubject(),
+		Versions:      make([]int32, len(page.Versions)),
+		NextPageToken: int32(page.NextCursor),
+	}
+	for i, version := range page.Versions {
+		resp.Versions[i] = int32(version)
+	}
+	return resp, nil
+}
+
+func (s *Server) GetConfig(ctx context.Context, req *registrypb.GetConfigRequest) (*registrypb.Config, error) {
+	if err := s.authorize(ctx, acl.OpRead, req.GetSubject()); err != nil {
+		return nil, err
+	}
+
+	config, err := s.registry.GetConfig(ctx, req.GetSubject())
+	if err != nil {
+		return nil, registryStatus(err)
+	}
+	return configToProto(config), nil
+}
+
+func (s *Server) UpdateConfig(ctx context.Context, req *registrypb.UpdateConfigRequest) (*registrypb.Config, error) {
+	if err := s.authorize(ctx, acl.OpConfig, req.GetSubject()); err != nil {
+		return nil, err
+	}
+
+	config := &models.SchemaConfig{
+		Subject:       req.GetSubject(),
+		Compatibility: req.GetCompatibility(),
+		MaxVersions:   int(req.GetMaxVersions()),
+	}
+	if err := s.registry.SetConfig(ctx, config); err != nil {
+		return nil, registryStatus(err)
+	}
+	return configToProto(config), nil
+}
+
+func (s *Server) DeleteConfig(ctx context.Context, req *registrypb.DeleteConfigRequest) (*registrypb.Config, error) {
+	if err := s.authorize(ctx, acl.OpConfig, req.GetSubject()); err != nil {
+		return nil, err
+	}
+
+	// Reset para padrão
+	config := &models.SchemaConfig{
+		Subject:       req.GetSubject(),
+		Compatibility: models.CompatibilityBackward,
+	}
+	if err := s.registry.SetConfig(ctx, config); err != nil {
+		return nil, registryStatus(err)
+	}
+	return configToProto(config), nil
+}
+
+func (s *Server) CheckCompatibility(ctx context.Context, req *registrypb.CheckCompatibilityRequest) (*registrypb.CompatibilityResponse, error) {
+	if err := s.authorize(ctx, acl.OpRead, req.GetSubject()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.registry.CheckCompatibility(ctx, req.GetSubject(), "", req.GetSchema())
+	if err != nil {
+		return nil, registryStatus(err)
+	}
+	return &registrypb.CompatibilityResponse{
+		IsCompatible: result.Valid,
+		Errors:       result.Errors,
+		Warnings:     result.Warnings,
+	}, nil
+}
+
+func (s *Server) ValidateData(ctx context.Context, req *registrypb.ValidateDataRequest) (*registrypb.ValidationResponse, error) {
+	if err := s.authorize(ctx, acl.OpRead, req.GetSubject()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.registry.ValidateData(ctx, &models.SchemaValidationRequest{
+		Subject: req.GetSubject(),
+		Schema:  req.GetSchema(),
+		Data:    req.GetData().AsInterface(),
+	})
+	if err != nil {
+		return nil, registryStatus(err)
+	}
+	return &registrypb.ValidationResponse{
+		IsValid:  result.Valid,
+		Errors:   result.Errors,
+		Warnings: result.Warnings,
+	}, nil
+}
+
+// pageSize aplica o limite padrão da listagem, como no /v1 da API HTTP
+func pageSize(size int32) (int, error) {
+	if size < 0 {
+		return 0, statusError(codes.InvalidArgument, models.ErrorCodeBadRequest,
+			fmt.Sprintf("invalid page_size %d", size))
+	}
+	if size == 0 {
+		return schema.DefaultListLimit, nil
+	}
+	return min(int(size), schema.MaxListLimit), nil
+}
+
+func deletedFromProto(filter registrypb.DeletedFilter) string {
+	switch filter {
+	case registrypb.DeletedFilter_DELETED_FILTER_INCLUDE:
+		return models.DeletedInclude
+	case registrypb.DeletedFilter_DELETED_FILTER_ONLY:
+		return models.DeletedOnly
+	default:
+		return models.DeletedExclude
+	}
+}
+
+func orderFromProto(order registrypb.SortOrder) string {
+	if order == registrypb.SortOrder_SORT_ORDER_DESC {
+		return models.OrderDesc
+	}
+	return models.OrderAsc
+}
+
+func schemaToProto(schema *models.Schema) *registrypb.Schema {
+	references := make([]*registrypb.Reference, len(schema.References))
+	for i, ref := range schema.References {
+		references[i] = &registrypb.Reference{Name: ref.Name, Subject: ref.Subject, Version: int32(ref.Version)}
+	}
+
+	return &registrypb.Schema{
+		Id:          schema.ID,
+		Subject:     schema.Subject,
+		Version:     int32(schema.Version),
+		Schema:      schema.Schema,
+		SchemaType:  schema.SchemaType,
+		Fingerprint: schema.Fingerprint(),
+		References:  references,
+		Metadata:    schema.Metadata,
+		Deleted:     schema.Deleted,
+		CreatedAt:   timestamppb.New(schema.CreatedAt),
+	}
+}
+
+func referencesFromProto(refs []*registrypb.Reference) []models.Reference {
+	if len(refs) == 0 {
+		return nil
+	}
+	references := make([]models.Reference, len(refs))
+	for i, ref := range refs {
+		references[i] = models.Reference{Name: ref.GetName(), Subject: ref.GetSubject(), Version: int(ref.GetVersion())}
+	}
+	return references
+}
+
+func configToProto(config *models.SchemaConfig) *registrypb.Config {
+	return &registrypb.Config{
+		Subject:       config.Subject,
+		Compatibility: config.Compatibility,
+		MaxVersions:   int32(config.MaxVersions),
+	}
+}
@@ -1,6 +1,7 @@
 package mappers
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/rodrigues-daniel/data-platform/internal/dtos"
@@ -12,7 +13,7 @@ func MapCreateSchemaRequestToModel(req dtos.CreateSchemaRequest) models.Schema {
 		ID:         "",
 		Subject:    req.Subject,
 		Version:    0,
-		Schema:     string(req.Schema),
+		Schema:     SchemaFromRaw(req.Schema),
 		SchemaType: req.SchemaType,
 		References: req.References,
 		Metadata:   req.Metadata,
@@ -20,3 +21,161 @@ func MapCreateSchemaRequestToModel(req dtos.CreateSchemaRequest) models.Schema {
 		UpdatedAt:  time.Now(),
 	}
 }
+
+func MapLookupSchemaRequestToModel(subject string, req dtos.LookupSchemaRequest) models.Schema {
+	return models.Schema{
+		Subject:    subject,
+		Schema:     SchemaFromRaw(req.Schema),
+		SchemaType: req.SchemaType,
+		References: req.References,
+	}
+}
+
+func MapSchemaToResponse(schema *models.Schema) dtos.SchemaResponse {
+	var references []dtos.Reference
+	for _, ref := range schema.References {
+		references = append(references, dtos.Reference{Name: ref.Name, Subject: ref.Subject, Version: ref.Version})
+	}
+
+	response := dtos.SchemaResponse{
+		ID:          schema.ID,
+		Subject:     schema.Subject,
+		Version:     schema.Version,
+		Schema:      SchemaToRaw(schema.SchemaType, schema.Schema),
+		SchemaType:  schema.SchemaType,
+		Fingerprint: schema.Fingerprint(),
+		References:  references,
+		Metadata:    schema.Metadata,
+		Deleted:     schema.Deleted,
+		CreatedAt:   schema.CreatedAt,
+	}
+	if schema.NormalizedSchema != "" {
+		response.NormalizedSchema = SchemaToRaw(schema.SchemaType, schema.NormalizedSchema)
+	}
+	return response
+}
+
+func MapSchemaResponseToModel(resp dtos.SchemaResponse) models.Schema {
+	var references []models.Reference
+	for _, ref := range resp.References {
+		references = append(references, models.Reference{Name: ref.Name, Subject: ref.Subject, Version: ref.Version})
+	}
+
+	return models.Schema{
+		ID:         resp.ID,
+		Subject:    resp.Subject,
+		Version:    resp.Version,
+		Schema:     SchemaFromRaw(resp.Schema),
+		SchemaType: resp.SchemaType,
+		References: references,
+		// Schemas sem normalização não trazem a forma canônica
+		NormalizedSchema: normalizedFromRaw(resp.NormalizedSchema),
+		Metadata:         resp.Metadata,
+		Deleted:          resp.Deleted,
+		CreatedAt:        resp.CreatedAt,
+	}
+}
+
+// SchemaToRaw embute o schema como JSON para JSON/AVRO e como string para PROTOBUF
+// (ou quando o conteúdo armazenado não é JSON válido)
+func SchemaToRaw(schemaType, schema string) json.RawMessage {
+	if schemaType != models.SchemaTypeProtobuf && json.Valid([]byte(schema)) {
+		return json.RawMessage(schema)
+	}
+
+	encoded, _ := json.Marshal(schema)
+	return encoded
+}
+
+// SchemaFromRaw converte o schema recebido para a forma armazenada: strings JSON
+// são decodificadas, demais valores são mantidos como JSON
+func SchemaFromRaw(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	return string(raw)
+}
+
+func normalizedFromRaw(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	return SchemaFromRaw(raw)
+}
+
+func MapSchemaConfigRequestToModel(subject string, req dtos.SchemaConfigRequest) models.SchemaConfig {
+	return models.SchemaConfig{
+		Subject:       subject,
+		Compatibility: req.Compatibility,
+		MaxVersions:   req.MaxVersions,
+	}
+}
+
+func MapSchemaConfigToResponse(config *models.SchemaConfig) dtos.SchemaConfigResponse {
+	return dtos.SchemaConfigResponse{
+		Subject:       config.Subject,
+		Compatibility: config.Compatibility,
+		MaxVersions:   config.MaxVersions,
+	}
+}
+
+func MapSubjectInfoToDetail(info models.SubjectInfo) dtos.SubjectDetail {
+	detail := dtos.SubjectDetail{
+		Subject:       info.Subject,
+		LatestVersion: info.LatestVersion,
+		SchemaType:    info.SchemaType,
+		Metadata:      info.Metadata,
+		Deleted:       info.Deleted,
+	}
+	if info.Config != nil {
+		config := MapSchemaConfigToResponse(info.Config)
+		detail.Config = &config
+	}
+	return detail
+}
+
+func MapSchemaModeRequestToModel(subject string, req dtos.SchemaModeRequest) models.SchemaMode {
+	return models.SchemaMode{Subject: subject, Mode: req.Mode}
+}
+
+func MapSchemaModeToResponse(mode *models.SchemaMode) dtos.SchemaModeResponse {
+	return dtos.SchemaModeResponse{Subject: mode.Subject, Mode: mode.Mode}
+}
+
+// MapCompatibilityResult com verbose inclui o detalhe de cada incompatibilidade
+func MapCompatibilityResult(result *models.SchemaValidationResult, verbose bool) dtos.CompatibilityResponse {
+	response := dtos.CompatibilityResponse{
+		IsCompatible: result.Valid,
+		Errors:       result.Errors,
+		Warnings:     result.Warnings,
+	}
+	if verbose {
+		response.Incompatibilities = result.Incompatibilities
+	}
+	return response
+}
+
+func MapValidationResult(result *models.SchemaValidationResult) dtos.ValidationResponse {
+	return dtos.ValidationResponse{
+		IsValid:  result.Valid,
+		Errors:   result.Errors,
+		Warnings: result.Warnings,
+	}
+}
+
+func MapValidateDataRequestToModel(subject string, req dtos.ValidateDataRequest) models.SchemaValidationRequest {
+	validation := models.SchemaValidationRequest{Subject: subject, Data: req.Data}
+	if len(req.Schema) > 0 {
+		validation.Schema = SchemaFromRaw(req.Schema)
+	}
+	return validation
+}
+
+func MapCompatibilityCheckRequestToSchema(subject string, req dtos.CompatibilityCheckRequest) *models.Schema {
+	return &models.Schema{
+		Subject:    subject,
+		SchemaType: req.SchemaType,
+		Schema:     SchemaFromRaw(req.Schema),
+	}
+}
@@ -0,0 +1,74 @@
+package mappers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestSchemaToRaw(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		schema     string
+		wantRaw    string
+	}{
+		{
+			name:       "should embed JSON schema as object",
+			schemaType: models.SchemaTypeJSON,
+			schema:     `{"type":"object"}`,
+			wantRaw:    `{"type":"object"}`,
+		},
+		{
+			name:       "should embed AVRO primitive as JSON string value",
+			schemaType: models.SchemaTypeAVRO,
+			schema:     `"string"`,
+			wantRaw:    `"string"`,
+		},
+		{
+			name:       "should encode PROTOBUF as string",
+			schemaType: models.SchemaTypeProtobuf,
+			schema:     `syntax = "proto3";`,
+			wantRaw:    `"syntax = \"proto3\";"`,
+		},
+		{
+			name:       "should encode invalid JSON as string",
+			schemaType: models.SchemaTypeJSON,
+			schema:     `{broken`,
+			wantRaw:    `"{broken"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := SchemaToRaw(tt.schemaType, tt.schema)
+			if string(raw) != tt.wantRaw {
+				t.Errorf("SchemaToRaw() = %s, want %s", raw, tt.wantRaw)
+			}
+			if !json.Valid(raw) {
+				t.Errorf("SchemaToRaw() produced invalid JSON: %s", raw)
+			}
+		})
+	}
+}
+
+func TestSchemaFromRaw(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{name: "should keep JSON object", raw: `{"type":"object"}`, want: `{"type":"object"}`},
+		{name: "should unquote string", raw: `"syntax = \"proto3\";"`, want: `syntax = "proto3";`},
+		{name: "should unquote serialized JSON", raw: `"{\"type\":\"object\"}"`, want: `{"type":"object"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SchemaFromRaw(json.RawMessage(tt.raw)); got != tt.want {
+				t.Errorf("SchemaFromRaw() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,21 +1,26 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"time"
 )
 
 // Schema representa um schema no registry
 type Schema struct {
-	ID         string            `json:"id"`
-	Subject    string            `json:"subject"`
-	Version    int               `json:"version"`
-	Schema     string            `json:"schema"`
-	SchemaType string            `json:"schema_type"` // AVRO, JSON, PROTOBUF
-	References []Reference       `json:"references,omitempty"`
-	Metadata   map[string]string `json:"metadata,omitempty"`
-	CreatedAt  time.Time         `json:"created_at"`
-	UpdatedAt  time.Time         `json:"updated_at"`
+	ID               string            `json:"id"`
+	Subject          string            `json:"subject"`
+	Version          int               `json:"version"`
+	Schema           string            `json:"schema"`
+	SchemaType       string            `json:"schema_type"`                 // AVRO, JSON, PROTOBUF
+	NormalizedSchema string            `json:"normalized_schema,omitempty"` // forma canônica, quando registrado com normalize
+	References       []Reference       `json:"references,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	Deleted          bool              `json:"deleted,omitempty"` // removido via soft delete
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
 }
 
 // Reference representa dependências entre schemas
@@ -28,7 +33,116 @@ type Reference struct {
 // SchemaConfig configuração de compatibilidade
 type SchemaConfig struct {
 	Subject       string `json:"subject"`
-	Compatibility string `json:"compatibility"` // BACKWARD, FORWARD, FULL, NONE
+	Compatibility string `json:"compatibility"`          // BACKWARD, FORWARD, FULL, NONE
+	MaxVersions   int    `json:"max_versions,omitempty"` // 0 usa o limite padrão do registry
+}
+
+// SchemaMode modo de operação de um subject
+type SchemaMode struct {
+	Subject string `json:"subject"`
+	Mode    string `json:"mode"` // READWRITE, READONLY, IMPORT
+}
+
+// RestoreReport resumo de uma restauração de backup
+type RestoreReport struct {
+	Schemas int `json:"schemas"`
+	Configs int `json:"configs"`
+	Modes   int `json:"modes"`
+	Skipped int `json:"skipped,omitempty"` // versões já restauradas, puladas com resume
+}
+
+// ImportReport resumo de uma importação de outro registry
+type ImportReport struct {
+	Imported  int              `json:"imported"`
+	Skipped   int              `json:"skipped"`
+	Configs   int              `json:"configs"`
+	Modes     int              `json:"modes"`
+	Conflicts []ImportConflict `json:"conflicts,omitempty"`
+	Warnings  []string         `json:"warnings,omitempty"`
+}
+
+// ImportConflict item que não pôde ser importado
+type ImportConflict struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// ACLBinding concede um papel a um principal sobre um padrão de subjects
+type ACLBinding struct {
+	ID        string    `json:"id"`
+	Principal string    `json:"principal"`
+	Role      string    `json:"role"`    // reader, writer, admin
+	Pattern   string    `json:"pattern"` // ex: payments.*, * para todos
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Webhook destino HTTP que recebe os eventos de schema
+type Webhook struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	Subjects   string   `json:"subjects"`              // padrão de subjects, ex: payments.*, * para todos
+	EventTypes []string `json:"event_types,omitempty"` // vazio = todos os tipos
+	// Secret chave do HMAC das entregas; a API só a retorna na criação, quando gerada pelo registry
+	Secret              string    `json:"secret,omitempty"`
+	Enabled             bool      `json:"enabled"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	DisabledReason      string    `json:"disabled_reason,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// WebhookRequest corpo de criação/atualização de webhook; campos omitidos na atualização são mantidos
+type WebhookRequest struct {
+	URL        string   `json:"url"`
+	Subjects   string   `json:"subjects,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Secret     string   `json:"secret,omitempty"`
+	Enabled    *bool    `json:"enabled,omitempty"`
+}
+
+// WebhookDelivery registro de uma tentativa de entrega de evento a um webhook
+type WebhookDelivery struct {
+	Sequence      uint64     `json:"sequence,omitempty"`
+	WebhookID     string     `json:"webhook_id"`
+	EventID       string     `json:"event_id"`
+	EventType     string     `json:"event_type"`
+	EventSequence uint64     `json:"event_sequence"`
+	Subject       string     `json:"subject"`
+	Attempt       int        `json:"attempt"`
+	Status        string     `json:"status"` // success, retrying, failed
+	StatusCode    int        `json:"status_code,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	DurationMs    int64      `json:"duration_ms"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	Timestamp     time.Time  `json:"timestamp"`
+}
+
+// WebhookDeliveryPage página do log de entregas
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	NextCursor uint64            `json:"next_cursor,omitempty"`
+}
+
+// SchemaChange alteração estrutural entre duas versões de um schema. Compatibility indica os modos
+// preservados pela alteração: FULL (ambos), BACKWARD, FORWARD ou NONE.
+type SchemaChange struct {
+	Type          string      `json:"type"`
+	Path          string      `json:"path"`
+	Old           interface{} `json:"old,omitempty"`
+	New           interface{} `json:"new,omitempty"`
+	Compatibility string      `json:"compatibility"`
+}
+
+// SchemaDiff diferença estrutural entre duas versões; Compatibility resume as alterações
+type SchemaDiff struct {
+	Subject       string         `json:"subject"`
+	SchemaType    string         `json:"schema_type"`
+	From          int            `json:"from"`
+	To            int            `json:"to"`
+	Compatibility string         `json:"compatibility"`
+	Changes       []SchemaChange `json:"changes"`
 }
 
 // SchemaValidationRequest pedido de validação
@@ -38,30 +152,167 @@ type SchemaValidationRequest struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// RegistrationCheck resultado de um registro simulado (dry run): a versão que o schema receberia
+// e os problemas encontrados nas etapas do registro. Valid indica que o registro seria aceito.
+type RegistrationCheck struct {
+	Subject     string                `json:"subject"`
+	Version     int                   `json:"version"`
+	SchemaType  string                `json:"schema_type"`
+	Fingerprint string                `json:"fingerprint"`
+	Valid       bool                  `json:"valid"`
+	Existing    bool                  `json:"existing,omitempty"` // conteúdo já registrado: o registro retornaria essa versão
+	Findings    []RegistrationFinding `json:"findings"`
+}
+
+// RegistrationFinding problema encontrado em uma etapa do registro
+type RegistrationFinding struct {
+	Stage           string                 `json:"stage"`
+	Severity        string                 `json:"severity"`
+	Message         string                 `json:"message"`
+	Incompatibility *SchemaIncompatibility `json:"incompatibility,omitempty"`
+}
+
 // SchemaValidationResult resultado da validação
 type SchemaValidationResult struct {
 	Valid    bool     `json:"valid"`
 	Errors   []string `json:"errors,omitempty"`
 	Warnings []string `json:"warnings,omitempty"`
+	// Incompatibilities detalha os erros de compatibilidade (um por alteração e regra violada)
+	Incompatibilities []SchemaIncompatibility `json:"incompatibilities,omitempty"`
 }
 
-// SchemaResponse resposta da API
+// SchemaIncompatibility alteração que viola uma regra de compatibilidade (BACKWARD ou FORWARD)
+// em relação à versão Version
+type SchemaIncompatibility struct {
+	Rule    string      `json:"rule"`
+	Type    string      `json:"type"`
+	Path    string      `json:"path"`
+	Old     interface{} `json:"old,omitempty"`
+	New     interface{} `json:"new,omitempty"`
+	Version int         `json:"version"`
+	Message string      `json:"message"`
+}
+
+// SchemaResponse resposta da API. Em caso de erro, Error traz a mensagem,
+// ErrorCode o código estável e Details informações estruturadas.
 type SchemaResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success   bool                   `json:"success"`
+	Data      interface{}            `json:"data,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	ErrorCode int                    `json:"error_code,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
 }
 
+// Códigos de erro da API; os três primeiros dígitos correspondem ao status HTTP
+const (
+	ErrorCodeBadRequest            = 40001
+	ErrorCodeUnauthorized          = 40101
+	ErrorCodeForbidden             = 40301
+	ErrorCodeSubjectNotFound       = 40401
+	ErrorCodeVersionNotFound       = 40402
+	ErrorCodeSchemaNotFound        = 40403
+	ErrorCodeNotFound              = 40404
+	ErrorCodeIncompatibleSchema    = 40901
+	ErrorCodeConflict              = 40902
+	ErrorCodeBatchAborted          = 40903
+	ErrorCodeInvalidSchema         = 42201
+	ErrorCodeInvalidVersion        = 42202
+	ErrorCodeInvalidConfig         = 42203
+	ErrorCodeInvalidMode           = 42204
+	ErrorCodeOperationNotPermitted = 42205
+	ErrorCodeVersionLimit          = 42206
+	ErrorCodePolicyViolation       = 42207
+	ErrorCodeRateLimited           = 42901
+	ErrorCodeInternal              = 50001
+)
+
 // Eventos para o JetStream
 type SchemaEvent struct {
-	Type      string                 `json:"type"` // SCHEMA_CREATED, SCHEMA_UPDATED, SCHEMA_DELETED
+	ID string `json:"id"`
+	// Sequence posição no stream SCHEMA_EVENTS, preenchida na leitura
+	Sequence  uint64                 `json:"sequence,omitempty"`
+	Type      string                 `json:"type"` // SCHEMA_CREATED, SCHEMA_SOFT_DELETED, SCHEMA_DELETED, CONFIG_CHANGED, MODE_CHANGED, SUBJECT_DELETED
 	Subject   string                 `json:"subject"`
-	Version   int                    `json:"version"`
-	SchemaID  string                 `json:"schema_id"`
+	Version   int                    `json:"version,omitempty"`
+	SchemaID  string                 `json:"schema_id,omitempty"`
 	Timestamp time.Time              `json:"timestamp"`
+	Before    json.RawMessage        `json:"before,omitempty"`
+	After     json.RawMessage        `json:"after,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// AuditRecord registra quem alterou o quê no registry
+type AuditRecord struct {
+	Sequence  uint64          `json:"sequence,omitempty"`
+	Action    string          `json:"action"` // SCHEMA_REGISTERED, SCHEMA_IMPORTED, SCHEMA_DELETED, CONFIG_CHANGED, MODE_CHANGED
+	Subject   string          `json:"subject"`
+	Version   int             `json:"version,omitempty"`
+	Actor     string          `json:"actor"`
+	Timestamp time.Time       `json:"timestamp"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+}
+
+// AuditQuery filtros da consulta de auditoria
+type AuditQuery struct {
+	Subject string
+	Since   time.Time
+	After   uint64
+	Limit   int
+}
+
+// AuditPage página de registros de auditoria
+type AuditPage struct {
+	Records    []AuditRecord `json:"records"`
+	NextCursor uint64        `json:"next_cursor,omitempty"`
+}
+
+// SubjectQuery filtros e paginação da listagem de subjects
+type SubjectQuery struct {
+	Prefix     string
+	Contains   string
+	SchemaType string
+	Labels     map[string]string // metadata da última versão
+	Deleted    string            // exclude (padrão), include, only
+	Order      string            // asc (padrão), desc
+	After      string            // cursor: último subject da página anterior
+	Limit      int               // 0 = sem limite
+	Verbose    bool              // inclui a configuração de cada subject
+	// Visible restringe a listagem aos subjects que o chamador pode ler; é aplicado
+	// antes do limite, para que as páginas fiquem cheias e o cursor seja consistente
+	Visible func(subject string) bool
+}
+
+// SubjectInfo resumo de um subject na listagem
+type SubjectInfo struct {
+	Subject       string            `json:"subject"`
+	LatestVersion int               `json:"latest_version"`
+	SchemaType    string            `json:"schema_type"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	Deleted       bool              `json:"deleted,omitempty"`
+	Config        *SchemaConfig     `json:"config,omitempty"`
+}
+
+// SubjectPage página da listagem de subjects
+type SubjectPage struct {
+	Subjects   []SubjectInfo `json:"subjects"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// VersionQuery filtros e paginação da listagem de versões
+type VersionQuery struct {
+	Deleted string // exclude (padrão), include, only
+	Order   string // asc (padrão), desc
+	After   int    // cursor: última versão da página anterior
+	Limit   int    // 0 = sem limite
+}
+
+// VersionPage página da listagem de versões
+type VersionPage struct {
+	Versions   []int `json:"versions"`
+	NextCursor int   `json:"next_cursor,omitempty"`
+}
+
 // Constants
 const (
 	SchemaTypeAVRO     = "AVRO"
@@ -72,8 +323,87 @@ const (
 	CompatibilityForward  = "FORWARD"
 	CompatibilityFull     = "FULL"
 	CompatibilityNone     = "NONE"
+
+	ModeReadWrite = "READWRITE"
+	ModeReadOnly  = "READONLY"
+	ModeImport    = "IMPORT"
+
+	AuditSchemaRegistered = "SCHEMA_REGISTERED"
+	AuditSchemaDeleted    = "SCHEMA_DELETED"
+	AuditSchemaImported   = "SCHEMA_IMPORTED"
+	AuditConfigChanged    = "CONFIG_CHANGED"
+	AuditModeChanged      = "MODE_CHANGED"
+
+	EventSchemaCreated     = "SCHEMA_CREATED"
+	EventSchemaSoftDeleted = "SCHEMA_SOFT_DELETED"
+	EventSchemaDeleted     = "SCHEMA_DELETED"
+	EventConfigChanged     = "CONFIG_CHANGED"
+	EventModeChanged       = "MODE_CHANGED"
+	EventSubjectDeleted    = "SUBJECT_DELETED"
+
+	ChangeSchemaTypeChanged  = "SCHEMA_TYPE_CHANGED"
+	ChangeTypeAdded          = "TYPE_ADDED"
+	ChangeTypeRemoved        = "TYPE_REMOVED"
+	ChangeFieldAdded         = "FIELD_ADDED"
+	ChangeFieldRemoved       = "FIELD_REMOVED"
+	ChangeFieldTypeChanged   = "FIELD_TYPE_CHANGED"
+	ChangeFieldNumberChanged = "FIELD_NUMBER_CHANGED"
+	ChangeFieldRenamed       = "FIELD_RENAMED"
+	ChangeDefaultChanged     = "DEFAULT_CHANGED"
+	ChangeRequiredAdded      = "REQUIRED_ADDED"
+	ChangeRequiredRemoved    = "REQUIRED_REMOVED"
+	ChangeEnumSymbolAdded    = "ENUM_SYMBOL_ADDED"
+	ChangeEnumSymbolRemoved  = "ENUM_SYMBOL_REMOVED"
+	ChangeEnumNumberChanged  = "ENUM_NUMBER_CHANGED"
+
+	DeliverySuccess  = "success"
+	DeliveryRetrying = "retrying"
+	DeliveryFailed   = "failed"
+
+	// Etapas do registro reportadas no dry run
+	StageMode          = "MODE"
+	StageValidation    = "VALIDATION"
+	StageLint          = "LINT"
+	StageReferences    = "REFERENCES"
+	StageVersionLimit  = "VERSION_LIMIT"
+	StagePolicy        = "POLICY"
+	StageCompatibility = "COMPATIBILITY"
+
+	SeverityError   = "ERROR"
+	SeverityWarning = "WARNING"
+
+	DeletedExclude = "exclude"
+	DeletedInclude = "include"
+	DeletedOnly    = "only"
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
 )
 
+// Fingerprint identifica o conteúdo do schema (tipo, definição e referências), independente de subject e versão.
+// Schemas normalizados usam a forma canônica.
+func (s *Schema) Fingerprint() string {
+	if s.NormalizedSchema != "" {
+		return s.FingerprintOf(s.NormalizedSchema)
+	}
+	return s.FingerprintOf(s.Schema)
+}
+
+// SameContent indica se os dois schemas têm a mesma definição original, tipo e referências
+func (s *Schema) SameContent(other *Schema) bool {
+	return s.FingerprintOf(s.Schema) == other.FingerprintOf(other.Schema)
+}
+
+// FingerprintOf fingerprint de uma definição com o tipo e as referências do schema
+func (s *Schema) FingerprintOf(content string) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n", s.SchemaType, content)
+	for _, ref := range s.References {
+		fmt.Fprintf(hash, "%s %s %d\n", ref.Name, ref.Subject, ref.Version)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 // Validações
 func (s *Schema) Validate() error {
 	if s.Subject == "" {
@@ -111,5 +441,23 @@ func (c *SchemaConfig) Validate() error {
 		return fmt.Errorf("invalid compatibility: %s", c.Compatibility)
 	}
 
+	if c.MaxVersions < 0 {
+		return fmt.Errorf("invalid max versions: %d", c.MaxVersions)
+	}
+
+	return nil
+}
+
+func (m *SchemaMode) Validate() error {
+	validModes := map[string]bool{
+		ModeReadWrite: true,
+		ModeReadOnly:  true,
+		ModeImport:    true,
+	}
+
+	if !validModes[m.Mode] {
+		return fmt.Errorf("invalid mode: %s", m.Mode)
+	}
+
 	return nil
 }
@@ -0,0 +1,95 @@
+package natsapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/dtos"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+)
+
+// subjectQuery valida os filtros de $SR.SUBJECTS com as mesmas regras do /v1/subjects
+func subjectQuery(req dtos.SubjectQueryRequest) (models.SubjectQuery, error) {
+	query := models.SubjectQuery{
+		Prefix:   req.Prefix,
+		Contains: req.Contains,
+		Labels:   req.Labels,
+		After:    req.After,
+		Verbose:  req.Verbose,
+	}
+
+	if req.Type != "" {
+		query.SchemaType = strings.ToUpper(req.Type)
+		switch query.SchemaType {
+		case models.SchemaTypeAVRO, models.SchemaTypeJSON, models.SchemaTypeProtobuf:
+		default:
+			return query, fmt.Errorf("%w: invalid type %q", errBadRequest, req.Type)
+		}
+	}
+
+	var err error
+	if query.Deleted, err = deletedFilter(req.Deleted); err != nil {
+		return query, err
+	}
+	if query.Order, err = sortOrder(req.Order); err != nil {
+		return query, err
+	}
+	if query.Limit, err = listLimit(req.Limit); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// versionQuery valida os filtros de $SR.VERSIONS
+func versionQuery(req dtos.VersionQueryRequest) (models.VersionQuery, error) {
+	query := models.VersionQuery{After: req.After}
+	if req.After < 0 {
+		return query, fmt.Errorf("%w: invalid after cursor %d", errBadRequest, req.After)
+	}
+
+	var err error
+	if query.Deleted, err = deletedFilter(req.Deleted); err != nil {
+		return query, err
+	}
+	if query.Order, err = sortOrder(req.Order); err != nil {
+		return query, err
+	}
+	if query.Limit, err = listLimit(req.Limit); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+func deletedFilter(deleted string) (string, error) {
+	switch deleted {
+	case "", models.DeletedExclude:
+		return models.DeletedExclude, nil
+	case models.DeletedInclude, models.DeletedOnly:
+		return deleted, nil
+	default:
+		return "", fmt.Errorf("%w: invalid deleted %q, expected exclude, include or only", errBadRequest, deleted)
+	}
+}
+
+func sortOrder(order string) (string, error) {
+	switch order {
+	case "", models.OrderAsc:
+		return models.OrderAsc, nil
+	case models.OrderDesc:
+		return order, nil
+	default:
+		return "", fmt.Errorf("%w: invalid order %q, expected asc or desc", errBadRequest, order)
+	}
+}
+
+// listLimit aplica o limite padrão do /v1 quando limit não é informado
+func listLimit(limit int) (int, error) {
+	if limit < 0 {
+		return 0, fmt.Errorf("%w: invalid limit %d", errBadRequest, limit)
+	}
+	if limit == 0 {
+		return schema.DefaultListLimit, nil
+	}
+	return min(limit, schema.MaxListLimit), nil
+}
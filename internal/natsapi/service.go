@@ -0,0 +1,433 @@
+package natsapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/api"
+	"github.com/rodrigues-daniel/data-platform/internal/audit"
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+	"github.com/rodrigues-daniel/data-platform/internal/dtos"
+	"github.com/rodrigues-daniel/data-platform/internal/mappers"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+)
+
+const (
+	ServiceName    = "schema-registry"
+	ServiceVersion = "1.0.0"
+
+	// SubjectPrefix prefixo dos endpoints: $SR.<OPERAÇÃO>.<subject>...
+	SubjectPrefix = "$SR"
+
+	// requestTimeout limite de cada operação no registry
+	requestTimeout = 10 * time.Second
+)
+
+var (
+	// errBadRequest requisição malformada (status 400 no envelope)
+	errBadRequest = errors.New("bad request")
+	// errUnauthorized credenciais ausentes ou inválidas (401)
+	errUnauthorized = errors.New("unauthorized")
+	// errForbidden principal sem permissão para a operação (403)
+	errForbidden = errors.New("forbidden")
+)
+
+// Authorizer decide se o principal pode executar a operação no subject
+type Authorizer interface {
+	Authorize(principal *auth.Principal, operation, subject string) error
+}
+
+// handlerFunc executa a operação e retorna o conteúdo de data do envelope
+type handlerFunc func(ctx context.Context, req micro.Request) (interface{}, error)
+
+// Service expõe as operações do registry como endpoints NATS micro (request-reply).
+// Requisições e respostas usam os mesmos DTOs e envelope da API HTTP /v1.
+type Service struct {
+	registry       *schema.Registry
+	service        micro.Service
+	authenticators []auth.Authenticator
+	authorizer     Authorizer
+}
+
+func NewService(registry *schema.Registry) *Service {
+	return &Service{registry: registry}
+}
+
+// SetAuthenticators exige credenciais em todas as requisições, lidas dos headers da mensagem
+// (Authorization, X-API-Key) com os mesmos autenticadores da API HTTP
+func (s *Service) SetAuthenticators(authenticators []auth.Authenticator) {
+	s.authenticators = authenticators
+}
+
+// SetAuthorizer habilita o controle de acesso por subject
+func (s *Service) SetAuthorizer(authorizer Authorizer) {
+	s.authorizer = authorizer
+}
+
+// Start registra o serviço e seus endpoints na conexão; INFO, STATS e PING ficam em $SRV.*
+func (s *Service) Start(nc *nats.Conn) error {
+	service, err := micro.AddService(nc, micro.Config{
+		Name:        ServiceName,
+		Version:     ServiceVersion,
+		Description: "Schema Registry via NATS request-reply",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add micro service: %w", err)
+	}
+
+	endpoints := []struct {
+		name        string
+		subject     string
+		description string
+		handler     handlerFunc
+	}{
+		{"register", "REGISTER.>", "Registra um schema: $SR.REGISTER.<subject>", s.register},
+		{"get", "GET.>", "Obtém uma versão: $SR.GET.<subject>.<versão|latest>", s.get},
+		{"id", "ID.>", "Obtém um schema pelo ID global: $SR.ID.<id>", s.getByID},
+		{"subjects", "SUBJECTS", "Lista subjects com filtros e paginação", s.listSubjects},
+		{"versions", "VERSIONS.>", "Lista as versões de um subject: $SR.VERSIONS.<subject>", s.listVersions},
+		{"config", "CONFIG.>", "Consulta (corpo vazio) ou altera a configuração: $SR.CONFIG.<subject>", s.config},
+		{"compatibility", "COMPAT.>", "Verifica compatibilidade: $SR.COMPAT.<subject>", s.compatibility},
+		{"validate", "VALIDATE.>", "Valida dados: $SR.VALIDATE.<subject>", s.validate},
+	}
+
+	group := service.AddGroup(SubjectPrefix)
+	for _, endpoint := range endpoints {
+		err := group.AddEndpoint(endpoint.name, s.endpoint(endpoint.handler),
+			micro.WithEndpointSubject(endpoint.subject),
+			micro.WithEndpointMetadata(map[string]string{"description": endpoint.description}))
+		if err != nil {
+			service.Stop()
+			return fmt.Errorf("failed to add endpoint %s: %w", endpoint.name, err)
+		}
+	}
+
+	s.service = service
+	return nil
+}
+
+// Stop remove as inscrições do serviço
+func (s *Service) Stop() error {
+	if s.service == nil {
+		return nil
+	}
+	return s.service.Stop()
+}
+
+// endpoint adapta handlerFunc ao micro.Handler, respondendo com o envelope da API HTTP.
+// Erros usam também os headers Nats-Service-Error e Nats-Service-Error-Code (status HTTP).
+func (s *Service) endpoint(fn handlerFunc) micro.Handler {
+	return micro.HandlerFunc(func(req micro.Request) {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		// Autor das alterações (auditoria): principal autenticado ou "nats"
+		actor := "nats"
+		if len(s.authenticators) > 0 {
+			principal, err := s.authenticate(req)
+			if err != nil {
+				respondError(req, err)
+				return
+			}
+			ctx = auth.WithPrincipal(ctx, principal)
+			actor = principal.Name
+		}
+
+		data, err := fn(audit.WithActor(ctx, actor), req)
+		if err != nil {
+			respondError(req, err)
+			return
+		}
+		req.RespondJSON(models.SchemaResponse{Success: true, Data: data})
+	})
+}
+
+// authenticate identifica o principal pelos headers da mensagem
+func (s *Service) authenticate(req micro.Request) (*auth.Principal, error) {
+	r := &http.Request{Method: http.MethodPost, Header: http.Header{}}
+	for key, values := range req.Headers() {
+		for _, value := range values {
+			r.Header.Add(key, value)
+		}
+	}
+
+	principal, err := auth.Authenticate(r, s.authenticators)
+	switch {
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		return nil, fmt.Errorf("%w: invalid credentials", errUnauthorized)
+	case err != nil:
+		log.Printf("Authentication error: %v", err)
+		return nil, errors.New("authentication unavailable")
+	case principal == nil:
+		return nil, fmt.Errorf("%w: authentication required", errUnauthorized)
+	}
+	return principal, nil
+}
+
+// authorize verifica a permissão do principal autenticado; sem autenticação tudo é permitido
+func (s *Service) authorize(ctx context.Context, operation, subject string) error {
+	if s.authorizer == nil {
+		return nil
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if err := s.authorizer.Authorize(principal, operation, subject); err != nil {
+		return fmt.Errorf("%w: %v", errForbidden, err)
+	}
+	return nil
+}
+
+func respondError(req micro.Request, err error) {
+	var status, code int
+	var details map[string]interface{}
+	switch {
+	case errors.Is(err, errBadRequest):
+		status, code = http.StatusBadRequest, models.ErrorCodeBadRequest
+	case errors.Is(err, errUnauthorized):
+		status, code = http.StatusUnauthorized, models.ErrorCodeUnauthorized
+	case errors.Is(err, errForbidden):
+		status, code = http.StatusForbidden, models.ErrorCodeForbidden
+	default:
+		status, code, details = api.ClassifyError(err, http.StatusInternalServerError)
+	}
+
+	body, _ := json.Marshal(models.SchemaResponse{
+		Success:   false,
+		Error:     err.Error(),
+		ErrorCode: code,
+		Details:   details,
+	})
+	req.Error(strconv.Itoa(status), err.Error(), body)
+}
+
+func (s *Service) register(ctx context.Context, req micro.Request) (interface{}, error) {
+	subject := subjectFrom(req, "REGISTER")
+
+	var body dtos.CreateSchemaRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+	if body.Subject != "" && body.Subject != subject {
+		return nil, fmt.Errorf("%w: subject %q does not match %q", errBadRequest, body.Subject, subject)
+	}
+	body.Subject = subject
+	if err := s.authorize(ctx, acl.OpRegister, subject); err != nil {
+		return nil, err
+	}
+
+	model := mappers.MapCreateSchemaRequestToModel(body)
+	registered, err := s.registry.RegisterSchema(ctx, &model)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.MapSchemaToResponse(registered), nil
+}
+
+func (s *Service) get(ctx context.Context, req micro.Request) (interface{}, error) {
+	// O subject pode conter pontos; a versão é sempre o último token
+	subject, versionStr, ok := cutLast(subjectFrom(req, "GET"))
+	if !ok {
+		return nil, fmt.Errorf("%w: expected %s.GET.<subject>.<version>", errBadRequest, SubjectPrefix)
+	}
+	if err := s.authorize(ctx, acl.OpRead, subject); err != nil {
+		return nil, err
+	}
+
+	var body dtos.SchemaLookupRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+
+	var found *models.Schema
+	var err error
+	if versionStr == "latest" {
+		found, err = s.registry.GetLatestSchema(ctx, subject)
+	} else {
+		version, parseErr := strconv.Atoi(versionStr)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w: invalid version %q", errBadRequest, versionStr)
+		}
+		found, err = s.registry.FindSchema(ctx, subject, version, body.Deleted)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mappers.MapSchemaToResponse(found), nil
+}
+
+func (s *Service) getByID(ctx context.Context, req micro.Request) (interface{}, error) {
+	var body dtos.SchemaLookupRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+
+	// Sem permissão de leitura no subject, o ID é tratado como inexistente
+	found, err := s.registry.FindVisibleSchemaByID(ctx, subjectFrom(req, "ID"), body.Deleted,
+		func(subject string) bool {
+			return s.authorize(ctx, acl.OpRead, subject) == nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return mappers.MapSchemaToResponse(found), nil
+}
+
+func (s *Service) listSubjects(ctx context.Context, req micro.Request) (interface{}, error) {
+	var body dtos.SubjectQueryRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+	query, err := subjectQuery(body)
+	if err != nil {
+		return nil, err
+	}
+	// Subjects sem permissão de leitura são omitidos antes do limite, como na API HTTP
+	query.Visible = func(subject string) bool {
+		return s.authorize(ctx, acl.OpRead, subject) == nil
+	}
+
+	page, err := s.registry.QuerySubjects(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Verbose {
+		details := make([]dtos.SubjectDetail, 0, len(page.Subjects))
+		for _, info := range page.Subjects {
+			details = append(details, mappers.MapSubjectInfoToDetail(info))
+		}
+		return dtos.SubjectDetailListResponse{Subjects: details, Total: len(details), NextCursor: page.NextCursor}, nil
+	}
+
+	names := make([]string, 0, len(page.Subjects))
+	for _, info := range page.Subjects {
+		names = append(names, info.Subject)
+	}
+	return dtos.SubjectListResponse{Subjects: names, Total: len(names), NextCursor: page.NextCursor}, nil
+}
+
+func (s *Service) listVersions(ctx context.Context, req micro.Request) (interface{}, error) {
+	subject := subjectFrom(req, "VERSIONS")
+	if err := s.authorize(ctx, acl.OpRead, subject); err != nil {
+		return nil, err
+	}
+
+	var body dtos.VersionQueryRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+	query, err := versionQuery(body)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.registry.QueryVersions(ctx, subject, query)
+	if err != nil {
+		return nil, err
+	}
+	return dtos.VersionListResponse{Subject: subject, Versions: page.Versions, NextCursor: page.NextCursor}, nil
+}
+
+func (s *Service) config(ctx context.Context, req micro.Request) (interface{}, error) {
+	subject := subjectFrom(req, "CONFIG")
+
+	operation := acl.OpConfig
+	if len(req.Data()) == 0 {
+		operation = acl.OpRead
+	}
+	if err := s.authorize(ctx, operation, subject); err != nil {
+		return nil, err
+	}
+
+	if len(req.Data()) == 0 {
+		config, err := s.registry.GetConfig(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+		return mappers.MapSchemaConfigToResponse(config), nil
+	}
+
+	var body dtos.SchemaConfigRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+	config := mappers.MapSchemaConfigRequestToModel(subject, body)
+	if err := s.registry.SetConfig(ctx, &config); err != nil {
+		return nil, err
+	}
+	return mappers.MapSchemaConfigToResponse(&config), nil
+}
+
+func (s *Service) compatibility(ctx context.Context, req micro.Request) (interface{}, error) {
+	var body dtos.CompatibilityCheckRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+
+	schema := mappers.MapCompatibilityCheckRequestToSchema(subjectFrom(req, "COMPAT"), body)
+	if err := s.authorize(ctx, acl.OpRead, schema.Subject); err != nil {
+		return nil, err
+	}
+	result, err := s.registry.CheckCompatibility(ctx, schema.Subject, schema.SchemaType, schema.Schema)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.MapCompatibilityResult(result, false), nil
+}
+
+func (s *Service) validate(ctx context.Context, req micro.Request) (interface{}, error) {
+	var body dtos.ValidateDataRequest
+	if err := decode(req, &body); err != nil {
+		return nil, err
+	}
+
+	validation := mappers.MapValidateDataRequestToModel(subjectFrom(req, "VALIDATE"), body)
+	if err := s.authorize(ctx, acl.OpRead, validation.Subject); err != nil {
+		return nil, err
+	}
+	result, err := s.registry.ValidateData(ctx, &validation)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.MapValidationResult(result), nil
+}
+
+// subjectFrom extrai o restante do subject NATS após $SR.<operação>.
+func subjectFrom(req micro.Request, operation string) string {
+	return strings.TrimPrefix(req.Subject(), SubjectPrefix+"."+operation+".")
+}
+
+// cutLast separa o último token de um subject NATS
+func cutLast(subject string) (string, string, bool) {
+	i := strings.LastIndexByte(subject, '.')
+	if i <= 0 || i == len(subject)-1 {
+		return "", "", false
+	}
+	return subject[:i], subject[i+1:], true
+}
+
+// decode lê o corpo JSON; corpo vazio mantém os valores padrão
+func decode(req micro.Request, v interface{}) error {
+	if len(req.Data()) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(req.Data(), v); err != nil {
+		return fmt.Errorf("%w: invalid request body", errBadRequest)
+	}
+	return nil
+}
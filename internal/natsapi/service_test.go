@@ -0,0 +1,248 @@
+package natsapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/auth"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/natstest"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+)
+
+// newTestConn inicia um NATS embutido com o serviço registrado; configure ajusta o serviço antes de iniciá-lo
+func newTestConn(t *testing.T, configure ...func(*Service)) *nats.Conn {
+	t.Helper()
+
+	env := natstest.Start(t, natstest.Options{Bucket: "schemadb"})
+	nc, kv := env.Conn, env.KV
+
+	storage := schema.NewStorage(kv)
+	registry := schema.NewRegistry(storage, schema.NewValidator(storage), nc)
+
+	service := NewService(registry)
+	for _, fn := range configure {
+		fn(service)
+	}
+	if err := service.Start(nc); err != nil {
+		t.Fatalf("failed to start service: %v", err)
+	}
+	t.Cleanup(func() { service.Stop() })
+	return nc
+}
+
+// envelope resposta no formato da API HTTP
+type envelope struct {
+	Success   bool            `json:"success"`
+	Data      json.RawMessage `json:"data"`
+	Error     string          `json:"error"`
+	ErrorCode int             `json:"error_code"`
+}
+
+func request(t *testing.T, nc *nats.Conn, subject, body string) (*envelope, string) {
+	t.Helper()
+	return requestWithHeader(t, nc, subject, body, nil)
+}
+
+func requestWithHeader(t *testing.T, nc *nats.Conn, subject, body string, header nats.Header) (*envelope, string) {
+	t.Helper()
+
+	msg, err := nc.RequestMsg(&nats.Msg{Subject: subject, Data: []byte(body), Header: header}, 2*time.Second)
+	if err != nil {
+		t.Fatalf("%s: request failed: %v", subject, err)
+	}
+
+	var resp envelope
+	if err := json.Unmarshal(msg.Data, &resp); err != nil {
+		t.Fatalf("%s: invalid envelope %q: %v", subject, msg.Data, err)
+	}
+	return &resp, msg.Header.Get(micro.ErrorCodeHeader)
+}
+
+func TestService(t *testing.T) {
+	nc := newTestConn(t)
+
+	created, _ := request(t, nc, "$SR.REGISTER.orders.created",
+		`{"schema_type":"JSON","schema":{"type":"object","properties":{"id":{"type":"string"}}},"metadata":{"team":"checkout"}}`)
+	if !created.Success {
+		t.Fatalf("register failed: %s", created.Error)
+	}
+	var registered struct {
+		ID      string `json:"id"`
+		Subject string `json:"subject"`
+		Version int    `json:"version"`
+	}
+	json.Unmarshal(created.Data, &registered)
+	if registered.Subject != "orders.created" || registered.Version != 1 {
+		t.Fatalf("unexpected registered schema: %s", created.Data)
+	}
+
+	tests := []struct {
+		name          string
+		subject       string
+		body          string
+		wantSuccess   bool
+		wantErrorCode int
+		wantHeader    string
+	}{
+		{name: "should get latest of dotted subject", subject: "$SR.GET.orders.created.latest", wantSuccess: true},
+		{name: "should get numbered version", subject: "$SR.GET.orders.created.1", wantSuccess: true},
+		{name: "should get by id", subject: "$SR.ID." + registered.ID, wantSuccess: true},
+		{name: "should list subjects", subject: "$SR.SUBJECTS", body: `{"labels":{"team":"checkout"},"verbose":true}`, wantSuccess: true},
+		{name: "should list versions", subject: "$SR.VERSIONS.orders.created", wantSuccess: true},
+		{name: "should set config", subject: "$SR.CONFIG.orders.created", body: `{"compatibility":"FULL"}`, wantSuccess: true},
+		{name: "should get config", subject: "$SR.CONFIG.orders.created", wantSuccess: true},
+		{name: "should check compatibility", subject: "$SR.COMPAT.orders.created", body: `{"schema":{"type":"object"}}`, wantSuccess: true},
+		{name: "should validate data", subject: "$SR.VALIDATE.orders.created", body: `{"data":{"id":"1"}}`, wantSuccess: true},
+		{name: "should return not found for unknown subject", subject: "$SR.GET.missing.latest", wantErrorCode: models.ErrorCodeSubjectNotFound, wantHeader: "404"},
+		{name: "should return not found for unknown version", subject: "$SR.GET.orders.created.9", wantErrorCode: models.ErrorCodeVersionNotFound, wantHeader: "404"},
+		{name: "should reject invalid version", subject: "$SR.GET.orders.created.first", wantErrorCode: models.ErrorCodeBadRequest, wantHeader: "400"},
+		{name: "should reject invalid body", subject: "$SR.SUBJECTS", body: "{", wantErrorCode: models.ErrorCodeBadRequest, wantHeader: "400"},
+		{name: "should reject invalid filter", subject: "$SR.SUBJECTS", body: `{"order":"random"}`, wantErrorCode: models.ErrorCodeBadRequest, wantHeader: "400"},
+		{name: "should reject mismatched subject", subject: "$SR.REGISTER.orders", body: `{"subject":"users","schema_type":"JSON","schema":{}}`, wantErrorCode: models.ErrorCodeBadRequest, wantHeader: "400"},
+		{name: "should reject invalid config", subject: "$SR.CONFIG.orders.created", body: `{"compatibility":"SOMETIMES"}`, wantErrorCode: models.ErrorCodeInvalidConfig, wantHeader: "422"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, header := request(t, nc, tt.subject, tt.body)
+			if resp.Success != tt.wantSuccess {
+				t.Fatalf("success = %v, want %v (%s)", resp.Success, tt.wantSuccess, resp.Error)
+			}
+			if resp.ErrorCode != tt.wantErrorCode {
+				t.Errorf("error_code = %d, want %d", resp.ErrorCode, tt.wantErrorCode)
+			}
+			if header != tt.wantHeader {
+				t.Errorf("%s = %q, want %q", micro.ErrorCodeHeader, header, tt.wantHeader)
+			}
+		})
+	}
+}
+
+// keyAuthenticator aceita as chaves "writer" e "reader" no header X-API-Key
+type keyAuthenticator struct{}
+
+func (keyAuthenticator) Authenticate(r *http.Request) (*auth.Principal, error) {
+	switch key := r.Header.Get("X-API-Key"); key {
+	case "":
+		return nil, nil
+	case "writer", "reader":
+		return &auth.Principal{Name: key, Method: auth.MethodAPIKey}, nil
+	case "outage":
+		return nil, errors.New("failed to lookup api key: kv unavailable")
+	default:
+		return nil, auth.ErrInvalidCredentials
+	}
+}
+
+// roleAuthorizer permite tudo ao writer e apenas leitura de orders.* ao reader
+type roleAuthorizer struct{}
+
+func (roleAuthorizer) Authorize(principal *auth.Principal, operation, subject string) error {
+	if principal.Name == "writer" || (operation == acl.OpRead && strings.HasPrefix(subject, "orders.")) {
+		return nil
+	}
+	return fmt.Errorf("%s may not %s %s", principal.Name, operation, subject)
+}
+
+func TestServiceAuth(t *testing.T) {
+	nc := newTestConn(t, func(s *Service) {
+		s.SetAuthenticators([]auth.Authenticator{keyAuthenticator{}})
+		s.SetAuthorizer(roleAuthorizer{})
+	})
+
+	withKey := func(key string) nats.Header {
+		if key == "" {
+			return nil
+		}
+		return nats.Header{"X-API-Key": []string{key}}
+	}
+	ids := make(map[string]string)
+	for _, subject := range []string{"orders.created", "payments.settled"} {
+		resp, _ := requestWithHeader(t, nc, "$SR.REGISTER."+subject, `{"schema_type":"JSON","schema":{"type":"object"}}`, withKey("writer"))
+		if !resp.Success {
+			t.Fatalf("register %s failed: %s", subject, resp.Error)
+		}
+		var registered struct {
+			ID string `json:"id"`
+		}
+		json.Unmarshal(resp.Data, &registered)
+		ids[subject] = registered.ID
+	}
+
+	tests := []struct {
+		name       string
+		subject    string
+		body       string
+		key        string
+		wantHeader string
+	}{
+		{name: "should require credentials", subject: "$SR.GET.orders.created.latest", wantHeader: "401"},
+		{name: "should reject invalid key", subject: "$SR.GET.orders.created.latest", key: "wrong", wantHeader: "401"},
+		{name: "should report authenticator failures as server errors", subject: "$SR.GET.orders.created.latest", key: "outage", wantHeader: "500"},
+		{name: "should allow permitted reads", subject: "$SR.GET.orders.created.latest", key: "reader"},
+		{name: "should forbid reads outside the acl", subject: "$SR.GET.payments.settled.latest", key: "reader", wantHeader: "403"},
+		{name: "should allow permitted reads by id", subject: "$SR.ID." + ids["orders.created"], key: "reader"},
+		{name: "should hide ids outside the acl as not found", subject: "$SR.ID." + ids["payments.settled"], key: "reader", wantHeader: "404"},
+		{name: "should return not found for unknown ids", subject: "$SR.ID.999999", key: "reader", wantHeader: "404"},
+		{name: "should forbid writes without permission", subject: "$SR.REGISTER.orders.created", body: `{"schema_type":"JSON","schema":{"type":"object"}}`, key: "reader", wantHeader: "403"},
+		{name: "should forbid config changes without permission", subject: "$SR.CONFIG.orders.created", body: `{"compatibility":"NONE"}`, key: "reader", wantHeader: "403"},
+		{name: "should allow writes with permission", subject: "$SR.CONFIG.orders.created", body: `{"compatibility":"NONE"}`, key: "writer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, header := requestWithHeader(t, nc, tt.subject, tt.body, withKey(tt.key))
+			if header != tt.wantHeader {
+				t.Errorf("%s = %q, want %q (%s)", micro.ErrorCodeHeader, header, tt.wantHeader, resp.Error)
+			}
+		})
+	}
+
+	// A listagem omite os subjects que o principal não pode ler
+	resp, _ := requestWithHeader(t, nc, "$SR.SUBJECTS", "", withKey("reader"))
+	var list struct {
+		Subjects []string `json:"subjects"`
+	}
+	json.Unmarshal(resp.Data, &list)
+	if len(list.Subjects) != 1 || list.Subjects[0] != "orders.created" {
+		t.Errorf("subjects = %v, want [orders.created]", list.Subjects)
+	}
+}
+
+func TestServiceDiscovery(t *testing.T) {
+	nc := newTestConn(t)
+
+	subject, err := micro.ControlSubject(micro.InfoVerb, ServiceName, "")
+	if err != nil {
+		t.Fatalf("failed to build control subject: %v", err)
+	}
+	msg, err := nc.Request(subject, nil, 2*time.Second)
+	if err != nil {
+		t.Fatalf("info request failed: %v", err)
+	}
+
+	var info micro.Info
+	if err := json.Unmarshal(msg.Data, &info); err != nil {
+		t.Fatalf("invalid info: %v", err)
+	}
+
+	subjects := map[string]bool{}
+	for _, endpoint := range info.Endpoints {
+		subjects[endpoint.Subject] = true
+	}
+	for _, want := range []string{"$SR.REGISTER.>", "$SR.GET.>", "$SR.ID.>", "$SR.SUBJECTS"} {
+		if !subjects[want] {
+			t.Errorf("endpoint %s not advertised, got %v", want, subjects)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package natstest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// Options recursos do servidor de teste
+type Options struct {
+	// Server ajusta as opções do servidor antes de iniciá-lo (ex: autorização)
+	Server func(*server.Options)
+	// Connect opções da conexão retornada em Env.Conn
+	Connect []nats.Option
+	// Streams criados após a conexão, na ordem informada
+	Streams []*nats.StreamConfig
+	// Bucket bucket KV criado após os streams (vazio = nenhum)
+	Bucket string
+}
+
+// Env servidor embutido e conexão com JetStream
+type Env struct {
+	Server *server.Server
+	Conn   *nats.Conn
+	JS     nats.JetStreamContext
+	// KV bucket de Options.Bucket
+	KV nats.KeyValue
+}
+
+// Start inicia um NATS embutido com JetStream em diretório temporário, conecta e cria os
+// streams e o bucket pedidos; servidor e conexão são encerrados ao fim do teste
+func Start(t testing.TB, opts Options) *Env {
+	t.Helper()
+
+	serverOpts := &server.Options{JetStream: true, StoreDir: t.TempDir(), Port: -1}
+	if opts.Server != nil {
+		opts.Server(serverOpts)
+	}
+	ns, err := server.NewServer(serverOpts)
+	if err != nil {
+		t.Fatalf("failed to create nats server: %v", err)
+	}
+	go ns.Start()
+	t.Cleanup(ns.Shutdown)
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats server not ready")
+	}
+
+	nc, err := nats.Connect(ns.ClientURL(), opts.Connect...)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatalf("failed to get jetstream: %v", err)
+	}
+	for _, config := range opts.Streams {
+		if _, err := js.AddStream(config); err != nil {
+			t.Fatalf("failed to create stream %s: %v", config.Name, err)
+		}
+	}
+
+	env := &Env{Server: ns, Conn: nc, JS: js}
+	if opts.Bucket != "" {
+		if env.KV, err = js.CreateKeyValue(&nats.KeyValueConfig{Bucket: opts.Bucket}); err != nil {
+			t.Fatalf("failed to create kv bucket: %v", err)
+		}
+	}
+	return env
+}
@@ -0,0 +1,253 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// DefaultBatchConcurrency subjects processados em paralelo por lote
+const DefaultBatchConcurrency = 8
+
+// BatchResult resultado de um item de uma operação em lote
+type BatchResult struct {
+	Schema        *models.Schema
+	Compatibility *models.SchemaValidationResult
+	Err           error
+}
+
+// VersionRef versão a ser obtida em lote (Version 0 = última versão)
+type VersionRef struct {
+	Subject string
+	Version int
+}
+
+// SetBatchConcurrency define quantos subjects são processados em paralelo nas operações em lote
+func (r *Registry) SetBatchConcurrency(concurrency int) {
+	r.batchConcurrency = concurrency
+}
+
+// RegisterBatch registra vários schemas. Itens do mesmo subject são registrados em ordem;
+// subjects diferentes são processados em paralelo. Com atomic, o lote inteiro é verificado
+// antes de qualquer gravação; se uma gravação ainda assim falhar (ex.: um registro concorrente
+// tomou a versão e o item deixou de ser compatível), os registros já feitos são desfeitos e os
+// demais itens retornam ErrBatchAborted.
+func (r *Registry) RegisterBatch(ctx context.Context, schemas []*models.Schema, atomic bool) []BatchResult {
+	results := make([]BatchResult, len(schemas))
+
+	if atomic && !r.preflightBatch(ctx, schemas, results) {
+		return results
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	groups := groupBySubject(schemas)
+	created := make([]bool, len(schemas))
+	var failed sync.Once
+	r.forEach(runCtx, len(groups), func(g int) {
+		for _, i := range groups[g] {
+			if runCtx.Err() != nil {
+				results[i].Err = batchAborted(schemas[i].Subject, "not processed")
+				continue
+			}
+			results[i].Schema, created[i], results[i].Err = r.registerSchema(runCtx, schemas[i])
+			if results[i].Err != nil && atomic {
+				failed.Do(cancel)
+			}
+		}
+	})
+
+	if atomic && runCtx.Err() != nil {
+		// O rollback roda mesmo que a requisição tenha sido cancelada
+		r.rollbackBatch(context.WithoutCancel(ctx), results, created)
+	}
+	return results
+}
+
+// preflightBatch verifica todos os itens antes de gravar qualquer um: modo, conteúdo,
+// referências, limite de versões e compatibilidade. Em cada subject os itens são avaliados
+// em ordem, cada um contra o anterior do lote, como se já tivesse sido registrado.
+func (r *Registry) preflightBatch(ctx context.Context, schemas []*models.Schema, results []BatchResult) bool {
+	ok := true
+	for _, group := range groupBySubject(schemas) {
+		subject := schemas[group[0]].Subject
+		previous, count, compatibility, err := r.batchBaseline(ctx, subject)
+		for _, i := range group {
+			if err != nil {
+				results[i].Err = err
+				ok = false
+				continue
+			}
+
+			existing, dupErr := r.findDuplicate(ctx, schemas[i])
+			if dupErr == nil && existing != nil {
+				// Resolve para a versão existente: nada será gravado
+				continue
+			}
+			if itemErr := r.preflightItem(ctx, schemas[i], dupErr, previous, count, compatibility); itemErr != nil {
+				results[i].Err = itemErr
+				ok = false
+				continue
+			}
+			previous = schemas[i]
+			count++
+		}
+	}
+	if ok {
+		return true
+	}
+
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = batchAborted(schemas[i].Subject, "other items failed validation")
+		}
+	}
+	return false
+}
+
+// batchBaseline estado atual do subject usado na simulação do lote: última versão (nil se não
+// houver), quantidade de versões e nível de compatibilidade configurado
+func (r *Registry) batchBaseline(ctx context.Context, subject string) (*models.Schema, int, string, error) {
+	versions, err := r.storage.GetSchemaVersions(ctx, subject)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("failed to get schema versions: %w", err)
+	}
+	config, err := r.storage.GetConfig(ctx, subject)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("failed to get config: %w", err)
+	}
+
+	previous, err := r.storage.GetLatestSchema(ctx, subject)
+	if errors.Is(err, ErrSubjectNotFound) {
+		return nil, len(versions), config.Compatibility, nil
+	}
+	if err != nil {
+		return nil, 0, "", err
+	}
+	return previous, len(versions), config.Compatibility, nil
+}
+
+// preflightItem verifica um item do lote contra a versão anterior simulada
+func (r *Registry) preflightItem(ctx context.Context, schema *models.Schema, dupErr error, previous *models.Schema, count int, compatibility string) error {
+	if dupErr != nil {
+		return dupErr
+	}
+	if err := r.checkRegistrable(ctx, schema); err != nil {
+		return err
+	}
+	if err := r.checkVersionLimit(ctx, schema.Subject, count); err != nil {
+		return err
+	}
+	if previous == nil {
+		return nil
+	}
+	if result := r.validator.ValidateAgainst(compatibility, schema, previous); !result.Valid {
+		return incompatibleSchema(result)
+	}
+	return nil
+}
+
+// rollbackBatch remove definitivamente os schemas registrados por um lote atômico que falhou.
+// Itens que resolveram para versões já existentes (created falso) não são removidos; itens
+// cuja remoção falhou continuam registrados e são reportados como erro.
+func (r *Registry) rollbackBatch(ctx context.Context, results []BatchResult, created []bool) {
+	for i := range results {
+		registered := results[i].Schema
+		if results[i].Err != nil || registered == nil {
+			continue
+		}
+		if !created[i] {
+			results[i].Schema = nil
+			results[i].Err = batchAborted(registered.Subject, "rolled back")
+			continue
+		}
+
+		events, err := r.deletionEvents(ctx, registered, map[string]interface{}{"rollback": true})
+		if err == nil {
+			err = r.applyWithEvents(ctx, func() error {
+				return r.storage.DeleteSchema(ctx, registered.Subject, registered.Version)
+			}, events...)
+		}
+		if err != nil {
+			log.Printf("Warning: failed to roll back %s version %d: %v", registered.Subject, registered.Version, err)
+			results[i].Err = fmt.Errorf("batch aborted but version %d of %s could not be rolled back: %w",
+				registered.Version, registered.Subject, err)
+			continue
+		}
+		r.recordAudit(ctx, models.AuditSchemaDeleted, registered.Subject, registered.Version, registered, nil)
+
+		results[i].Schema = nil
+		results[i].Err = batchAborted(registered.Subject, "rolled back")
+	}
+}
+
+// GetBatch obtém várias versões em paralelo
+func (r *Registry) GetBatch(ctx context.Context, refs []VersionRef) []BatchResult {
+	results := make([]BatchResult, len(refs))
+	r.forEach(ctx, len(refs), func(i int) {
+		if refs[i].Version == 0 {
+			results[i].Schema, results[i].Err = r.GetLatestSchema(ctx, refs[i].Subject)
+			return
+		}
+		results[i].Schema, results[i].Err = r.FindSchema(ctx, refs[i].Subject, refs[i].Version, false)
+	})
+	return results
+}
+
+// CheckCompatibilityBatch verifica vários schemas contra a última versão de seus subjects
+func (r *Registry) CheckCompatibilityBatch(ctx context.Context, schemas []*models.Schema) []BatchResult {
+	results := make([]BatchResult, len(schemas))
+	r.forEach(ctx, len(schemas), func(i int) {
+		results[i].Compatibility, results[i].Err = r.CheckCompatibility(ctx, schemas[i].Subject, schemas[i].SchemaType, schemas[i].Schema)
+	})
+	return results
+}
+
+// forEach executa fn para cada índice com no máximo batchConcurrency chamadas simultâneas
+func (r *Registry) forEach(ctx context.Context, n int, fn func(i int)) {
+	concurrency := r.batchConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// groupBySubject agrupa os índices por subject, preservando a ordem dos itens
+func groupBySubject(schemas []*models.Schema) [][]int {
+	positions := make(map[string]int)
+	var groups [][]int
+	for i, schema := range schemas {
+		g, ok := positions[schema.Subject]
+		if !ok {
+			g = len(groups)
+			positions[schema.Subject] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+func batchAborted(subject, reason string) error {
+	return newError(ErrBatchAborted, map[string]interface{}{"subject": subject},
+		"batch aborted: %s", reason)
+}
@@ -0,0 +1,175 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestGroupBySubject(t *testing.T) {
+	tests := []struct {
+		name     string
+		subjects []string
+		want     [][]int
+	}{
+		{name: "should return no groups for empty batch", want: nil},
+		{name: "should keep distinct subjects apart", subjects: []string{"a", "b", "c"}, want: [][]int{{0}, {1}, {2}}},
+		{name: "should group repeated subjects in order", subjects: []string{"a", "b", "a", "c", "b"}, want: [][]int{{0, 2}, {1, 4}, {3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemas := make([]*models.Schema, len(tt.subjects))
+			for i, subject := range tt.subjects {
+				schemas[i] = &models.Schema{Subject: subject}
+			}
+			if got := groupBySubject(schemas); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupBySubject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForEachBoundsConcurrency(t *testing.T) {
+	r := &Registry{}
+	r.SetBatchConcurrency(3)
+
+	var running, peak int32
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	started := make(chan int, 10)
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		r.forEach(context.Background(), 10, func(i int) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+					break
+				}
+			}
+			started <- i
+			<-release
+			atomic.AddInt32(&running, -1)
+
+			mu.Lock()
+			seen[i] = true
+			mu.Unlock()
+		})
+		close(done)
+	}()
+
+	// Com três chamadas bloqueadas, nenhuma outra pode começar até que sejam liberadas
+	for k := 0; k < 3; k++ {
+		<-started
+	}
+	select {
+	case i := <-started:
+		t.Errorf("item %d started while 3 calls were running", i)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	<-done
+
+	if peak > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", peak)
+	}
+	if len(seen) != 10 {
+		t.Errorf("visited %d items, want 10", len(seen))
+	}
+}
+
+// recordingAuditor conta os registros de auditoria por ação
+type recordingAuditor struct {
+	mu      sync.Mutex
+	actions map[string]int
+}
+
+func (a *recordingAuditor) Record(ctx context.Context, record *models.AuditRecord) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.actions[record.Action]++
+	return nil
+}
+
+func TestRegisterBatchAtomic(t *testing.T) {
+	avro := func(fields string) *models.Schema {
+		return &models.Schema{Subject: "orders", SchemaType: models.SchemaTypeAVRO,
+			Schema: `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}` + fields + `]}`}
+	}
+	withString := `,{"name":"note","type":"string","default":""}`
+	withInt := `,{"name":"note","type":"int","default":0}`
+
+	tests := []struct {
+		name         string
+		batch        []*models.Schema
+		wantErrs     []error
+		wantVersions []int
+		wantAudits   int // registros gravados pelo lote
+	}{
+		{
+			name:         "should register compatible items in order",
+			batch:        []*models.Schema{avro(withString), avro(withString + `,{"name":"total","type":"int","default":0}`)},
+			wantErrs:     []error{nil, nil},
+			wantVersions: []int{1, 2, 3},
+			wantAudits:   2,
+		},
+		{
+			// withInt é compatível com a versão 1, mas não com o item anterior do lote
+			name:         "should check each item against the previous item of the batch",
+			batch:        []*models.Schema{avro(withString), avro(withInt)},
+			wantErrs:     []error{ErrBatchAborted, ErrIncompatible},
+			wantVersions: []int{1},
+		},
+		{
+			name:         "should reject invalid items before writing",
+			batch:        []*models.Schema{avro(withString), {Subject: "payments", SchemaType: models.SchemaTypeAVRO, Schema: "{"}},
+			wantErrs:     []error{ErrBatchAborted, ErrInvalidSchema},
+			wantVersions: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			nc, kv := newTestKV(t)
+			storage := NewStorage(kv)
+			registry := NewRegistry(storage, NewValidator(storage), nc)
+			if _, err := registry.RegisterSchema(ctx, avro("")); err != nil {
+				t.Fatalf("register v1: %v", err)
+			}
+			auditor := &recordingAuditor{actions: make(map[string]int)}
+			registry.SetAuditor(auditor)
+
+			results := registry.RegisterBatch(ctx, tt.batch, true)
+			for i, result := range results {
+				if tt.wantErrs[i] == nil && result.Err != nil {
+					t.Errorf("item %d: unexpected error %v", i, result.Err)
+				}
+				if tt.wantErrs[i] != nil && !errors.Is(result.Err, tt.wantErrs[i]) {
+					t.Errorf("item %d: error = %v, want %v", i, result.Err, tt.wantErrs[i])
+				}
+			}
+
+			versions, err := storage.GetSchemaVersions(ctx, "orders")
+			if err != nil {
+				t.Fatalf("list versions: %v", err)
+			}
+			if !reflect.DeepEqual(versions, tt.wantVersions) {
+				t.Errorf("versions = %v, want %v", versions, tt.wantVersions)
+			}
+			// Lotes rejeitados não gravam nada, nem mesmo registros desfeitos depois
+			if auditor.actions[models.AuditSchemaRegistered] != tt.wantAudits || auditor.actions[models.AuditSchemaDeleted] != 0 {
+				t.Errorf("audit = %v, want %d registrations and no deletions", auditor.actions, tt.wantAudits)
+			}
+		})
+	}
+}
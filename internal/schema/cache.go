@@ -0,0 +1,297 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CacheRequests conta leituras servidas pelo cache, por tipo e resultado (hit/miss)
+var CacheRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "schema_registry_cache_requests_total",
+		Help: "Leituras do storage servidas pelo cache em memória.",
+	},
+	[]string{"kind", "result"},
+)
+
+type cacheEntry struct {
+	value    []byte
+	revision uint64
+	deleted  bool
+}
+
+// rewatchInterval intervalo entre tentativas de restabelecer o watcher encerrado
+var rewatchInterval = time.Second
+
+// Cache mantém uma cópia em memória do bucket KV, atualizada por um watcher
+type Cache struct {
+	mu       sync.RWMutex
+	entries  map[string]cacheEntry
+	versions map[string][]int
+	ready    bool
+	// watcher watcher em uso, substituído quando é restabelecido
+	watcher nats.KeyWatcher
+}
+
+func newCache() *Cache {
+	return &Cache{
+		entries:  make(map[string]cacheEntry),
+		versions: make(map[string][]int),
+	}
+}
+
+// Start carrega o estado inicial do bucket e mantém o cache coerente até ctx ser cancelado.
+// Se o watcher for encerrado, as leituras vão ao KV até que ele seja restabelecido.
+func (c *Cache) Start(ctx context.Context, kv nats.KeyValue) error {
+	watcher, err := c.load(kv)
+	if err != nil {
+		return err
+	}
+
+	go c.follow(ctx, kv, watcher)
+	return nil
+}
+
+// load cria um watcher e substitui o conteúdo do cache pelo estado atual do bucket
+func (c *Cache) load(kv nats.KeyValue) (nats.KeyWatcher, error) {
+	watcher, err := kv.WatchAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch bucket: %w", err)
+	}
+
+	// Valores iniciais terminam com uma entrada nil
+	fresh := newCache()
+	for entry := range watcher.Updates() {
+		if entry == nil {
+			break
+		}
+		fresh.applyEntry(entry)
+	}
+
+	c.mu.Lock()
+	c.entries, c.versions = fresh.entries, fresh.versions
+	c.watcher = watcher
+	c.ready = true
+	c.mu.Unlock()
+	return watcher, nil
+}
+
+// follow aplica as alterações entregues pelo watcher e o restabelece quando encerrado
+func (c *Cache) follow(ctx context.Context, kv nats.KeyValue, watcher nats.KeyWatcher) {
+	for {
+		c.consume(ctx, watcher)
+		watcher.Stop()
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("Warning: cache watcher closed, falling back to KV reads until it is re-established")
+		c.mu.Lock()
+		c.ready = false
+		c.mu.Unlock()
+
+		for watcher = nil; watcher == nil; {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(rewatchInterval):
+			}
+
+			var err error
+			if watcher, err = c.load(kv); err != nil {
+				log.Printf("Warning: failed to re-establish cache watcher: %v", err)
+			}
+		}
+		log.Printf("Cache watcher re-established")
+	}
+}
+
+// consume aplica as alterações até o watcher ser encerrado ou ctx cancelado
+func (c *Cache) consume(ctx context.Context, watcher nats.KeyWatcher) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry, ok := <-watcher.Updates():
+			if !ok {
+				return
+			}
+			if entry != nil {
+				c.applyEntry(entry)
+			}
+		}
+	}
+}
+
+// Ready indica se o cache já carregou o estado inicial
+func (c *Cache) Ready() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ready
+}
+
+func (c *Cache) applyEntry(entry nats.KeyValueEntry) {
+	// Eventos do outbox são transitórios e lidos apenas pelo relay
+	if strings.HasPrefix(entry.Key(), outboxPrefix) {
+		return
+	}
+	deleted := entry.Operation() != nats.KeyValuePut
+	c.apply(entry.Key(), entry.Value(), entry.Revision(), deleted)
+	if deleted {
+		c.prune(entry.Key(), entry.Revision())
+	}
+}
+
+// prune descarta a tombstone da chave. O watcher entrega as revisões em ordem, então depois
+// da remoção confirmada por ele nenhuma revisão anterior da chave chega pelo watcher.
+func (c *Cache) prune(key string, revision uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cur, ok := c.entries[key]; ok && cur.deleted && cur.revision <= revision {
+		delete(c.entries, key)
+	}
+}
+
+// apply registra uma alteração; revisões antigas entregues pelo watcher são ignoradas
+func (c *Cache) apply(key string, value []byte, revision uint64, deleted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cur, ok := c.entries[key]; ok {
+		if revision < cur.revision || (revision == cur.revision && cur.deleted && !deleted) {
+			return
+		}
+	}
+
+	c.entries[key] = cacheEntry{value: value, revision: revision, deleted: deleted}
+
+	if subject, version, ok := parseSchemaKey(key); ok {
+		if deleted {
+			c.removeVersion(subject, version)
+		} else {
+			c.addVersion(subject, version)
+		}
+	}
+}
+
+// remove marca uma chave apagada localmente, antes da confirmação do watcher
+func (c *Cache) remove(key string) {
+	c.mu.RLock()
+	revision := c.entries[key].revision
+	c.mu.RUnlock()
+
+	c.apply(key, nil, revision, true)
+}
+
+func (c *Cache) get(key string) ([]byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.deleted {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *Cache) keys(prefix, suffix string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var keys []string
+	for key, entry := range c.entries {
+		if !entry.deleted && strings.HasPrefix(key, prefix) && strings.HasSuffix(key, suffix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (c *Cache) schemaVersions(subject string) []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	versions := make([]int, len(c.versions[subject]))
+	copy(versions, c.versions[subject])
+	return versions
+}
+
+// latestVersion maior versão do subject presente no bucket, ativa ou não
+func (c *Cache) latestVersion(subject string) (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	versions := c.versions[subject]
+	if len(versions) == 0 {
+		return 0, false
+	}
+	return versions[len(versions)-1], true
+}
+
+// versionIndex cópia das versões de todos os subjects
+func (c *Cache) versionIndex() map[string][]int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	index := make(map[string][]int, len(c.versions))
+	for subject, versions := range c.versions {
+		index[subject] = append([]int(nil), versions...)
+	}
+	return index
+}
+
+func (c *Cache) addVersion(subject string, version int) {
+	versions := c.versions[subject]
+	i := sort.SearchInts(versions, version)
+	if i < len(versions) && versions[i] == version {
+		return
+	}
+	versions = append(versions, 0)
+	copy(versions[i+1:], versions[i:])
+	versions[i] = version
+	c.versions[subject] = versions
+}
+
+func (c *Cache) removeVersion(subject string, version int) {
+	versions := c.versions[subject]
+	i := sort.SearchInts(versions, version)
+	if i == len(versions) || versions[i] != version {
+		return
+	}
+	versions = append(versions[:i], versions[i+1:]...)
+	if len(versions) == 0 {
+		delete(c.versions, subject)
+		return
+	}
+	c.versions[subject] = versions
+}
+
+// parseSchemaKey extrai subject e versão de chaves no formato schemas.<subject>.<version>
+func parseSchemaKey(key string) (string, int, bool) {
+	if !strings.HasPrefix(key, "schemas.") {
+		return "", 0, false
+	}
+	rest := strings.TrimPrefix(key, "schemas.")
+
+	dot := strings.LastIndex(rest, ".")
+	if dot <= 0 {
+		return "", 0, false
+	}
+
+	version, err := strconv.Atoi(rest[dot+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return rest[:dot], version, true
+}
@@ -0,0 +1,194 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestParseSchemaKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		wantSubject string
+		wantVersion int
+		wantOK      bool
+	}{
+		{
+			name:        "should parse simple subject",
+			key:         "schemas.user.1",
+			wantSubject: "user",
+			wantVersion: 1,
+			wantOK:      true,
+		},
+		{
+			name:        "should parse dotted subject",
+			key:         "schemas.payments.order.12",
+			wantSubject: "payments.order",
+			wantVersion: 12,
+			wantOK:      true,
+		},
+		{
+			name:   "should ignore config keys",
+			key:    "subjects.user.config",
+			wantOK: false,
+		},
+		{
+			name:   "should ignore keys without version",
+			key:    "schemas.user",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, version, ok := parseSchemaKey(tt.key)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok=%v, got %v", tt.wantOK, ok)
+			}
+			if subject != tt.wantSubject || version != tt.wantVersion {
+				t.Errorf("expected %s/%d, got %s/%d", tt.wantSubject, tt.wantVersion, subject, version)
+			}
+		})
+	}
+}
+
+func TestCacheApply(t *testing.T) {
+	cache := newCache()
+
+	cache.apply("schemas.user.2", []byte("v2"), 2, false)
+	cache.apply("schemas.user.1", []byte("v1"), 1, false)
+
+	if got := cache.schemaVersions("user"); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("expected versions [1 2], got %v", got)
+	}
+
+	if latest, ok := cache.latestVersion("user"); !ok || latest != 2 {
+		t.Fatalf("expected latest 2, got %d", latest)
+	}
+
+	// Delete local seguido da entrega atrasada do put pelo watcher
+	cache.remove("schemas.user.2")
+	cache.apply("schemas.user.2", []byte("v2"), 2, false)
+
+	if _, ok := cache.get("schemas.user.2"); ok {
+		t.Error("expected stale watcher update to be ignored")
+	}
+
+	if latest, _ := cache.latestVersion("user"); latest != 1 {
+		t.Errorf("expected latest 1 after delete, got %d", latest)
+	}
+
+	cache.apply("schemas.user.2", []byte("v2b"), 3, false)
+	if value, ok := cache.get("schemas.user.2"); !ok || string(value) != "v2b" {
+		t.Errorf("expected newer revision to be applied, got %q", value)
+	}
+
+	// Remoção confirmada pelo watcher descarta a tombstone
+	cache.remove("schemas.user.2")
+	cache.apply("schemas.user.2", nil, 4, true)
+	cache.prune("schemas.user.2", 4)
+	if _, ok := cache.entries["schemas.user.2"]; ok {
+		t.Error("expected tombstone to be pruned")
+	}
+}
+
+// countingKV conta as listagens de chaves do bucket (o watcher do cache usa WatchAll)
+type countingKV struct {
+	nats.KeyValue
+	scans int
+}
+
+func (kv *countingKV) Keys(opts ...nats.WatchOpt) ([]string, error) {
+	kv.scans++
+	return kv.KeyValue.Keys(opts...)
+}
+
+func (kv *countingKV) Watch(keys string, opts ...nats.WatchOpt) (nats.KeyWatcher, error) {
+	kv.scans++
+	return kv.KeyValue.Watch(keys, opts...)
+}
+
+func TestCacheFollowsWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, kv := newTestKV(t)
+	writer := NewStorage(kv)
+	counting := &countingKV{KeyValue: kv}
+	reader := NewStorage(counting)
+	if err := reader.EnableCache(ctx); err != nil {
+		t.Fatalf("enable cache: %v", err)
+	}
+
+	// eventually espera o watcher entregar as alterações feitas por outra instância
+	eventually := func(what string, check func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !check() {
+			if time.Now().After(deadline) {
+				t.Fatalf("cache did not observe %s", what)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	for version := 1; version <= 2; version++ {
+		schema := &models.Schema{Subject: "user", Version: version, SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object"}`}
+		if err := writer.SaveSchema(ctx, schema); err != nil {
+			t.Fatalf("save v%d: %v", version, err)
+		}
+	}
+	eventually("puts", func() bool {
+		latest, err := reader.GetLatestSchema(ctx, "user")
+		return err == nil && latest.Version == 2
+	})
+
+	if err := writer.DeleteSchema(ctx, "user", 2); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	eventually("delete", func() bool {
+		latest, err := reader.GetLatestSchema(ctx, "user")
+		return err == nil && latest.Version == 1
+	})
+	if versions, _ := reader.GetSchemaVersions(ctx, "user"); !reflect.DeepEqual(versions, []int{1}) {
+		t.Errorf("expected versions [1], got %v", versions)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := reader.GetLatestSchema(ctx, "unknown"); !errors.Is(err, ErrSubjectNotFound) {
+			t.Fatalf("expected subject not found, got %v", err)
+		}
+	}
+	if counting.scans != 0 {
+		t.Errorf("expected no key scans with a ready cache, got %d", counting.scans)
+	}
+
+	// Watcher encerrado: o cache é recarregado e volta a acompanhar o bucket
+	rewatchInterval = 10 * time.Millisecond
+	defer func() { rewatchInterval = time.Second }()
+	reader.cache.mu.RLock()
+	watcher := reader.cache.watcher
+	reader.cache.mu.RUnlock()
+	watcher.Stop()
+	eventually("watcher closure", func() bool {
+		reader.cache.mu.RLock()
+		defer reader.cache.mu.RUnlock()
+		return reader.cache.watcher != watcher && reader.cache.ready
+	})
+
+	schema := &models.Schema{Subject: "user", Version: 3, SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object"}`}
+	if err := writer.SaveSchema(ctx, schema); err != nil {
+		t.Fatalf("save v3: %v", err)
+	}
+	eventually("puts after re-establishing the watcher", func() bool {
+		latest, err := reader.GetLatestSchema(ctx, "user")
+		return err == nil && latest.Version == 3
+	})
+}
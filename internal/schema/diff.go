@@ -0,0 +1,450 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// Diff compara duas versões de um schema e classifica cada alteração pelos modos de
+// compatibilidade que ela preserva (from = versão antiga, escrita; to = versão nova, leitura)
+func Diff(from, to *models.Schema) (*models.SchemaDiff, error) {
+	diff := &models.SchemaDiff{
+		Subject:    to.Subject,
+		SchemaType: to.SchemaType,
+		From:       from.Version,
+		To:         to.Version,
+		Changes:    []models.SchemaChange{},
+	}
+
+	if from.SchemaType != to.SchemaType {
+		diff.Changes = append(diff.Changes, models.SchemaChange{
+			Type: models.ChangeSchemaTypeChanged, Path: "$", Old: from.SchemaType, New: to.SchemaType,
+			Compatibility: models.CompatibilityNone,
+		})
+		diff.Compatibility = models.CompatibilityNone
+		return diff, nil
+	}
+
+	oldStructure, err := parseStructure(from.SchemaType, from.Schema)
+	if err != nil {
+		return nil, newError(ErrInvalidSchema, map[string]interface{}{"version": from.Version},
+			"failed to parse version %d: %w", from.Version, err)
+	}
+	newStructure, err := parseStructure(to.SchemaType, to.Schema)
+	if err != nil {
+		return nil, newError(ErrInvalidSchema, map[string]interface{}{"version": to.Version},
+			"failed to parse version %d: %w", to.Version, err)
+	}
+
+	diff.Changes = diffStructures(to.SchemaType, oldStructure, newStructure)
+	diff.Compatibility = combineCompatibility(diff.Changes)
+	return diff, nil
+}
+
+// elementPair elemento da versão antiga e o correspondente da nova (nil quando ausente)
+type elementPair struct {
+	path string
+	o, n *element
+}
+
+// matchElements pareia os elementos das duas versões em ordem de caminho. No Protobuf um campo
+// é identificado pelo número dentro da mensagem: o mesmo número com outro nome é o mesmo campo
+// renomeado, e só os campos sem correspondente por número são pareados pelo nome.
+func matchElements(schemaType string, old, new structure) []elementPair {
+	pendingOld := make(map[string]*element, len(old))
+	for path, o := range old {
+		pendingOld[path] = o
+	}
+	pendingNew := make(map[string]*element, len(new))
+	for path, n := range new {
+		pendingNew[path] = n
+	}
+
+	var pairs []elementPair
+	if schemaType == models.SchemaTypeProtobuf {
+		byNumber := make(map[string]*element)
+		for _, o := range old {
+			if o.kind == kindField {
+				byNumber[protoFieldKey(o)] = o
+			}
+		}
+		for path, n := range new {
+			if n.kind != kindField {
+				continue
+			}
+			if o := byNumber[protoFieldKey(n)]; o != nil {
+				pairs = append(pairs, elementPair{path: path, o: o, n: n})
+				delete(pendingOld, o.path)
+				delete(pendingNew, path)
+			}
+		}
+	}
+
+	for path, o := range pendingOld {
+		pairs = append(pairs, elementPair{path: path, o: o, n: pendingNew[path]})
+		delete(pendingNew, path)
+	}
+	for path, n := range pendingNew {
+		pairs = append(pairs, elementPair{path: path, n: n})
+	}
+
+	// Um caminho pode aparecer duas vezes (campo renomeado para o nome de outro removido);
+	// os pareados por número vêm antes
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].path < pairs[j].path })
+	return pairs
+}
+
+// protoFieldKey identifica o campo Protobuf pela mensagem e pelo número
+func protoFieldKey(e *element) string {
+	message := e.path[:strings.LastIndexByte(e.path, '.')]
+	return message + "#" + strconv.Itoa(e.number)
+}
+
+// fieldName último segmento do caminho
+func fieldName(path string) string {
+	return path[strings.LastIndexByte(path, '.')+1:]
+}
+
+// diffStructures lista as alterações em ordem de caminho
+func diffStructures(schemaType string, old, new structure) []models.SchemaChange {
+	changes := []models.SchemaChange{}
+	add := func(changeType, path string, oldValue, newValue interface{}, o, n *element) {
+		changes = append(changes, models.SchemaChange{
+			Type: changeType, Path: path, Old: oldValue, New: newValue,
+			Compatibility: classifyChange(schemaType, changeType, o, n),
+		})
+	}
+
+	for _, pair := range matchElements(schemaType, old, new) {
+		path, o, n := pair.path, pair.o, pair.n
+		switch {
+		case n == nil:
+			add(removedChange(o.kind), path, describe(o), nil, o, nil)
+		case o == nil:
+			add(addedChange(n.kind), path, nil, describe(n), nil, n)
+		default:
+			if o.path != n.path {
+				add(models.ChangeFieldRenamed, path, fieldName(o.path), fieldName(n.path), o, n)
+			}
+			if o.typ != n.typ {
+				add(models.ChangeFieldTypeChanged, path, o.typ, n.typ, o, n)
+			}
+			if o.number != n.number {
+				changeType := models.ChangeFieldNumberChanged
+				if o.kind == kindEnumValue {
+					changeType = models.ChangeEnumNumberChanged
+				}
+				add(changeType, path, o.number, n.number, o, n)
+			}
+			if !bytes.Equal(o.def, n.def) {
+				add(models.ChangeDefaultChanged, path, rawOrNil(o.def), rawOrNil(n.def), o, n)
+			}
+			// No Avro a obrigatoriedade decorre do default, já coberto acima
+			if o.required != n.required && schemaType != models.SchemaTypeAVRO {
+				if n.required {
+					add(models.ChangeRequiredAdded, path, nil, nil, o, n)
+				} else {
+					add(models.ChangeRequiredRemoved, path, nil, nil, o, n)
+				}
+			}
+			for _, symbol := range missing(o.symbols, n.symbols) {
+				add(models.ChangeEnumSymbolRemoved, path, symbolValue(schemaType, symbol), nil, o, n)
+			}
+			for _, symbol := range missing(n.symbols, o.symbols) {
+				add(models.ChangeEnumSymbolAdded, path, nil, symbolValue(schemaType, symbol), o, n)
+			}
+		}
+	}
+	return changes
+}
+
+func addedChange(kind string) string {
+	switch kind {
+	case kindField:
+		return models.ChangeFieldAdded
+	case kindEnumValue:
+		return models.ChangeEnumSymbolAdded
+	default:
+		return models.ChangeTypeAdded
+	}
+}
+
+func removedChange(kind string) string {
+	switch kind {
+	case kindField:
+		return models.ChangeFieldRemoved
+	case kindEnumValue:
+		return models.ChangeEnumSymbolRemoved
+	default:
+		return models.ChangeTypeRemoved
+	}
+}
+
+// describe valor exibido para elementos adicionados ou removidos
+func describe(e *element) interface{} {
+	if e.kind == kindEnumValue {
+		return e.number
+	}
+	return e.typ
+}
+
+func rawOrNil(raw json.RawMessage) interface{} {
+	if raw == nil {
+		return nil
+	}
+	return raw
+}
+
+// symbolValue símbolos de enum do JSON Schema são valores JSON; no Avro, nomes
+func symbolValue(schemaType, symbol string) interface{} {
+	if schemaType == models.SchemaTypeJSON {
+		return json.RawMessage(symbol)
+	}
+	return symbol
+}
+
+// missing itens de a ausentes em b, na ordem de a
+func missing(a, b []string) []string {
+	present := make(map[string]bool, len(b))
+	for _, item := range b {
+		present[item] = true
+	}
+	var result []string
+	for _, item := range a {
+		if !present[item] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// compatibility modos preservados a partir das direções de leitura
+func compatibility(backward, forward bool) string {
+	switch {
+	case backward && forward:
+		return models.CompatibilityFull
+	case backward:
+		return models.CompatibilityBackward
+	case forward:
+		return models.CompatibilityForward
+	default:
+		return models.CompatibilityNone
+	}
+}
+
+// combineCompatibility modos preservados por todas as alterações
+func combineCompatibility(changes []models.SchemaChange) string {
+	backward, forward := true, true
+	for _, change := range changes {
+		switch change.Compatibility {
+		case models.CompatibilityBackward:
+			forward = false
+		case models.CompatibilityForward:
+			backward = false
+		case models.CompatibilityNone:
+			backward, forward = false, false
+		}
+	}
+	return compatibility(backward, forward)
+}
+
+// classifyChange modos preservados por uma alteração. BACKWARD: a versão nova lê dados da
+// antiga; FORWARD: a versão antiga lê dados da nova.
+func classifyChange(schemaType, changeType string, o, n *element) string {
+	switch schemaType {
+	case models.SchemaTypeAVRO:
+		return classifyAvro(changeType, o, n)
+	case models.SchemaTypeProtobuf:
+		return classifyProtobuf(changeType, o, n)
+	default:
+		return classifyJSON(changeType, o, n)
+	}
+}
+
+func classifyJSON(changeType string, o, n *element) string {
+	switch changeType {
+	case models.ChangeFieldAdded:
+		// Campo novo obrigatório não existe nos dados antigos
+		return compatibility(!n.required, true)
+	case models.ChangeFieldRemoved:
+		return compatibility(true, !o.required)
+	case models.ChangeFieldTypeChanged:
+		return compatibility(jsonTypeAccepts(n.typ, o.typ), jsonTypeAccepts(o.typ, n.typ))
+	case models.ChangeRequiredAdded:
+		return models.CompatibilityForward
+	case models.ChangeRequiredRemoved:
+		return models.CompatibilityBackward
+	case models.ChangeEnumSymbolAdded:
+		// Sem enum antes, a restrição nova estreita os valores aceitos
+		if len(o.symbols) == 0 {
+			return models.CompatibilityForward
+		}
+		return models.CompatibilityBackward
+	case models.ChangeEnumSymbolRemoved:
+		if len(n.symbols) == 0 {
+			return models.CompatibilityBackward
+		}
+		return models.CompatibilityForward
+	default:
+		return models.CompatibilityFull
+	}
+}
+
+// jsonTypeAccepts indica se o tipo reader aceita todos os valores do tipo writer
+func jsonTypeAccepts(reader, writer string) bool {
+	if reader == "" {
+		return true
+	}
+	if writer == "" {
+		return false
+	}
+	readerTypes := make(map[string]bool)
+	for _, t := range strings.Split(reader, "|") {
+		readerTypes[t] = true
+	}
+	for _, t := range strings.Split(writer, "|") {
+		if !readerTypes[t] && !(t == "integer" && readerTypes["number"]) {
+			return false
+		}
+	}
+	return true
+}
+
+func classifyAvro(changeType string, o, n *element) string {
+	switch changeType {
+	case models.ChangeFieldAdded:
+		// Sem default, a versão nova não lê registros antigos
+		return compatibility(n.def != nil, true)
+	case models.ChangeFieldRemoved:
+		return compatibility(true, o.def != nil)
+	case models.ChangeFieldTypeChanged:
+		return compatibility(avroReads(n.typ, o.typ), avroReads(o.typ, n.typ))
+	case models.ChangeEnumSymbolAdded:
+		// A versão antiga só lê símbolos novos se tiver default
+		return compatibility(true, o.def != nil)
+	case models.ChangeEnumSymbolRemoved:
+		return compatibility(n.def != nil, true)
+	default:
+		return models.CompatibilityFull
+	}
+}
+
+// avroPromotions tipos do writer que cada tipo do reader aceita, além do próprio
+var avroPromotions = map[string][]string{
+	"long":   {"int"},
+	"float":  {"int", "long"},
+	"double": {"int", "long", "float"},
+	"string": {"bytes"},
+	"bytes":  {"string"},
+}
+
+// avroReads indica se dados escritos com writer são lidos com reader (regras de resolução do Avro)
+func avroReads(reader, writer string) bool {
+	if members := splitUnion(writer); len(members) > 1 {
+		for _, member := range members {
+			if !avroReads(reader, member) {
+				return false
+			}
+		}
+		return true
+	}
+	if members := splitUnion(reader); len(members) > 1 {
+		for _, member := range members {
+			if avroReads(member, writer) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if reader == writer {
+		return true
+	}
+	for _, container := range []string{"array<", "map<"} {
+		if strings.HasPrefix(reader, container) && strings.HasPrefix(writer, container) {
+			return avroReads(strings.TrimSuffix(strings.TrimPrefix(reader, container), ">"),
+				strings.TrimSuffix(strings.TrimPrefix(writer, container), ">"))
+		}
+	}
+
+	// logicalType não altera a codificação
+	reader, _, _ = strings.Cut(reader, ":")
+	writer, _, _ = strings.Cut(writer, ":")
+	if reader == writer {
+		return true
+	}
+	for _, promoted := range avroPromotions[reader] {
+		if promoted == writer {
+			return true
+		}
+	}
+	return false
+}
+
+// splitUnion membros de uma união (separados por | fora de array<> e map<>)
+func splitUnion(typ string) []string {
+	var members []string
+	depth, start := 0, 0
+	for i, r := range typ {
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case '|':
+			if depth == 0 {
+				members = append(members, typ[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(members, typ[start:])
+}
+
+func classifyProtobuf(changeType string, o, n *element) string {
+	switch changeType {
+	case models.ChangeFieldAdded:
+		return compatibility(!n.required, true)
+	case models.ChangeFieldRemoved:
+		return compatibility(true, !o.required)
+	case models.ChangeRequiredAdded:
+		return models.CompatibilityForward
+	case models.ChangeRequiredRemoved:
+		return models.CompatibilityBackward
+	case models.ChangeFieldTypeChanged:
+		if protoWireGroup(o.typ) != "" && protoWireGroup(o.typ) == protoWireGroup(n.typ) {
+			return models.CompatibilityFull
+		}
+		return models.CompatibilityNone
+	case models.ChangeFieldNumberChanged, models.ChangeEnumNumberChanged:
+		return models.CompatibilityNone
+	default:
+		// O wire format só usa o número, então renomear não afeta a leitura; campos e valores de
+		// enum desconhecidos são preservados
+		return models.CompatibilityFull
+	}
+}
+
+// protoWireGroups tipos escalares com a mesma codificação no wire format
+var protoWireGroups = map[string]string{
+	"int32": "varint", "uint32": "varint", "int64": "varint", "uint64": "varint", "bool": "varint",
+	"sint32": "zigzag", "sint64": "zigzag",
+	"fixed32": "fixed32", "sfixed32": "fixed32",
+	"fixed64": "fixed64", "sfixed64": "fixed64",
+	"string": "bytes", "bytes": "bytes",
+}
+
+func protoWireGroup(typ string) string {
+	if rest, ok := strings.CutPrefix(typ, "repeated "); ok {
+		if group := protoWireGroups[rest]; group != "" {
+			return "repeated " + group
+		}
+		return ""
+	}
+	return protoWireGroups[typ]
+}
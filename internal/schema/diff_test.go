@@ -0,0 +1,216 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// change resumo de uma alteração para comparação nos testes
+type change struct {
+	Type, Path, Compatibility string
+}
+
+func summarize(changes []models.SchemaChange) []change {
+	result := []change{}
+	for _, c := range changes {
+		result = append(result, change{c.Type, c.Path, c.Compatibility})
+	}
+	return result
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name        string
+		schemaType  string
+		from, to    string
+		wantChanges []change
+		wantCompat  string
+	}{
+		{
+			name:       "should report no changes for reordered json keys",
+			schemaType: models.SchemaTypeJSON,
+			from:       `{"type":"object","properties":{"id":{"type":"string"},"name":{"type":"string"}}}`,
+			to:         `{"properties":{"name":{"type":"string"},"id":{"type":"string"}},"type":"object"}`,
+			wantCompat: models.CompatibilityFull,
+		},
+		{
+			name:       "should classify json field and required changes",
+			schemaType: models.SchemaTypeJSON,
+			from:       `{"type":"object","properties":{"id":{"type":"integer"},"status":{"enum":["new","paid"]},"note":{"type":"string"}},"required":["id"]}`,
+			to:         `{"type":"object","properties":{"id":{"type":"number"},"status":{"enum":["new","paid","refunded"]},"email":{"type":"string","default":""}},"required":["id","email"]}`,
+			wantChanges: []change{
+				{models.ChangeFieldAdded, "$.email", models.CompatibilityForward},
+				{models.ChangeFieldTypeChanged, "$.id", models.CompatibilityBackward},
+				{models.ChangeFieldRemoved, "$.note", models.CompatibilityFull},
+				{models.ChangeEnumSymbolAdded, "$.status", models.CompatibilityBackward},
+			},
+			wantCompat: models.CompatibilityNone,
+		},
+		{
+			name:       "should classify avro fields by default and type promotion",
+			schemaType: models.SchemaTypeAVRO,
+			from: `{"type":"record","name":"Order","namespace":"com.acme","fields":[
+				{"name":"id","type":"string"},{"name":"amount","type":"int"},
+				{"name":"status","type":{"type":"enum","name":"Status","symbols":["NEW","PAID"]}}]}`,
+			to: `{"type":"record","name":"Order","namespace":"com.acme","fields":[
+				{"name":"id","type":"string"},{"name":"amount","type":"long"},
+				{"name":"status","type":{"type":"enum","name":"Status","symbols":["NEW","PAID","REFUNDED"]}},
+				{"name":"note","type":["null","string"],"default":null}]}`,
+			wantChanges: []change{
+				{models.ChangeFieldTypeChanged, "com.acme.Order.amount", models.CompatibilityBackward},
+				{models.ChangeFieldAdded, "com.acme.Order.note", models.CompatibilityFull},
+				{models.ChangeEnumSymbolAdded, "com.acme.Status", models.CompatibilityBackward},
+			},
+			wantCompat: models.CompatibilityBackward,
+		},
+		{
+			name:       "should flag avro field added without default",
+			schemaType: models.SchemaTypeAVRO,
+			from:       `{"type":"record","name":"User","fields":[{"name":"id","type":"string"}]}`,
+			to:         `{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"email","type":"string"}]}`,
+			wantChanges: []change{
+				{models.ChangeFieldAdded, "User.email", models.CompatibilityForward},
+			},
+			wantCompat: models.CompatibilityForward,
+		},
+		{
+			name:       "should report protobuf field numbers and wire types",
+			schemaType: models.SchemaTypeProtobuf,
+			from: `syntax = "proto3";
+				package shop;
+				// Pedido
+				message Order { string id = 1; int32 quantity = 2; Status status = 3;
+				  enum Status { NEW = 0; PAID = 1; } }`,
+			to: `syntax = "proto3";
+				package shop;
+				message Order {
+				  string id = 1;
+				  int64 quantity = 2 [deprecated = true];
+				  Status status = 4;
+				  repeated string tags = 5;
+				  enum Status { NEW = 0; PAID = 1; REFUNDED = 2; }
+				}`,
+			wantChanges: []change{
+				{models.ChangeEnumSymbolAdded, "shop.Order.Status.REFUNDED", models.CompatibilityFull},
+				{models.ChangeFieldTypeChanged, "shop.Order.quantity", models.CompatibilityFull},
+				{models.ChangeFieldNumberChanged, "shop.Order.status", models.CompatibilityNone},
+				{models.ChangeFieldAdded, "shop.Order.tags", models.CompatibilityFull},
+			},
+			wantCompat: models.CompatibilityNone,
+		},
+		{
+			name:       "should match protobuf fields by number when reused with another wire type",
+			schemaType: models.SchemaTypeProtobuf,
+			from:       `syntax = "proto3"; message Order { string id = 1; string note = 2; }`,
+			to:         `syntax = "proto3"; message Order { string id = 1; int32 count = 2; }`,
+			wantChanges: []change{
+				{models.ChangeFieldRenamed, "Order.count", models.CompatibilityFull},
+				{models.ChangeFieldTypeChanged, "Order.count", models.CompatibilityNone},
+			},
+			wantCompat: models.CompatibilityNone,
+		},
+		{
+			name:       "should report protobuf rename on the same number",
+			schemaType: models.SchemaTypeProtobuf,
+			from:       `syntax = "proto3"; message Order { string id = 1; string note = 2; }`,
+			to:         `syntax = "proto3"; message Order { string id = 1; string comment = 2; string note = 3; }`,
+			wantChanges: []change{
+				{models.ChangeFieldRenamed, "Order.comment", models.CompatibilityFull},
+				{models.ChangeFieldAdded, "Order.note", models.CompatibilityFull},
+			},
+			wantCompat: models.CompatibilityFull,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := &models.Schema{Subject: "orders", Version: 1, SchemaType: tt.schemaType, Schema: tt.from}
+			to := &models.Schema{Subject: "orders", Version: 2, SchemaType: tt.schemaType, Schema: tt.to}
+
+			diff, err := Diff(from, to)
+			if err != nil {
+				t.Fatalf("diff failed: %v", err)
+			}
+
+			got := summarize(diff.Changes)
+			if len(got) != len(tt.wantChanges) {
+				t.Fatalf("changes = %+v, want %+v", got, tt.wantChanges)
+			}
+			for i := range got {
+				if got[i] != tt.wantChanges[i] {
+					t.Errorf("change %d = %+v, want %+v", i, got[i], tt.wantChanges[i])
+				}
+			}
+			if diff.Compatibility != tt.wantCompat {
+				t.Errorf("compatibility = %s, want %s", diff.Compatibility, tt.wantCompat)
+			}
+		})
+	}
+}
+
+func TestCheckRuleRejectsReusedProtobufNumber(t *testing.T) {
+	oldSchema := &models.Schema{Version: 1, SchemaType: models.SchemaTypeProtobuf,
+		Schema: `syntax = "proto3"; message Order { string id = 1; string note = 2; }`}
+	newSchema := &models.Schema{Version: 2, SchemaType: models.SchemaTypeProtobuf,
+		Schema: `syntax = "proto3"; message Order { string id = 1; double total = 2; }`}
+
+	result := checkRule(models.CompatibilityBackward, oldSchema, newSchema)
+	if result.Valid {
+		t.Fatal("expected reused field number with another wire type to break BACKWARD compatibility")
+	}
+	if len(result.Incompatibilities) != 1 || result.Incompatibilities[0].Type != models.ChangeFieldTypeChanged {
+		t.Errorf("incompatibilities = %+v, want one %s", result.Incompatibilities, models.ChangeFieldTypeChanged)
+	}
+}
+
+func TestCheckRuleRejectsUnparseableVersion(t *testing.T) {
+	oldSchema := &models.Schema{Version: 1, SchemaType: models.SchemaTypeAVRO, Schema: `{"type":"record","fields":`}
+	newSchema := &models.Schema{Version: 2, SchemaType: models.SchemaTypeAVRO,
+		Schema: `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`}
+
+	result := checkRule(models.CompatibilityBackward, oldSchema, newSchema)
+	if result.Valid || len(result.Errors) != 1 {
+		t.Fatalf("expected unparseable version to fail the check, got %+v", result)
+	}
+	if !strings.Contains(result.Errors[0], "version 1") {
+		t.Errorf("error = %q, want reference to version 1", result.Errors[0])
+	}
+}
+
+func TestDiffSchemaTypeChanged(t *testing.T) {
+	diff, err := Diff(
+		&models.Schema{SchemaType: models.SchemaTypeJSON, Schema: `{"type":"string"}`},
+		&models.Schema{SchemaType: models.SchemaTypeAVRO, Schema: `{"type":"string"}`},
+	)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Type != models.ChangeSchemaTypeChanged || diff.Compatibility != models.CompatibilityNone {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+}
+
+func TestParseStructureErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		content    string
+	}{
+		{name: "should reject avro record without name", schemaType: models.SchemaTypeAVRO, content: `{"type":"record","fields":[]}`},
+		{name: "should reject avro field without name", schemaType: models.SchemaTypeAVRO, content: `{"type":"record","name":"A","fields":[{"type":"string"}]}`},
+		{name: "should reject protobuf without syntax", schemaType: models.SchemaTypeProtobuf, content: `message A { string id = 1; }`},
+		{name: "should reject protobuf field without number", schemaType: models.SchemaTypeProtobuf, content: `syntax = "proto3"; message A { string id; }`},
+		{name: "should reject unbalanced protobuf message", schemaType: models.SchemaTypeProtobuf, content: `syntax = "proto3"; message A { string id = 1;`},
+		{name: "should reject invalid json", schemaType: models.SchemaTypeJSON, content: `{"type":`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseStructure(tt.schemaType, tt.content); err == nil {
+				t.Error("expected parse error")
+			}
+		})
+	}
+}
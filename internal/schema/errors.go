@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Erros sentinela do registry; use errors.Is para identificá-los
+var (
+	ErrSubjectNotFound       = errors.New("subject not found")
+	ErrVersionNotFound       = errors.New("version not found")
+	ErrSchemaNotFound        = errors.New("schema not found")
+	ErrInvalidSchema         = errors.New("invalid schema")
+	ErrIncompatible          = errors.New("incompatible schema")
+	ErrConflict              = errors.New("conflict")
+	ErrInvalidConfig         = errors.New("invalid config")
+	ErrInvalidMode           = errors.New("invalid mode")
+	ErrOperationNotPermitted = errors.New("operation not permitted")
+	ErrVersionLimit          = errors.New("version limit reached")
+	ErrBatchAborted          = errors.New("batch aborted")
+	ErrPolicyViolation       = errors.New("policy violation")
+)
+
+// RegistryError erro de domínio com mensagem legível e detalhes estruturados.
+// Unwrap expõe o erro sentinela correspondente e a causa, quando formatada com %w.
+type RegistryError struct {
+	Err     error
+	Message string
+	Details map[string]interface{}
+	cause   error
+}
+
+func (e *RegistryError) Error() string {
+	return e.Message
+}
+
+func (e *RegistryError) Unwrap() []error {
+	if e.cause != nil {
+		return []error{e.Err, e.cause}
+	}
+	return []error{e.Err}
+}
+
+func newError(sentinel error, details map[string]interface{}, format string, args ...interface{}) *RegistryError {
+	formatted := fmt.Errorf(format, args...)
+	return &RegistryError{Err: sentinel, Message: formatted.Error(), Details: details, cause: errors.Unwrap(formatted)}
+}
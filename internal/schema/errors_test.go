@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegistryErrorUnwrap(t *testing.T) {
+	cause := errors.New("kv unavailable")
+	wrapped := newError(ErrInvalidConfig, nil, "failed to save: %w", cause)
+	formatted := newError(ErrInvalidConfig, nil, "invalid: %v", cause)
+
+	tests := []struct {
+		name      string
+		err       error
+		target    error
+		wantMatch bool
+	}{
+		{name: "should match sentinel", err: wrapped, target: ErrInvalidConfig, wantMatch: true},
+		{name: "should match cause wrapped with %w", err: wrapped, target: cause, wantMatch: true},
+		{name: "should not match cause formatted with %v", err: formatted, target: cause},
+		{name: "should not match other sentinels", err: wrapped, target: ErrInvalidMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.wantMatch {
+				t.Errorf("errors.Is = %v, want %v", got, tt.wantMatch)
+			}
+		})
+	}
+
+	if wrapped.Error() != "failed to save: kv unavailable" {
+		t.Errorf("unexpected message %q", wrapped.Error())
+	}
+
+}
@@ -10,8 +10,13 @@ type JetStream interface {
 	Publish(subj string, data []byte) error
 }
 
+type Auditor interface {
+	Record(ctx context.Context, record *models.AuditRecord) error
+}
+
 type StorageSchema interface {
 	StorageConfig
+	StorageMode
 	StorageCRUD
 	StorageLatest
 	StorageSubjects
@@ -20,10 +25,18 @@ type StorageSchema interface {
 type StorageConfig interface {
 	SaveConfig(ctx context.Context, config *models.SchemaConfig) error
 	GetConfig(ctx context.Context, subject string) (*models.SchemaConfig, error)
+	ListConfigs(ctx context.Context) ([]*models.SchemaConfig, error)
+}
+
+type StorageMode interface {
+	SaveMode(ctx context.Context, mode *models.SchemaMode) error
+	GetMode(ctx context.Context, subject string) (*models.SchemaMode, error)
+	ListModes(ctx context.Context) ([]*models.SchemaMode, error)
 }
 
 type StorageCRUD interface {
 	SaveSchema(ctx context.Context, schema *models.Schema) error
+	CreateSchema(ctx context.Context, schema *models.Schema) error
 	GetSchema(ctx context.Context, subject string, version int) (*models.Schema, error)
 	DeleteSchema(ctx context.Context, subject string, version int) error
 	GetSchemaByID(ctx context.Context, schemaID string) (*models.Schema, error)
@@ -32,14 +45,18 @@ type StorageCRUD interface {
 type StorageLatest interface {
 	GetLatestSchema(ctx context.Context, subject string) (*models.Schema, error)
 	GetSchemaVersions(ctx context.Context, subject string) ([]int, error)
+	LastVersion(ctx context.Context, subject string) (int, error)
 }
 
 type StorageSubjects interface {
 	ListSubjects(ctx context.Context) ([]string, error)
+	QuerySubjects(ctx context.Context, query models.SubjectQuery) (*models.SubjectPage, error)
 }
 
 type ValidatorSchema interface {
 	ValidateSchema(schema *models.Schema) *models.SchemaValidationResult
 	ValidateCompatibility(ctx context.Context, newSchema *models.Schema) *models.SchemaValidationResult
+	ValidateAgainst(mode string, newSchema *models.Schema, previous ...*models.Schema) *models.SchemaValidationResult
 	ValidateData(ctx context.Context, subject string, version int, data interface{}) *models.SchemaValidationResult
+	ValidateDataAgainst(schema *models.Schema, data interface{}) *models.SchemaValidationResult
 }
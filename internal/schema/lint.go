@@ -0,0 +1,134 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// Convenções de nomes verificadas pelo lint
+var (
+	pascalCase     = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	lowerSnakeCase = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	upperSnakeCase = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	identifier     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	lowerFirst     = regexp.MustCompile(`^[a-z_][A-Za-z0-9_]*$`)
+
+	protoDeclaration = regexp.MustCompile(`^\s*(message|enum)\s+(\w+)`)
+)
+
+// lintSchema verifica convenções de nomes e documentação do formato. Os avisos não impedem o
+// registro; são reportados no dry run. O schema deve ser válido.
+func lintSchema(schema *models.Schema) []string {
+	s, err := parseStructure(schema.SchemaType, schema.Schema)
+	if err != nil {
+		return nil
+	}
+
+	warnings := lintNames(schema.SchemaType, s)
+	switch schema.SchemaType {
+	case models.SchemaTypeAVRO:
+		warnings = append(warnings, lintAvroDocs(schema.Schema)...)
+	case models.SchemaTypeProtobuf:
+		warnings = append(warnings, lintProtobufDocs(schema.Schema)...)
+	default:
+		warnings = append(warnings, lintJSONDocs(schema.Schema)...)
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// lintNames tipos em PascalCase; no Protobuf, campos em lower_snake_case e valores de enum em
+// UPPER_SNAKE_CASE; no Avro e no JSON Schema, campos utilizáveis como identificadores
+func lintNames(schemaType string, s structure) []string {
+	var warnings []string
+	for path, e := range s {
+		name := fieldName(path)
+		switch e.kind {
+		case kindRecord, kindEnum, kindFixed, kindMessage:
+			if !pascalCase.MatchString(name) {
+				warnings = append(warnings, fmt.Sprintf("%s %s should be named in PascalCase", e.kind, path))
+			}
+		case kindEnumValue:
+			if !upperSnakeCase.MatchString(name) {
+				warnings = append(warnings, fmt.Sprintf("enum value %s should be named in UPPER_SNAKE_CASE", path))
+			}
+		case kindField:
+			switch {
+			case strings.HasSuffix(path, "[]"):
+			case schemaType == models.SchemaTypeProtobuf && !lowerSnakeCase.MatchString(name):
+				warnings = append(warnings, fmt.Sprintf("field %s should be named in lower_snake_case", path))
+			case schemaType == models.SchemaTypeAVRO && !lowerFirst.MatchString(name):
+				warnings = append(warnings, fmt.Sprintf("field %s should start with a lowercase letter", path))
+			case schemaType == models.SchemaTypeJSON && !identifier.MatchString(name):
+				warnings = append(warnings, fmt.Sprintf("property %s is not a valid identifier", path))
+			}
+		}
+	}
+	return warnings
+}
+
+// lintAvroDocs records e enums sem doc
+func lintAvroDocs(content string) []string {
+	var root interface{}
+	if err := json.Unmarshal([]byte(content), &root); err != nil {
+		return nil
+	}
+
+	var warnings []string
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch t := node.(type) {
+		case []interface{}:
+			for _, item := range t {
+				walk(item)
+			}
+		case map[string]interface{}:
+			switch t["type"] {
+			case "record", "error", "enum":
+				if doc, _ := t["doc"].(string); doc == "" {
+					warnings = append(warnings, fmt.Sprintf("%s %v has no doc", t["type"], t["name"]))
+				}
+			}
+			for _, value := range t {
+				walk(value)
+			}
+		}
+	}
+	walk(root)
+	return warnings
+}
+
+// lintJSONDocs schema raiz sem title nem description
+func lintJSONDocs(content string) []string {
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &root); err != nil {
+		return nil
+	}
+	if root["title"] == nil && root["description"] == nil {
+		return []string{"schema has no title or description"}
+	}
+	return nil
+}
+
+// lintProtobufDocs mensagens e enums sem comentário na linha anterior
+func lintProtobufDocs(content string) []string {
+	var warnings []string
+	previous := ""
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if match := protoDeclaration.FindStringSubmatch(line); match != nil {
+			if !strings.HasPrefix(previous, "//") && !strings.HasSuffix(previous, "*/") {
+				warnings = append(warnings, fmt.Sprintf("%s %s has no comment", match[1], match[2]))
+			}
+		}
+		if trimmed != "" {
+			previous = trimmed
+		}
+	}
+	return warnings
+}
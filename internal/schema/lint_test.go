@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestLintSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		schema     string
+		want       []string
+	}{
+		{
+			name:       "should accept documented avro with conventional names",
+			schemaType: models.SchemaTypeAVRO,
+			schema:     `{"type":"record","name":"Order","doc":"Pedido","fields":[{"name":"orderId","type":"string"}]}`,
+		},
+		{
+			name:       "should warn about avro names and missing docs",
+			schemaType: models.SchemaTypeAVRO,
+			schema: `{"type":"record","name":"order","fields":[{"name":"Total","type":"int"},
+				{"name":"status","type":{"type":"enum","name":"Status","doc":"Situação","symbols":["NEW"]}}]}`,
+			want: []string{
+				"field order.Total should start with a lowercase letter",
+				"record order has no doc",
+				"record order should be named in PascalCase",
+			},
+		},
+		{
+			name:       "should warn about json property names and missing description",
+			schemaType: models.SchemaTypeJSON,
+			schema:     `{"type":"object","properties":{"first-name":{"type":"string"},"id":{"type":"string"}}}`,
+			want: []string{
+				"property $.first-name is not a valid identifier",
+				"schema has no title or description",
+			},
+		},
+		{
+			name:       "should follow the protobuf style guide",
+			schemaType: models.SchemaTypeProtobuf,
+			schema: `syntax = "proto3";
+// Pedido
+message Order {
+  string orderId = 1;
+  enum status { new = 0; }
+}`,
+			want: []string{
+				"enum Order.status should be named in PascalCase",
+				"enum status has no comment",
+				"enum value Order.status.new should be named in UPPER_SNAKE_CASE",
+				"field Order.orderId should be named in lower_snake_case",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lintSchema(&models.Schema{SchemaType: tt.schemaType, Schema: tt.schema})
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lintSchema() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
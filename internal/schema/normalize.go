@@ -0,0 +1,374 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// Normalize calcula a forma canônica do schema e a guarda em NormalizedSchema, que passa a ser
+// usada no fingerprint e na deduplicação. A definição original é mantida em Schema.
+func Normalize(schema *models.Schema) error {
+	normalized, err := normalizeContent(schema.SchemaType, schema.Schema)
+	if err != nil {
+		return newError(ErrInvalidSchema, map[string]interface{}{"schema_type": schema.SchemaType},
+			"failed to normalize schema: %w", err)
+	}
+	schema.NormalizedSchema = normalized
+	return nil
+}
+
+func normalizeContent(schemaType, content string) (string, error) {
+	switch schemaType {
+	case models.SchemaTypeAVRO:
+		return normalizeAvro(content)
+	case models.SchemaTypeProtobuf:
+		return normalizeProtobuf(content)
+	default:
+		return normalizeJSON(content)
+	}
+}
+
+// contentKey fingerprint usado na deduplicação e no lookup. Com normalize compara as formas
+// canônicas, calculando-a para versões registradas sem normalização.
+func contentKey(schema *models.Schema, normalize bool) string {
+	content := schema.Schema
+	if normalize {
+		if schema.NormalizedSchema != "" {
+			content = schema.NormalizedSchema
+		} else if normalized, err := normalizeContent(schema.SchemaType, schema.Schema); err == nil {
+			content = normalized
+		}
+	}
+	return schema.FingerprintOf(content)
+}
+
+// --- JSON ---
+
+// normalizeJSON serializa sem espaços e com chaves ordenadas, preservando a grafia dos números
+func normalizeJSON(content string) (string, error) {
+	value, err := decodeJSON(content)
+	if err != nil {
+		return "", err
+	}
+	data, err := marshalCanonical(value)
+	return string(data), err
+}
+
+func decodeJSON(content string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected content after JSON value")
+	}
+	return value, nil
+}
+
+func marshalCanonical(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// --- Avro ---
+
+// avroCanonicalAttributes atributos mantidos, na ordem de saída. Os sete primeiros formam a
+// Parsing Canonical Form; logicalType (com precision e scale), default e doc são preservados.
+var avroCanonicalAttributes = []string{"name", "type", "fields", "symbols", "items", "values", "size",
+	"logicalType", "precision", "scale", "default", "doc"}
+
+// avroFieldAttributes atributos mantidos nos campos de um record
+var avroFieldAttributes = []string{"name", "type", "default", "doc"}
+
+// normalizeAvro gera a Parsing Canonical Form: nomes completos sem namespace, primitivos como
+// string, atributos em ordem fixa e sem espaços
+func normalizeAvro(content string) (string, error) {
+	root, err := decodeJSON(content)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := writeAvro(&buf, root, ""); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func writeAvro(buf *bytes.Buffer, node interface{}, namespace string) error {
+	switch t := node.(type) {
+	case string:
+		if !avroPrimitives[t] {
+			t = avroFullName(t, namespace)
+		}
+		return writeCanonical(buf, t)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, member := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeAvro(buf, member, namespace); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case map[string]interface{}:
+		return writeAvroObject(buf, t, namespace)
+	default:
+		return fmt.Errorf("invalid avro type: %v", node)
+	}
+}
+
+func writeAvroObject(buf *bytes.Buffer, node map[string]interface{}, namespace string) error {
+	typeName, ok := node["type"].(string)
+	if !ok {
+		if nested, exists := node["type"]; exists {
+			return writeAvro(buf, nested, namespace)
+		}
+		return fmt.Errorf("avro schema must have 'type' field")
+	}
+	if _, logical := node["logicalType"]; avroPrimitives[typeName] && !logical {
+		return writeCanonical(buf, typeName)
+	}
+
+	fullName := ""
+	switch typeName {
+	case "record", "error", "enum", "fixed":
+		name, _ := node["name"].(string)
+		if name == "" {
+			return fmt.Errorf("avro %s must have a name", typeName)
+		}
+		if ns, ok := node["namespace"].(string); ok && !strings.Contains(name, ".") {
+			namespace = ns
+		}
+		fullName = avroFullName(name, namespace)
+		namespace = ""
+		if i := strings.LastIndex(fullName, "."); i >= 0 {
+			namespace = fullName[:i]
+		}
+	}
+
+	keys := avroCanonicalAttributes
+	if fullName == "" {
+		// Apenas tipos nomeados têm name
+		keys = keys[1:]
+	}
+	return writeAvroAttributes(buf, node, keys, func(key string, value interface{}) error {
+		switch key {
+		case "name":
+			return writeCanonical(buf, fullName)
+		case "type":
+			return writeCanonical(buf, typeName)
+		case "items", "values":
+			return writeAvro(buf, value, namespace)
+		case "fields":
+			fields, ok := value.([]interface{})
+			if !ok {
+				return fmt.Errorf("invalid fields in record %s", fullName)
+			}
+			buf.WriteByte('[')
+			for i, raw := range fields {
+				field, ok := raw.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("invalid field in record %s", fullName)
+				}
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				if err := writeAvroField(buf, field, namespace); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte(']')
+			return nil
+		default:
+			return writeCanonical(buf, value)
+		}
+	})
+}
+
+func writeAvroField(buf *bytes.Buffer, field map[string]interface{}, namespace string) error {
+	return writeAvroAttributes(buf, field, avroFieldAttributes, func(key string, value interface{}) error {
+		if key == "type" {
+			return writeAvro(buf, value, namespace)
+		}
+		return writeCanonical(buf, value)
+	})
+}
+
+// writeAvroAttributes escreve os atributos presentes em node na ordem de keys
+func writeAvroAttributes(buf *bytes.Buffer, node map[string]interface{}, keys []string, write func(key string, value interface{}) error) error {
+	buf.WriteByte('{')
+	first := true
+	for _, key := range keys {
+		value, ok := node[key]
+		if !ok {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		writeCanonical(buf, key)
+		buf.WriteByte(':')
+		if err := write(key, value); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func writeCanonical(buf *bytes.Buffer, value interface{}) error {
+	data, err := marshalCanonical(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// --- Protobuf ---
+
+// normalizeProtobuf reescreve o arquivo a partir dos tokens: sem comentários, com espaçamento e
+// indentação fixos, strings entre aspas duplas e imports e opções de arquivo ordenados
+func normalizeProtobuf(content string) (string, error) {
+	if _, err := parseProtobuf(content); err != nil {
+		return "", err
+	}
+
+	var header, imports, options, body [][]string
+	tokens := tokenizeProto(content)
+	for i := 0; i < len(tokens); {
+		end := protoStatementEnd(tokens, i)
+		statement := tokens[i:end]
+		switch statement[0] {
+		case "syntax", "edition", "package":
+			header = append(header, statement)
+		case "import":
+			imports = append(imports, statement)
+		case "option":
+			options = append(options, statement)
+		default:
+			body = append(body, statement)
+		}
+		i = end
+	}
+
+	sortStatements(imports)
+	sortStatements(options)
+
+	var out strings.Builder
+	for _, group := range [][][]string{header, imports, options, body} {
+		for _, statement := range group {
+			renderProto(&out, statement)
+		}
+	}
+	return out.String(), nil
+}
+
+// protoStatementEnd fim da declaração de topo iniciada em start (após ";" ou o "}" do bloco)
+func protoStatementEnd(tokens []string, start int) int {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case ";":
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(tokens)
+}
+
+func sortStatements(statements [][]string) {
+	sort.SliceStable(statements, func(i, j int) bool {
+		return strings.Join(statements[i], " ") < strings.Join(statements[j], " ")
+	})
+}
+
+// renderProto escreve uma declaração com uma instrução por linha e blocos indentados
+func renderProto(out *strings.Builder, tokens []string) {
+	depth := 0
+	lineStart := true
+	previous := ""
+	for _, token := range tokens {
+		switch token {
+		case "{":
+			out.WriteString(" {\n")
+			depth++
+			lineStart = true
+		case "}":
+			depth--
+			if !lineStart {
+				out.WriteString("\n")
+			}
+			out.WriteString(strings.Repeat("  ", depth) + "}\n")
+			lineStart = true
+		case ";":
+			if lineStart {
+				// ";" isolado após um bloco
+				continue
+			}
+			out.WriteString(";\n")
+			lineStart = true
+		default:
+			if lineStart {
+				out.WriteString(strings.Repeat("  ", depth))
+			} else if protoNeedsSpace(previous, token) {
+				out.WriteString(" ")
+			}
+			out.WriteString(protoQuote(token))
+			lineStart = false
+		}
+		previous = token
+	}
+}
+
+func protoNeedsSpace(previous, token string) bool {
+	if (token == "<" && previous == "map") || (strings.HasPrefix(token, ".") && previous == ")") {
+		return false
+	}
+	switch token {
+	case ",", ")", "]", ">":
+		return false
+	}
+	switch previous {
+	case "(", "[", "<":
+		return false
+	}
+	return true
+}
+
+// protoQuote converte strings entre aspas simples para aspas duplas
+func protoQuote(token string) string {
+	if len(token) < 2 || token[0] != '\'' {
+		return token
+	}
+	inner := token[1 : len(token)-1]
+	inner = strings.ReplaceAll(inner, `\'`, `'`)
+	inner = strings.ReplaceAll(inner, `\"`, `"`)
+	return `"` + strings.ReplaceAll(inner, `"`, `\"`) + `"`
+}
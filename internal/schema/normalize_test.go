@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		a, b       string
+		wantEqual  bool
+	}{
+		{
+			name:       "should ignore json whitespace and key order",
+			schemaType: models.SchemaTypeJSON,
+			a:          `{"type":"object","properties":{"id":{"type":"string"},"n":{"type":"number","maximum":1.50}}}`,
+			b:          "{\n  \"properties\": {\"n\": {\"maximum\": 1.50, \"type\": \"number\"}, \"id\": {\"type\": \"string\"}},\n  \"type\": \"object\"\n}",
+			wantEqual:  true,
+		},
+		{
+			name:       "should keep json array order",
+			schemaType: models.SchemaTypeJSON,
+			a:          `{"enum":["a","b"]}`,
+			b:          `{"enum":["b","a"]}`,
+		},
+		{
+			name:       "should resolve avro namespace inheritance",
+			schemaType: models.SchemaTypeAVRO,
+			a: `{"type":"record","name":"Order","namespace":"com.acme","fields":[
+				{"name":"status","type":{"type":"enum","name":"Status","symbols":["NEW"]}},
+				{"name":"previous","type":["null","Status"],"default":null}]}`,
+			b: `{"namespace":"com.acme","name":"com.acme.Order","type":"record","aliases":["Pedido"],"fields":[
+				{"name":"status","type":{"type":"enum","name":"com.acme.Status","symbols":["NEW"]},"order":"ascending"},
+				{"default":null,"name":"previous","type":["null","com.acme.Status"]}]}`,
+			wantEqual: true,
+		},
+		{
+			name:       "should collapse avro primitive objects",
+			schemaType: models.SchemaTypeAVRO,
+			a:          `{"type":"record","name":"A","fields":[{"name":"id","type":{"type":"string"}}]}`,
+			b:          `{"type":"record","name":"A","fields":[{"name":"id","type":"string"}]}`,
+			wantEqual:  true,
+		},
+		{
+			name:       "should preserve avro docs",
+			schemaType: models.SchemaTypeAVRO,
+			a:          `{"type":"record","name":"A","doc":"v1","fields":[]}`,
+			b:          `{"type":"record","name":"A","doc":"v2","fields":[]}`,
+		},
+		{
+			name:       "should preserve avro logical types",
+			schemaType: models.SchemaTypeAVRO,
+			a:          `{"type":"record","name":"A","fields":[{"name":"at","type":{"type":"long","logicalType":"timestamp-millis"}}]}`,
+			b:          `{"type":"record","name":"A","fields":[{"name":"at","type":"long"}]}`,
+		},
+		{
+			name:       "should ignore protobuf comments, spacing and import order",
+			schemaType: models.SchemaTypeProtobuf,
+			a: `syntax = "proto3";
+				package shop;
+				import "b.proto";
+				import "a.proto";
+				// Pedido
+				message Order { string id = 1; map<string,int32> items = 2 [deprecated=true]; }`,
+			b: `syntax='proto3'; package shop; import "a.proto"; import "b.proto";
+				message Order {
+				  string id = 1; /* chave */
+				  map<string, int32> items = 2 [deprecated = true];
+				}`,
+			wantEqual: true,
+		},
+		{
+			name:       "should keep protobuf field numbers",
+			schemaType: models.SchemaTypeProtobuf,
+			a:          `syntax = "proto3"; message A { string id = 1; }`,
+			b:          `syntax = "proto3"; message A { string id = 2; }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &models.Schema{SchemaType: tt.schemaType, Schema: tt.a}
+			b := &models.Schema{SchemaType: tt.schemaType, Schema: tt.b}
+			if err := Normalize(a); err != nil {
+				t.Fatalf("normalize a: %v", err)
+			}
+			if err := Normalize(b); err != nil {
+				t.Fatalf("normalize b: %v", err)
+			}
+
+			if equal := a.NormalizedSchema == b.NormalizedSchema; equal != tt.wantEqual {
+				t.Errorf("equal = %v, want %v:\n%s\n%s", equal, tt.wantEqual, a.NormalizedSchema, b.NormalizedSchema)
+			}
+			if equal := a.Fingerprint() == b.Fingerprint(); equal != tt.wantEqual {
+				t.Errorf("fingerprints equal = %v, want %v", equal, tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestNormalizeCanonicalForm(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		content    string
+		want       string
+	}{
+		{
+			name:       "should produce avro parsing canonical form",
+			schemaType: models.SchemaTypeAVRO,
+			content:    `{"namespace":"com.acme","type":"record","name":"User","fields":[{"type":{"type":"int"},"name":"age","default":0,"doc":"Idade"}]}`,
+			want:       `{"name":"com.acme.User","type":"record","fields":[{"name":"age","type":"int","default":0,"doc":"Idade"}]}`,
+		},
+		{
+			name:       "should render normalized protobuf text",
+			schemaType: models.SchemaTypeProtobuf,
+			content:    `syntax = "proto3"; import "b.proto"; import "a.proto"; message A { enum S { X = 0; } repeated string tags = 1; }`,
+			want: "syntax = \"proto3\";\nimport \"a.proto\";\nimport \"b.proto\";\n" +
+				"message A {\n  enum S {\n    X = 0;\n  }\n  repeated string tags = 1;\n}\n",
+		},
+		{
+			name:       "should not escape html characters in json",
+			schemaType: models.SchemaTypeJSON,
+			content:    `{ "pattern": "<a&b>" }`,
+			want:       `{"pattern":"<a&b>"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &models.Schema{SchemaType: tt.schemaType, Schema: tt.content}
+			if err := Normalize(schema); err != nil {
+				t.Fatalf("normalize: %v", err)
+			}
+			if schema.NormalizedSchema != tt.want {
+				t.Errorf("normalized =\n%s\nwant\n%s", schema.NormalizedSchema, tt.want)
+			}
+		})
+	}
+}
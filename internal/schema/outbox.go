@@ -0,0 +1,403 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// EventStreamName stream dos eventos de alteração de schema
+	EventStreamName = "SCHEMA_EVENTS"
+	// EventSubjectPrefix prefixo dos subjects NATS dos eventos
+	EventSubjectPrefix = "schema.events."
+
+	// DefaultRelayInterval intervalo entre novas tentativas de publicação
+	DefaultRelayInterval = time.Second
+
+	// outboxPrefix chaves do outbox no bucket KV
+	outboxPrefix = "outbox."
+	// eventDedupWindow janela de deduplicação por Nats-Msg-Id no stream
+	eventDedupWindow = 2 * time.Minute
+	// pendingTimeout tempo após o qual um evento não confirmado é conferido contra o storage
+	pendingTimeout = 30 * time.Second
+)
+
+var (
+	// OutboxEvents eventos processados pelo relay, por resultado (published, failed, discarded)
+	OutboxEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "schema_registry_outbox_events_total",
+			Help: "Eventos de schema processados pelo relay do outbox.",
+		},
+		[]string{"result"},
+	)
+
+	// OutboxPending eventos aguardando publicação
+	OutboxPending = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "schema_registry_outbox_pending",
+			Help: "Eventos de schema aguardando publicação no outbox.",
+		},
+	)
+)
+
+// EventRetention limites de retenção do stream de eventos (zero = ilimitado). Com limites, o
+// replay desde o início deixa de conter o histórico completo.
+type EventRetention struct {
+	MaxAge   time.Duration
+	MaxBytes int64
+	MaxMsgs  int64
+}
+
+// EventStreamConfig configuração do stream de eventos; Nats-Msg-Id deduplica republicações do relay
+func EventStreamConfig(retention EventRetention) *nats.StreamConfig {
+	config := &nats.StreamConfig{
+		Name:       EventStreamName,
+		Subjects:   []string{EventSubjectPrefix + ">"},
+		Storage:    nats.FileStorage,
+		Retention:  nats.LimitsPolicy,
+		Replicas:   1,
+		Duplicates: eventDedupWindow,
+		MaxAge:     retention.MaxAge,
+		MaxBytes:   -1,
+		MaxMsgs:    -1,
+	}
+	if retention.MaxBytes > 0 {
+		config.MaxBytes = retention.MaxBytes
+	}
+	if retention.MaxMsgs > 0 {
+		config.MaxMsgs = retention.MaxMsgs
+	}
+	// A janela de deduplicação não pode exceder a idade máxima das mensagens
+	if retention.MaxAge > 0 && retention.MaxAge < eventDedupWindow {
+		config.Duplicates = retention.MaxAge
+	}
+	return config
+}
+
+// EventPublisher publica um evento com o ID usado na deduplicação (Nats-Msg-Id)
+type EventPublisher interface {
+	PublishEvent(subject string, data []byte, msgID string) error
+}
+
+// outboxEntry evento gravado no KV junto com a alteração que o originou
+type outboxEntry struct {
+	Event models.SchemaEvent `json:"event"`
+	// Sequence ordem de criação, usada para publicar em ordem por subject
+	Sequence int64 `json:"sequence"`
+	// Committed indica que a alteração foi aplicada; entradas não confirmadas são
+	// conferidas contra o storage após pendingTimeout
+	Committed bool `json:"committed"`
+
+	key string
+}
+
+// Outbox grava eventos no bucket KV antes da alteração e os publica em segundo plano
+type Outbox struct {
+	kv nats.KeyValue
+
+	mu       sync.Mutex
+	sequence int64
+}
+
+func NewOutbox(kv nats.KeyValue) *Outbox {
+	return &Outbox{kv: kv}
+}
+
+// nextSequence retorna um valor crescente baseado no relógio
+func (o *Outbox) nextSequence() int64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.sequence = max(o.sequence+1, time.Now().UnixNano())
+	return o.sequence
+}
+
+// stage grava o evento ainda não confirmado
+func (o *Outbox) stage(event *models.SchemaEvent) (*outboxEntry, error) {
+	entry := &outboxEntry{Event: *event, Sequence: o.nextSequence(), key: outboxPrefix + event.ID}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal outbox entry: %w", err)
+	}
+	if _, err := o.kv.Create(entry.key, data); err != nil {
+		return nil, fmt.Errorf("failed to stage event: %w", err)
+	}
+	return entry, nil
+}
+
+// commit marca o evento como pronto para publicação
+func (o *Outbox) commit(entry *outboxEntry) error {
+	entry.Committed = true
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox entry: %w", err)
+	}
+	if _, err := o.kv.Put(entry.key, data); err != nil {
+		return fmt.Errorf("failed to commit event: %w", err)
+	}
+	return nil
+}
+
+// discard remove o evento do outbox, sem manter histórico
+func (o *Outbox) discard(entry *outboxEntry) error {
+	return o.kv.Purge(entry.key)
+}
+
+// Relay publica os eventos do outbox até ctx ser cancelado. Eventos de um mesmo subject são
+// publicados em ordem: uma falha bloqueia os seguintes do subject até a próxima tentativa.
+// applied confere, para eventos não confirmados, se a alteração chegou a ser gravada.
+func (o *Outbox) Relay(ctx context.Context, publisher EventPublisher, applied func(context.Context, *models.SchemaEvent) (bool, error), interval time.Duration) error {
+	watcher, err := o.kv.Watch(outboxPrefix + ">")
+	if err != nil {
+		return fmt.Errorf("failed to watch outbox: %w", err)
+	}
+	defer watcher.Stop()
+
+	if interval <= 0 {
+		interval = DefaultRelayInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	pending := make(map[string]*outboxEntry)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case update, ok := <-watcher.Updates():
+			if !ok {
+				return fmt.Errorf("outbox watcher closed")
+			}
+			// nil marca o fim dos valores iniciais
+			if update != nil {
+				o.track(pending, update)
+			}
+			o.flush(ctx, pending, publisher, applied)
+
+		case <-ticker.C:
+			o.flush(ctx, pending, publisher, applied)
+		}
+	}
+}
+
+// track atualiza o conjunto de eventos pendentes a partir do watcher
+func (o *Outbox) track(pending map[string]*outboxEntry, update nats.KeyValueEntry) {
+	if update.Operation() != nats.KeyValuePut {
+		delete(pending, update.Key())
+		return
+	}
+
+	var entry outboxEntry
+	if err := json.Unmarshal(update.Value(), &entry); err != nil {
+		log.Printf("Warning: discarding invalid outbox entry %s: %v", update.Key(), err)
+		o.kv.Purge(update.Key())
+		return
+	}
+	entry.key = update.Key()
+	pending[entry.key] = &entry
+}
+
+// flush tenta publicar os eventos pendentes em ordem de criação
+func (o *Outbox) flush(ctx context.Context, pending map[string]*outboxEntry, publisher EventPublisher, applied func(context.Context, *models.SchemaEvent) (bool, error)) {
+	defer func() { OutboxPending.Set(float64(len(pending))) }()
+
+	entries := make([]*outboxEntry, 0, len(pending))
+	for _, entry := range pending {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Sequence < entries[j].Sequence })
+
+	blocked := make(map[string]bool)
+	for _, entry := range entries {
+		subject := entry.Event.Subject
+		if blocked[subject] {
+			continue
+		}
+
+		if !entry.Committed {
+			if time.Since(entry.Event.Timestamp) < pendingTimeout {
+				blocked[subject] = true
+				continue
+			}
+			ok, err := applied(ctx, &entry.Event)
+			if err != nil {
+				log.Printf("Warning: failed to reconcile event %s: %v", entry.Event.ID, err)
+				blocked[subject] = true
+				continue
+			}
+			if !ok {
+				// A alteração não foi gravada: o evento não deve ser publicado
+				o.remove(pending, entry, "discarded")
+				continue
+			}
+		}
+
+		data, err := json.Marshal(entry.Event)
+		if err != nil {
+			log.Printf("Warning: discarding event %s: %v", entry.Event.ID, err)
+			o.remove(pending, entry, "discarded")
+			continue
+		}
+		if err := publisher.PublishEvent(EventSubjectPrefix+subject, data, entry.Event.ID); err != nil {
+			log.Printf("Warning: failed to publish event %s for %s: %v", entry.Event.ID, subject, err)
+			OutboxEvents.WithLabelValues("failed").Inc()
+			blocked[subject] = true
+			continue
+		}
+		o.remove(pending, entry, "published")
+	}
+}
+
+// remove tira o evento do outbox; se a remoção falhar ele é republicado e deduplicado pelo stream
+func (o *Outbox) remove(pending map[string]*outboxEntry, entry *outboxEntry, result string) {
+	if err := o.discard(entry); err != nil && !errors.Is(err, nats.ErrKeyNotFound) {
+		log.Printf("Warning: failed to remove outbox entry %s: %v", entry.key, err)
+		return
+	}
+	delete(pending, entry.key)
+	OutboxEvents.WithLabelValues(result).Inc()
+}
+
+// SetOutbox grava os eventos no outbox junto com as alterações; a publicação passa a ser
+// feita por RunEventRelay
+func (r *Registry) SetOutbox(outbox *Outbox) {
+	r.outbox = outbox
+}
+
+// applyWithEvents aplica a alteração e agenda os eventos correspondentes, na ordem informada.
+// Com outbox, os eventos são gravados antes da alteração e confirmados depois dela; sem outbox
+// são publicados diretamente.
+func (r *Registry) applyWithEvents(ctx context.Context, apply func() error, events ...*models.SchemaEvent) error {
+	now := time.Now()
+	for _, event := range events {
+		event.ID = newEventID()
+		event.Timestamp = now
+	}
+
+	if r.outbox == nil {
+		if err := apply(); err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := r.publishSchemaEvent(ctx, event); err != nil {
+				log.Printf("Warning: failed to publish schema event: %v", err)
+			}
+		}
+		return nil
+	}
+
+	entries := make([]*outboxEntry, 0, len(events))
+	discard := func() {
+		for _, entry := range entries {
+			if err := r.outbox.discard(entry); err != nil {
+				log.Printf("Warning: failed to discard event %s: %v", entry.Event.ID, err)
+			}
+		}
+	}
+
+	for _, event := range events {
+		entry, err := r.outbox.stage(event)
+		if err != nil {
+			discard()
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := apply(); err != nil {
+		discard()
+		return err
+	}
+
+	// Se a confirmação falhar, o relay confere a alteração no storage após pendingTimeout
+	for _, entry := range entries {
+		if err := r.outbox.commit(entry); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+	return nil
+}
+
+// eventApplied verifica se a alteração de um evento não confirmado foi gravada
+func (r *Registry) eventApplied(ctx context.Context, event *models.SchemaEvent) (bool, error) {
+	switch event.Type {
+	case models.EventConfigChanged:
+		config, err := r.storage.GetConfig(ctx, event.Subject)
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(eventPayload(config), event.After), nil
+
+	case models.EventModeChanged:
+		mode, err := r.storage.GetMode(ctx, event.Subject)
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(eventPayload(mode), event.After), nil
+
+	case models.EventSubjectDeleted:
+		versions, err := r.storage.GetSchemaVersions(ctx, event.Subject)
+		if err != nil {
+			return false, err
+		}
+		return len(versions) == 0, nil
+	}
+
+	schema, err := r.storage.GetSchema(ctx, event.Subject, event.Version)
+	if errors.Is(err, ErrVersionNotFound) || errors.Is(err, ErrSubjectNotFound) {
+		return event.Type == models.EventSchemaDeleted, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch event.Type {
+	case models.EventSchemaCreated:
+		return schema.ID == event.SchemaID, nil
+	case models.EventSchemaSoftDeleted:
+		return schema.Deleted, nil
+	}
+	return false, nil
+}
+
+// eventPayload serializa o estado antes/depois de uma alteração
+func eventPayload(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// RunEventRelay publica os eventos do outbox até ctx ser cancelado
+func (r *Registry) RunEventRelay(ctx context.Context, publisher EventPublisher, interval time.Duration) error {
+	if r.outbox == nil {
+		return fmt.Errorf("event outbox not configured")
+	}
+	return r.outbox.Relay(ctx, publisher, r.eventApplied, interval)
+}
+
+// newEventID identificador único do evento, usado como Nats-Msg-Id
+func newEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,230 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/natstest"
+
+	"github.com/nats-io/nats.go"
+)
+
+// newTestKV inicia um NATS embutido e retorna a conexão e o bucket do registry
+func newTestKV(t *testing.T) (*nats.Conn, nats.KeyValue) {
+	t.Helper()
+	env := natstest.Start(t, natstest.Options{Bucket: "schemadb"})
+	return env.Conn, env.KV
+}
+
+// recordingPublisher registra os eventos publicados e falha para os subjects em fail
+type recordingPublisher struct {
+	fail      map[string]bool
+	published []string
+}
+
+func (p *recordingPublisher) PublishEvent(subject string, data []byte, msgID string) error {
+	if p.fail[subject] {
+		return errors.New("publish failed")
+	}
+	p.published = append(p.published, msgID)
+	return nil
+}
+
+// stagedEvents grava eventos no outbox e retorna os pendentes como o relay os enxerga
+func stagedEvents(t *testing.T, outbox *Outbox, events []models.SchemaEvent, committed bool) map[string]*outboxEntry {
+	t.Helper()
+
+	pending := make(map[string]*outboxEntry)
+	for i := range events {
+		entry, err := outbox.stage(&events[i])
+		if err != nil {
+			t.Fatalf("stage %s: %v", events[i].ID, err)
+		}
+		if committed {
+			if err := outbox.commit(entry); err != nil {
+				t.Fatalf("commit %s: %v", events[i].ID, err)
+			}
+		}
+		pending[entry.key] = entry
+	}
+	return pending
+}
+
+func TestOutboxFlushOrdering(t *testing.T) {
+	_, kv := newTestKV(t)
+	outbox := NewOutbox(kv)
+
+	now := time.Now()
+	pending := stagedEvents(t, outbox, []models.SchemaEvent{
+		{ID: "orders-1", Subject: "orders", Timestamp: now},
+		{ID: "users-1", Subject: "users", Timestamp: now},
+		{ID: "orders-2", Subject: "orders", Timestamp: now},
+		{ID: "users-2", Subject: "users", Timestamp: now},
+	}, true)
+	applied := func(context.Context, *models.SchemaEvent) (bool, error) { return true, nil }
+
+	// Uma falha em orders bloqueia os eventos seguintes do subject, sem afetar users
+	publisher := &recordingPublisher{fail: map[string]bool{EventSubjectPrefix + "orders": true}}
+	outbox.flush(context.Background(), pending, publisher, applied)
+	if want := []string{"users-1", "users-2"}; !reflect.DeepEqual(publisher.published, want) {
+		t.Fatalf("published %v, want %v", publisher.published, want)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending events, got %d", len(pending))
+	}
+
+	// Na nova tentativa os eventos de orders saem na ordem de criação
+	publisher.fail = nil
+	outbox.flush(context.Background(), pending, publisher, applied)
+	if want := []string{"users-1", "users-2", "orders-1", "orders-2"}; !reflect.DeepEqual(publisher.published, want) {
+		t.Fatalf("published %v, want %v", publisher.published, want)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected empty outbox, got %d events", len(pending))
+	}
+	if keys, _ := kv.Keys(); len(keys) != 0 {
+		t.Errorf("expected purged outbox keys, got %v", keys)
+	}
+}
+
+func TestOutboxFlushUncommitted(t *testing.T) {
+	stale := time.Now().Add(-2 * pendingTimeout)
+
+	tests := []struct {
+		name          string
+		timestamp     time.Time
+		applied       bool
+		appliedErr    error
+		wantPublished bool
+		wantPending   bool
+	}{
+		{name: "should wait for recent uncommitted event", timestamp: time.Now(), applied: true, wantPending: true},
+		{name: "should publish stale event when change was applied", timestamp: stale, applied: true, wantPublished: true},
+		{name: "should discard stale event when change was not applied", timestamp: stale, applied: false},
+		{name: "should retry when reconciliation fails", timestamp: stale, appliedErr: errors.New("storage down"), wantPending: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, kv := newTestKV(t)
+			outbox := NewOutbox(kv)
+			pending := stagedEvents(t, outbox, []models.SchemaEvent{
+				{ID: "orders-1", Subject: "orders", Timestamp: tt.timestamp},
+			}, false)
+
+			publisher := &recordingPublisher{}
+			outbox.flush(context.Background(), pending, publisher, func(context.Context, *models.SchemaEvent) (bool, error) {
+				return tt.applied, tt.appliedErr
+			})
+
+			if published := len(publisher.published) == 1; published != tt.wantPublished {
+				t.Errorf("published = %v, want %v", published, tt.wantPublished)
+			}
+			if stillPending := len(pending) == 1; stillPending != tt.wantPending {
+				t.Errorf("pending = %v, want %v", stillPending, tt.wantPending)
+			}
+		})
+	}
+}
+
+// streamPublisher publica no stream de eventos com Nats-Msg-Id
+type streamPublisher struct {
+	js nats.JetStreamContext
+}
+
+func (p *streamPublisher) PublishEvent(subject string, data []byte, msgID string) error {
+	_, err := p.js.Publish(subject, data, nats.MsgId(msgID))
+	return err
+}
+
+func TestRegistryEventRelay(t *testing.T) {
+	nc, kv := newTestKV(t)
+	js, _ := nc.JetStream()
+	if _, err := js.AddStream(EventStreamConfig(EventRetention{})); err != nil {
+		t.Fatalf("failed to create event stream: %v", err)
+	}
+
+	storage := NewStorage(kv)
+	registry := NewRegistry(storage, NewValidator(storage), nil)
+	registry.SetOutbox(NewOutbox(kv))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	publisher := &streamPublisher{js: js}
+	go registry.RunEventRelay(ctx, publisher, 50*time.Millisecond)
+
+	registered, err := registry.RegisterSchema(ctx, &models.Schema{
+		Subject:    "orders",
+		SchemaType: models.SchemaTypeJSON,
+		Schema:     `{"type":"object"}`,
+	})
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if err := registry.SetConfig(ctx, &models.SchemaConfig{Subject: "orders", Compatibility: models.CompatibilityFull}); err != nil {
+		t.Fatalf("set config failed: %v", err)
+	}
+	if err := registry.DeleteSchema(ctx, "orders", registered.Version, false); err != nil {
+		t.Fatalf("soft delete failed: %v", err)
+	}
+	if err := registry.DeleteSchema(ctx, "orders", registered.Version, true); err != nil {
+		t.Fatalf("permanent delete failed: %v", err)
+	}
+
+	sub, err := js.SubscribeSync(EventSubjectPrefix+"orders", nats.DeliverAll())
+	if err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+
+	wantTypes := []string{
+		models.EventSchemaCreated,
+		models.EventConfigChanged,
+		models.EventSchemaSoftDeleted,
+		models.EventSchemaDeleted,
+		models.EventSubjectDeleted,
+	}
+	var events []models.SchemaEvent
+	for len(events) < len(wantTypes) {
+		msg, err := sub.NextMsg(2 * time.Second)
+		if err != nil {
+			t.Fatalf("expected %d events, got %d: %v", len(wantTypes), len(events), err)
+		}
+		var event models.SchemaEvent
+		json.Unmarshal(msg.Data, &event)
+		events = append(events, event)
+	}
+
+	for i, want := range wantTypes {
+		if events[i].Type != want {
+			t.Errorf("event %d: type = %s, want %s", i, events[i].Type, want)
+		}
+	}
+	if events[0].SchemaID != registered.ID || len(events[0].After) == 0 {
+		t.Errorf("expected created event with schema payload, got %+v", events[0])
+	}
+
+	var before, after models.SchemaConfig
+	json.Unmarshal(events[1].Before, &before)
+	json.Unmarshal(events[1].After, &after)
+	if before.Compatibility != models.CompatibilityBackward || after.Compatibility != models.CompatibilityFull {
+		t.Errorf("unexpected config payloads: before=%s after=%s", events[1].Before, events[1].After)
+	}
+
+	// Republicação do mesmo evento (ex.: falha ao remover do outbox) é deduplicada pelo stream
+	data, _ := json.Marshal(events[0])
+	if err := publisher.PublishEvent(EventSubjectPrefix+"orders", data, events[0].ID); err != nil {
+		t.Fatalf("republish failed: %v", err)
+	}
+	info, err := js.StreamInfo(EventStreamName)
+	if err != nil {
+		t.Fatalf("stream info failed: %v", err)
+	}
+	if info.State.Msgs != uint64(len(wantTypes)) {
+		t.Errorf("expected %d messages after duplicate publish, got %d", len(wantTypes), info.State.Msgs)
+	}
+}
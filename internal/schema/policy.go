@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// Policy regra organizacional avaliada em todo registro, além da validação do formato.
+// Retorna as violações encontradas; qualquer violação rejeita o registro.
+type Policy interface {
+	Evaluate(ctx context.Context, schema *models.Schema) []string
+}
+
+// PolicyFunc adapta uma função à interface Policy
+type PolicyFunc func(ctx context.Context, schema *models.Schema) []string
+
+func (f PolicyFunc) Evaluate(ctx context.Context, schema *models.Schema) []string {
+	return f(ctx, schema)
+}
+
+// RequiredMetadata exige que o schema traga as chaves de metadata informadas, não vazias
+func RequiredMetadata(keys ...string) Policy {
+	return PolicyFunc(func(ctx context.Context, schema *models.Schema) []string {
+		var violations []string
+		for _, key := range keys {
+			if strings.TrimSpace(schema.Metadata[key]) == "" {
+				violations = append(violations, fmt.Sprintf("metadata %q is required", key))
+			}
+		}
+		return violations
+	})
+}
+
+// AddPolicy inclui uma política avaliada em todos os registros
+func (r *Registry) AddPolicy(policy Policy) {
+	r.policies = append(r.policies, policy)
+}
+
+// policyViolations avalia as políticas configuradas
+func (r *Registry) policyViolations(ctx context.Context, schema *models.Schema) []string {
+	var violations []string
+	for _, policy := range r.policies {
+		violations = append(violations, policy.Evaluate(ctx, schema)...)
+	}
+	return violations
+}
+
+func policyViolated(subject string, violations []string) error {
+	return newError(ErrPolicyViolation, map[string]interface{}{"subject": subject, "errors": violations},
+		"schema violates registry policies: %v", violations)
+}
+
+// checkPolicies rejeita o schema se alguma política for violada
+func (r *Registry) checkPolicies(ctx context.Context, schema *models.Schema) error {
+	if violations := r.policyViolations(ctx, schema); len(violations) > 0 {
+		return policyViolated(schema.Subject, violations)
+	}
+	return nil
+}
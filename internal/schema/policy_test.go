@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestRequiredMetadataPolicy(t *testing.T) {
+	ctx := context.Background()
+	nc, kv := newTestKV(t)
+	storage := NewStorage(kv)
+	registry := NewRegistry(storage, NewValidator(storage), nc)
+	registry.AddPolicy(RequiredMetadata("owner"))
+
+	newSchema := func(metadata map[string]string) *models.Schema {
+		return &models.Schema{Subject: "orders", SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object"}`, Metadata: metadata}
+	}
+
+	if _, err := registry.RegisterSchema(ctx, newSchema(nil)); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("register without owner: err = %v, want %v", err, ErrPolicyViolation)
+	}
+
+	check, err := registry.DryRunRegistration(ctx, newSchema(map[string]string{"owner": " "}))
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if check.Valid || len(check.Findings) == 0 || check.Findings[len(check.Findings)-1].Stage != models.StagePolicy {
+		t.Errorf("expected a %s finding, got %+v", models.StagePolicy, check)
+	}
+
+	if _, err := registry.RegisterSchema(ctx, newSchema(map[string]string{"owner": "checkout"})); err != nil {
+		t.Errorf("register with owner: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+)
+
+// QuerySubjects lista subjects com filtros e paginação por cursor. As versões de todos os
+// subjects vêm de uma única listagem; os nomes ordenados permitem delimitar a janela do prefixo
+// e do cursor por busca binária, e só os subjects dentro dela têm a última versão lida.
+func (s *Storage) QuerySubjects(ctx context.Context, query models.SubjectQuery) (*models.SubjectPage, error) {
+	index, err := s.schemaIndex()
+	if err != nil {
+		return nil, err
+	}
+	names := sortedSubjects(index)
+
+	page := &models.SubjectPage{Subjects: []models.SubjectInfo{}}
+	for _, subject := range subjectWindow(names, query.Prefix, query.After, query.Order) {
+		if query.Contains != "" && !strings.Contains(subject, query.Contains) {
+			continue
+		}
+		if query.Visible != nil && !query.Visible(subject) {
+			continue
+		}
+
+		info, err := s.subjectInfo(ctx, subject, index[subject])
+		if err != nil {
+			return nil, err
+		}
+		if info == nil || !matchSubject(info, query) {
+			continue
+		}
+
+		if query.Limit > 0 && len(page.Subjects) == query.Limit {
+			page.NextCursor = page.Subjects[len(page.Subjects)-1].Subject
+			break
+		}
+
+		if query.Verbose {
+			config, err := s.GetConfig(ctx, subject)
+			if err != nil {
+				return nil, err
+			}
+			info.Config = config
+		}
+		page.Subjects = append(page.Subjects, *info)
+	}
+
+	return page, nil
+}
+
+// subjectWindow recorta da lista ordenada os nomes com o prefixo, após o cursor, na ordem pedida
+func subjectWindow(names []string, prefix, after, order string) []string {
+	lo := sort.SearchStrings(names, prefix)
+	hi := lo
+	for hi < len(names) && strings.HasPrefix(names[hi], prefix) {
+		hi++
+	}
+
+	if order != models.OrderDesc {
+		if after != "" {
+			lo = max(lo, sort.Search(len(names), func(i int) bool { return names[i] > after }))
+		}
+		if lo >= hi {
+			return nil
+		}
+		return names[lo:hi]
+	}
+
+	if after != "" {
+		hi = min(hi, sort.SearchStrings(names, after))
+	}
+	window := make([]string, 0, max(hi-lo, 0))
+	for i := hi - 1; i >= lo; i-- {
+		window = append(window, names[i])
+	}
+	return window
+}
+
+// subjectInfo resume o subject pela última versão ativa; se todas foram removidas,
+// usa a última versão e marca o subject como removido
+func (s *Storage) subjectInfo(ctx context.Context, subject string, versions []int) (*models.SubjectInfo, error) {
+	latest, err := s.latestSchema(ctx, subject, versions)
+	if err != nil || latest == nil {
+		return nil, err
+	}
+
+	return &models.SubjectInfo{
+		Subject:       subject,
+		LatestVersion: latest.Version,
+		SchemaType:    latest.SchemaType,
+		Metadata:      latest.Metadata,
+		Deleted:       latest.Deleted,
+	}, nil
+}
+
+// latestSchema percorre as versões da mais recente para a mais antiga e retorna a primeira
+// ativa; se todas foram removidas, retorna a mais recente (Deleted = true)
+func (s *Storage) latestSchema(ctx context.Context, subject string, versions []int) (*models.Schema, error) {
+	var latest *models.Schema
+	for i := len(versions) - 1; i >= 0; i-- {
+		schema, err := s.GetSchema(ctx, subject, versions[i])
+		if err != nil {
+			// Versão removida entre a listagem e a leitura
+			if errors.Is(err, ErrVersionNotFound) || errors.Is(err, ErrSubjectNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if !schema.Deleted {
+			return schema, nil
+		}
+		if latest == nil {
+			latest = schema
+		}
+	}
+	return latest, nil
+}
+
+// matchSubject aplica os filtros que dependem da última versão do subject
+func matchSubject(info *models.SubjectInfo, query models.SubjectQuery) bool {
+	switch query.Deleted {
+	case models.DeletedInclude:
+	case models.DeletedOnly:
+		if !info.Deleted {
+			return false
+		}
+	default:
+		if info.Deleted {
+			return false
+		}
+	}
+
+	if query.SchemaType != "" && !strings.EqualFold(info.SchemaType, query.SchemaType) {
+		return false
+	}
+
+	for key, value := range query.Labels {
+		if info.Metadata[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// pageVersions aplica ordem, cursor e limite a uma lista ordenada de versões
+func pageVersions(versions []int, query models.VersionQuery) *models.VersionPage {
+	ordered := make([]int, 0, len(versions))
+	if query.Order == models.OrderDesc {
+		for i := len(versions) - 1; i >= 0; i-- {
+			if query.After == 0 || versions[i] < query.After {
+				ordered = append(ordered, versions[i])
+			}
+		}
+	} else {
+		for _, version := range versions {
+			if version > query.After {
+				ordered = append(ordered, version)
+			}
+		}
+	}
+
+	page := &models.VersionPage{Versions: ordered}
+	if query.Limit > 0 && len(ordered) > query.Limit {
+		page.Versions = ordered[:query.Limit]
+		page.NextCursor = page.Versions[query.Limit-1]
+	}
+	return page
+}
@@ -0,0 +1,174 @@
+package schema
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestSubjectWindow(t *testing.T) {
+	names := []string{"billing.invoice", "orders.created", "orders.paid", "orders.shipped", "users"}
+
+	tests := []struct {
+		name   string
+		prefix string
+		after  string
+		order  string
+		want   []string
+	}{
+		{name: "should return all in ascending order", want: names},
+		{name: "should restrict to prefix", prefix: "orders.", want: []string{"orders.created", "orders.paid", "orders.shipped"}},
+		{name: "should start after cursor", prefix: "orders.", after: "orders.created", want: []string{"orders.paid", "orders.shipped"}},
+		{name: "should accept cursor outside prefix", prefix: "orders.", after: "a", want: []string{"orders.created", "orders.paid", "orders.shipped"}},
+		{name: "should return descending order", prefix: "orders.", order: models.OrderDesc, want: []string{"orders.shipped", "orders.paid", "orders.created"}},
+		{name: "should start before cursor when descending", order: models.OrderDesc, after: "orders.paid", want: []string{"orders.created", "billing.invoice"}},
+		{name: "should return nothing past the end", after: "zzz", want: nil},
+		{name: "should return nothing for unknown prefix", prefix: "payments", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := subjectWindow(names, tt.prefix, tt.after, tt.order)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("subjectWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchSubject(t *testing.T) {
+	active := &models.SubjectInfo{Subject: "orders", SchemaType: models.SchemaTypeAVRO, Metadata: map[string]string{"team": "sales"}}
+	deleted := &models.SubjectInfo{Subject: "legacy", SchemaType: models.SchemaTypeJSON, Deleted: true}
+
+	tests := []struct {
+		name  string
+		info  *models.SubjectInfo
+		query models.SubjectQuery
+		want  bool
+	}{
+		{name: "should hide deleted by default", info: deleted, want: false},
+		{name: "should include deleted when asked", info: deleted, query: models.SubjectQuery{Deleted: models.DeletedInclude}, want: true},
+		{name: "should return only deleted", info: active, query: models.SubjectQuery{Deleted: models.DeletedOnly}, want: false},
+		{name: "should match schema type", info: active, query: models.SubjectQuery{SchemaType: "AVRO"}, want: true},
+		{name: "should reject other schema type", info: active, query: models.SubjectQuery{SchemaType: "JSON"}, want: false},
+		{name: "should match labels", info: active, query: models.SubjectQuery{Labels: map[string]string{"team": "sales"}}, want: true},
+		{name: "should reject missing label", info: active, query: models.SubjectQuery{Labels: map[string]string{"tier": "gold"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchSubject(tt.info, tt.query); got != tt.want {
+				t.Errorf("matchSubject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuerySubjectsFiltersBeforeLimit(t *testing.T) {
+	ctx := context.Background()
+	_, kv := newTestKV(t)
+	storage := NewStorage(kv)
+
+	for _, subject := range []string{"a.hidden", "b.visible", "c.hidden", "d.visible", "e.hidden", "f.visible"} {
+		schema := &models.Schema{Subject: subject, Version: 1, SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object"}`}
+		if err := storage.SaveSchema(ctx, schema); err != nil {
+			t.Fatalf("save %s: %v", subject, err)
+		}
+	}
+
+	visible := func(subject string) bool { return strings.HasSuffix(subject, ".visible") }
+
+	// Percorre as páginas: cada uma deve vir cheia, sem subjects ocultos
+	var got []string
+	after := ""
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("too many pages: %v", got)
+		}
+		page, err := storage.QuerySubjects(ctx, models.SubjectQuery{After: after, Limit: 2, Visible: visible})
+		if err != nil {
+			t.Fatalf("query: %v", err)
+		}
+		if page.NextCursor != "" && len(page.Subjects) != 2 {
+			t.Errorf("page %d has %d subjects, want a full page", pages, len(page.Subjects))
+		}
+		for _, info := range page.Subjects {
+			got = append(got, info.Subject)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		after = page.NextCursor
+	}
+
+	want := []string{"b.visible", "d.visible", "f.visible"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("subjects = %v, want %v", got, want)
+	}
+}
+
+func TestSchemaIndexWithoutCache(t *testing.T) {
+	ctx := context.Background()
+	_, kv := newTestKV(t)
+	storage := NewStorage(kv)
+
+	for _, ref := range []VersionRef{{"orders", 1}, {"orders", 2}, {"orders.v2", 1}, {"payments", 3}} {
+		schema := &models.Schema{Subject: ref.Subject, Version: ref.Version, SchemaType: models.SchemaTypeJSON, Schema: `{"type":"object"}`}
+		if err := storage.SaveSchema(ctx, schema); err != nil {
+			t.Fatalf("save %s: %v", ref.Subject, err)
+		}
+	}
+	if err := storage.SaveConfig(ctx, &models.SchemaConfig{Subject: "orders", Compatibility: models.CompatibilityNone}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	index, err := storage.schemaIndex()
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	want := map[string][]int{"orders": {1, 2}, "orders.v2": {1}, "payments": {3}}
+	if !reflect.DeepEqual(index, want) {
+		t.Errorf("index = %v, want %v", index, want)
+	}
+
+	// O filtro por subject não inclui subjects com o mesmo prefixo
+	versions, err := storage.listSchemaVersions("orders")
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if !reflect.DeepEqual(versions, []int{1, 2}) {
+		t.Errorf("versions = %v, want [1 2]", versions)
+	}
+}
+
+func TestPageVersions(t *testing.T) {
+	versions := []int{1, 2, 4, 7}
+
+	tests := []struct {
+		name       string
+		query      models.VersionQuery
+		want       []int
+		wantCursor int
+	}{
+		{name: "should return all versions", want: versions},
+		{name: "should paginate", query: models.VersionQuery{Limit: 2}, want: []int{1, 2}, wantCursor: 2},
+		{name: "should continue after cursor", query: models.VersionQuery{Limit: 2, After: 2}, want: []int{4, 7}},
+		{name: "should paginate descending", query: models.VersionQuery{Order: models.OrderDesc, Limit: 2}, want: []int{7, 4}, wantCursor: 4},
+		{name: "should continue descending", query: models.VersionQuery{Order: models.OrderDesc, After: 4}, want: []int{2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := pageVersions(versions, tt.query)
+			if !reflect.DeepEqual(page.Versions, tt.want) || page.NextCursor != tt.wantCursor {
+				t.Errorf("pageVersions() = %v (cursor %d), want %v (cursor %d)", page.Versions, page.NextCursor, tt.want, tt.wantCursor)
+			}
+		})
+	}
+}
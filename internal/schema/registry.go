@@ -3,17 +3,30 @@ package schema
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"github.com/nats-io/nats.go"
 )
 
 type Registry struct {
 	storage   StorageSchema
 	validator ValidatorSchema
 	js        JetStream
+	auditor   Auditor
+	// outbox grava os eventos junto com as alterações (nil = publicação direta)
+	outbox *Outbox
+
+	// maxVersions limite padrão de versões por subject (0 = ilimitado)
+	maxVersions int
+	// batchConcurrency subjects processados em paralelo nas operações em lote
+	batchConcurrency int
+	// policies regras organizacionais avaliadas em todo registro
+	policies []Policy
 }
 
 func NewRegistry(
@@ -28,51 +41,406 @@ func NewRegistry(
 	}
 }
 
-// RegisterSchema registra um novo schema
+// SetAuditor habilita o registro de auditoria das alterações
+func (r *Registry) SetAuditor(auditor Auditor) {
+	r.auditor = auditor
+}
+
+// modeNotPermitted operação rejeitada pelo modo atual do subject
+func modeNotPermitted(subject, mode string) error {
+	return newError(ErrOperationNotPermitted, map[string]interface{}{"subject": subject, "mode": mode},
+		"subject %s is in %s mode", subject, mode)
+}
+
+// checkRegistrable verifica modo, validade, referências e políticas do schema sem gravá-lo
+func (r *Registry) checkRegistrable(ctx context.Context, schema *models.Schema) error {
+	if err := r.checkMode(ctx, schema.Subject); err != nil {
+		return err
+	}
+	if result := r.validator.ValidateSchema(schema); !result.Valid {
+		return invalidSchema(result.Errors)
+	}
+	if err := r.checkReferences(ctx, schema); err != nil {
+		return err
+	}
+	return r.checkPolicies(ctx, schema)
+}
+
+// checkMode rejeita registros em subjects fora do modo READWRITE
+func (r *Registry) checkMode(ctx context.Context, subject string) error {
+	mode, err := r.storage.GetMode(ctx, subject)
+	if err != nil {
+		return fmt.Errorf("failed to get mode: %w", err)
+	}
+	if mode.Mode != models.ModeReadWrite {
+		return modeNotPermitted(subject, mode.Mode)
+	}
+	return nil
+}
+
+func invalidSchema(errors []string) error {
+	return newError(ErrInvalidSchema, map[string]interface{}{"errors": errors}, "schema validation failed: %v", errors)
+}
+
+func incompatibleSchema(result *models.SchemaValidationResult) error {
+	return newError(ErrIncompatible,
+		map[string]interface{}{"errors": result.Errors, "incompatibilities": result.Incompatibilities},
+		"compatibility check failed: %v", result.Errors)
+}
+
+// checkReferences exige que cada referência aponte para uma versão ativa (versão 0 = última)
+func (r *Registry) checkReferences(ctx context.Context, schema *models.Schema) error {
+	for _, ref := range schema.References {
+		var err error
+		if ref.Version <= 0 {
+			_, err = r.storage.GetLatestSchema(ctx, ref.Subject)
+		} else {
+			_, err = r.FindSchema(ctx, ref.Subject, ref.Version, false)
+		}
+		if errors.Is(err, ErrSubjectNotFound) || errors.Is(err, ErrVersionNotFound) {
+			return newError(ErrInvalidSchema,
+				map[string]interface{}{"reference": ref.Name, "subject": ref.Subject, "version": ref.Version},
+				"reference %s not found: %w", ref.Name, err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to resolve reference %s: %w", ref.Name, err)
+		}
+	}
+	return nil
+}
+
+// SetMaxVersions define o limite padrão de versões por subject (0 = ilimitado)
+func (r *Registry) SetMaxVersions(limit int) {
+	r.maxVersions = limit
+}
+
+// checkVersionLimit rejeita novos registros quando o subject atingiu o limite de versões.
+// O limite do subject (config) tem precedência sobre o padrão do registry.
+func (r *Registry) checkVersionLimit(ctx context.Context, subject string, count int) error {
+	limit := r.maxVersions
+	config, err := r.storage.GetConfig(ctx, subject)
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	if config.MaxVersions > 0 {
+		limit = config.MaxVersions
+	}
+
+	if limit > 0 && count >= limit {
+		return newError(ErrVersionLimit, map[string]interface{}{"subject": subject, "max_versions": limit},
+			"subject %s reached the maximum of %d versions", subject, limit)
+	}
+	return nil
+}
+
+// RegisterSchema registra um novo schema. Schemas normalizados (ver Normalize) são deduplicados:
+// se o subject já tem uma versão ativa com a mesma forma canônica, ela é retornada.
 func (r *Registry) RegisterSchema(ctx context.Context, schema *models.Schema) (*models.Schema, error) {
-	// Validar schema
-	validationResult := r.validator.ValidateSchema(schema)
-	if !validationResult.Valid {
-		return nil, fmt.Errorf("schema validation failed: %v", validationResult.Errors)
+	registered, _, err := r.registerSchema(ctx, schema)
+	return registered, err
+}
+
+// registerAttempts tentativas de registro quando um registro concorrente grava o mesmo número
+// de versão; cada tentativa repete as verificações contra o novo estado do subject
+const registerAttempts = 3
+
+// registerSchema registra o schema, indicando se uma nova versão foi criada
+func (r *Registry) registerSchema(ctx context.Context, schema *models.Schema) (*models.Schema, bool, error) {
+	generatedID := schema.ID == ""
+	for attempt := 1; ; attempt++ {
+		registered, created, err := r.registerVersion(ctx, schema)
+		if !errors.Is(err, nats.ErrKeyExists) || attempt == registerAttempts {
+			return registered, created, err
+		}
+		log.Printf("Version %d of %s registered concurrently, retrying", schema.Version, schema.Subject)
+		if generatedID {
+			schema.ID = ""
+		}
+	}
+}
+
+// registerVersion uma tentativa de registro: a versão é criada sem sobrescrever uma existente
+func (r *Registry) registerVersion(ctx context.Context, schema *models.Schema) (*models.Schema, bool, error) {
+	existing, err := r.findDuplicate(ctx, schema)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		return existing, false, nil
+	}
+
+	if err := r.evaluateRegistration(ctx, schema, nil); err != nil {
+		return nil, false, err
+	}
+
+	// ID e datas são definidos antes para que o evento gravado no outbox já os contenha
+	if schema.ID == "" {
+		schema.ID = generateSchemaID(schema.Subject, schema.Version)
+	}
+	schema.CreatedAt = time.Now()
+	schema.UpdatedAt = schema.CreatedAt
+
+	// Salvar schema e publicar evento
+	err = r.applyWithEvents(ctx, func() error {
+		if err := r.storage.CreateSchema(ctx, schema); err != nil {
+			return fmt.Errorf("failed to save schema: %w", err)
+		}
+		return nil
+	}, &models.SchemaEvent{
+		Type:     models.EventSchemaCreated,
+		Subject:  schema.Subject,
+		Version:  schema.Version,
+		SchemaID: schema.ID,
+		After:    eventPayload(schema),
+		Metadata: map[string]interface{}{
+			"schema_type": schema.SchemaType,
+		},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	r.recordAudit(ctx, models.AuditSchemaRegistered, schema.Subject, schema.Version, nil, schema)
+
+	log.Printf("Schema registered: %s version %d", schema.Subject, schema.Version)
+	return schema, true, nil
+}
+
+// DryRunRegistration executa as verificações do registro sem gravar nem publicar eventos,
+// reportando a versão que o schema receberia e os problemas de todas as etapas
+func (r *Registry) DryRunRegistration(ctx context.Context, schema *models.Schema) (*models.RegistrationCheck, error) {
+	check := &models.RegistrationCheck{Findings: []models.RegistrationFinding{}}
+
+	// Erros de domínio (ex: modo) são reportados pela avaliação abaixo
+	existing, err := r.findDuplicate(ctx, schema)
+	var registryErr *RegistryError
+	if err != nil && !errors.As(err, &registryErr) {
+		return nil, err
+	}
+	if existing != nil {
+		// O registro retornaria a versão existente
+		schema = existing
+		check.Existing = true
+	} else if err := r.evaluateRegistration(ctx, schema, check); err != nil {
+		return nil, err
+	}
+
+	check.Subject = schema.Subject
+	check.Version = schema.Version
+	check.SchemaType = schema.SchemaType
+	check.Fingerprint = schema.Fingerprint()
+	check.Valid = true
+	for _, finding := range check.Findings {
+		if finding.Severity == models.SeverityError {
+			check.Valid = false
+		}
+	}
+	return check, nil
+}
+
+// LookupSchema procura no subject a versão com o mesmo conteúdo do candidato (tipo, definição e
+// referências). Com NormalizedSchema preenchido (ver Normalize), compara as formas canônicas.
+func (r *Registry) LookupSchema(ctx context.Context, candidate *models.Schema, includeDeleted bool) (*models.Schema, error) {
+	found, err := r.findExisting(ctx, candidate, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, newError(ErrSchemaNotFound, map[string]interface{}{"subject": candidate.Subject},
+			"schema not found in subject %s", candidate.Subject)
+	}
+	return found, nil
+}
+
+// findDuplicate versão ativa equivalente a um schema normalizado; schemas sem normalização
+// sempre geram nova versão. O modo do subject é verificado antes.
+func (r *Registry) findDuplicate(ctx context.Context, schema *models.Schema) (*models.Schema, error) {
+	if schema.NormalizedSchema == "" {
+		return nil, nil
+	}
+	if err := r.checkMode(ctx, schema.Subject); err != nil {
+		return nil, err
+	}
+	return r.findExisting(ctx, schema, false)
+}
+
+// findExisting versão mais recente do subject com o mesmo conteúdo do candidato, ou nil
+func (r *Registry) findExisting(ctx context.Context, candidate *models.Schema, includeDeleted bool) (*models.Schema, error) {
+	versions, err := r.storage.GetSchemaVersions(ctx, candidate.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema versions: %w", err)
+	}
+
+	normalize := candidate.NormalizedSchema != ""
+	key := contentKey(candidate, normalize)
+	for i := len(versions) - 1; i >= 0; i-- {
+		existing, err := r.storage.GetSchema(ctx, candidate.Subject, versions[i])
+		if errors.Is(err, ErrVersionNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if existing.Deleted && !includeDeleted {
+			continue
+		}
+		if existing.SchemaType == candidate.SchemaType && contentKey(existing, normalize) == key {
+			return existing, nil
+		}
+	}
+	return nil, nil
+}
+
+// evaluateRegistration executa as etapas de verificação do registro e define a versão do schema.
+// Sem check, o primeiro problema interrompe o registro; com check (dry run) os problemas são
+// reportados como findings e a avaliação continua. Falhas de infraestrutura sempre interrompem.
+func (r *Registry) evaluateRegistration(ctx context.Context, schema *models.Schema, check *models.RegistrationCheck) error {
+	report := func(stage string, err error) error {
+		var registryErr *RegistryError
+		if check == nil || !errors.As(err, &registryErr) {
+			return err
+		}
+		addFindings(check, stage, models.SeverityError, err.Error())
+		return nil
+	}
+
+	if err := r.checkMode(ctx, schema.Subject); err != nil {
+		if err := report(models.StageMode, err); err != nil {
+			return err
+		}
+	}
+
+	validation := r.validator.ValidateSchema(schema)
+	if !validation.Valid {
+		if check == nil {
+			return invalidSchema(validation.Errors)
+		}
+		addFindings(check, models.StageValidation, models.SeverityError, validation.Errors...)
+	}
+
+	// Convenções de nomes e documentação: só avisos, reportados no dry run
+	if check != nil && validation.Valid {
+		addFindings(check, models.StageLint, models.SeverityWarning, lintSchema(schema)...)
+	}
+
+	if err := r.checkReferences(ctx, schema); err != nil {
+		if err := report(models.StageReferences, err); err != nil {
+			return err
+		}
 	}
 
 	// Determinar próxima versão
 	versions, err := r.storage.GetSchemaVersions(ctx, schema.Subject)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get schema versions: %w", err)
+		return fmt.Errorf("failed to get schema versions: %w", err)
+	}
+
+	if err := r.checkVersionLimit(ctx, schema.Subject, len(versions)); err != nil {
+		if err := report(models.StageVersionLimit, err); err != nil {
+			return err
+		}
+	}
+
+	if violations := r.policyViolations(ctx, schema); len(violations) > 0 {
+		if check == nil {
+			return policyViolated(schema.Subject, violations)
+		}
+		addFindings(check, models.StagePolicy, models.SeverityError, violations...)
+	}
+
+	// Números de versões removidas permanentemente não são reutilizados
+	last, err := r.storage.LastVersion(ctx, schema.Subject)
+	if err != nil {
+		return fmt.Errorf("failed to get last version: %w", err)
 	}
+	schema.Version = last + 1
 
-	schema.Version = 1
-	if len(versions) > 0 {
-		schema.Version = versions[len(versions)-1] + 1
+	// Sem conteúdo válido não há o que comparar
+	if !validation.Valid {
+		return nil
 	}
 
-	// Validar compatibilidade
 	compatResult := r.validator.ValidateCompatibility(ctx, schema)
-	if !compatResult.Valid {
-		return nil, fmt.Errorf("compatibility check failed: %v", compatResult.Errors)
+	if check == nil {
+		if !compatResult.Valid {
+			return incompatibleSchema(compatResult)
+		}
+		return nil
 	}
 
-	// Salvar schema
-	if err := r.storage.SaveSchema(ctx, schema); err != nil {
-		return nil, fmt.Errorf("failed to save schema: %w", err)
+	for i := range compatResult.Incompatibilities {
+		incompatibility := compatResult.Incompatibilities[i]
+		check.Findings = append(check.Findings, models.RegistrationFinding{
+			Stage:           models.StageCompatibility,
+			Severity:        models.SeverityError,
+			Message:         incompatibility.Message,
+			Incompatibility: &incompatibility,
+		})
 	}
+	if len(compatResult.Incompatibilities) == 0 {
+		addFindings(check, models.StageCompatibility, models.SeverityError, compatResult.Errors...)
+	}
+	addFindings(check, models.StageCompatibility, models.SeverityWarning, compatResult.Warnings...)
+	return nil
+}
 
-	// Publicar evento
-	if err := r.publishSchemaEvent(ctx, &models.SchemaEvent{
-		Type:      "SCHEMA_CREATED",
-		Subject:   schema.Subject,
-		Version:   schema.Version,
-		SchemaID:  schema.ID,
-		Timestamp: time.Now(),
+func addFindings(check *models.RegistrationCheck, stage, severity string, messages ...string) {
+	for _, message := range messages {
+		check.Findings = append(check.Findings, models.RegistrationFinding{Stage: stage, Severity: severity, Message: message})
+	}
+}
+
+// ImportSchema grava um schema preservando ID e versão de origem (restore/migração)
+func (r *Registry) ImportSchema(ctx context.Context, schema *models.Schema) (*models.Schema, error) {
+	mode, err := r.storage.GetMode(ctx, schema.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mode: %w", err)
+	}
+	if mode.Mode == models.ModeReadOnly {
+		return nil, modeNotPermitted(schema.Subject, mode.Mode)
+	}
+
+	if schema.ID == "" || schema.Version <= 0 {
+		return nil, newError(ErrInvalidSchema, nil, "imported schema requires id and version")
+	}
+
+	validationResult := r.validator.ValidateSchema(schema)
+	if !validationResult.Valid {
+		return nil, newError(ErrInvalidSchema, map[string]interface{}{"errors": validationResult.Errors},
+			"schema validation failed: %v", validationResult.Errors)
+	}
+
+	if existing, err := r.storage.GetSchema(ctx, schema.Subject, schema.Version); err == nil {
+		return nil, newError(ErrConflict, map[string]interface{}{"subject": existing.Subject, "version": existing.Version, "id": existing.ID},
+			"schema already exists: %s version %d (id %s)", existing.Subject, existing.Version, existing.ID)
+	}
+	// Como no Confluent, o mesmo ID pode ser usado por várias versões com conteúdo idêntico
+	if existing, err := r.storage.GetSchemaByID(ctx, schema.ID); err == nil && !existing.SameContent(schema) {
+		return nil, newError(ErrConflict, map[string]interface{}{"subject": existing.Subject, "version": existing.Version, "id": schema.ID},
+			"schema id %s already used by %s version %d with different content", schema.ID, existing.Subject, existing.Version)
+	}
+
+	err = r.applyWithEvents(ctx, func() error {
+		if err := r.storage.CreateSchema(ctx, schema); err != nil {
+			return fmt.Errorf("failed to save schema: %w", err)
+		}
+		return nil
+	}, &models.SchemaEvent{
+		Type:     models.EventSchemaCreated,
+		Subject:  schema.Subject,
+		Version:  schema.Version,
+		SchemaID: schema.ID,
+		After:    eventPayload(schema),
 		Metadata: map[string]interface{}{
 			"schema_type": schema.SchemaType,
+			"imported":    true,
 		},
-	}); err != nil {
-		log.Printf("Warning: failed to publish schema event: %v", err)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("Schema registered: %s version %d", schema.Subject, schema.Version)
+	r.recordAudit(ctx, models.AuditSchemaImported, schema.Subject, schema.Version, nil, schema)
 	return schema, nil
 }
 
@@ -81,6 +449,25 @@ func (r *Registry) GetSchema(ctx context.Context, subject string, version int) (
 	return r.storage.GetSchema(ctx, subject, version)
 }
 
+// FindSchema obtém uma versão, tratando versões removidas (soft delete) como inexistentes
+// a menos que includeDeleted seja verdadeiro
+func (r *Registry) FindSchema(ctx context.Context, subject string, version int, includeDeleted bool) (*models.Schema, error) {
+	schema, err := r.storage.GetSchema(ctx, subject, version)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Deleted && !includeDeleted {
+		return nil, versionDeleted(subject, version)
+	}
+	return schema, nil
+}
+
+// versionDeleted versão removida via soft delete
+func versionDeleted(subject string, version int) error {
+	return newError(ErrVersionNotFound, map[string]interface{}{"subject": subject, "version": version, "deleted": true},
+		"version %d of subject %s was deleted", version, subject)
+}
+
 // GetLatestSchema obtém a última versão
 func (r *Registry) GetLatestSchema(ctx context.Context, subject string) (*models.Schema, error) {
 	return r.storage.GetLatestSchema(ctx, subject)
@@ -91,14 +478,78 @@ func (r *Registry) ListSubjects(ctx context.Context) ([]string, error) {
 	return r.storage.ListSubjects(ctx)
 }
 
-// ListVersions lista versões de um subject
+// QuerySubjects lista subjects com filtros e paginação
+func (r *Registry) QuerySubjects(ctx context.Context, query models.SubjectQuery) (*models.SubjectPage, error) {
+	return r.storage.QuerySubjects(ctx, query)
+}
+
+// QueryVersions lista versões de um subject com filtro de removidas e paginação
+func (r *Registry) QueryVersions(ctx context.Context, subject string, query models.VersionQuery) (*models.VersionPage, error) {
+	versions, err := r.storage.GetSchemaVersions(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, subjectNotFound(subject)
+	}
+
+	if query.Deleted != models.DeletedInclude {
+		filtered := make([]int, 0, len(versions))
+		for _, version := range versions {
+			schema, err := r.storage.GetSchema(ctx, subject, version)
+			if err != nil {
+				if errors.Is(err, ErrVersionNotFound) {
+					continue
+				}
+				return nil, err
+			}
+			if schema.Deleted == (query.Deleted == models.DeletedOnly) {
+				filtered = append(filtered, version)
+			}
+		}
+		// Todas as versões ativas removidas: o subject deixa de existir para a API
+		if len(filtered) == 0 && query.Deleted != models.DeletedOnly {
+			return nil, subjectNotFound(subject)
+		}
+		versions = filtered
+	}
+
+	return pageVersions(versions, query), nil
+}
+
+// ListVersions lista versões de um subject (incluindo removidas, usado pelo backup)
 func (r *Registry) ListVersions(ctx context.Context, subject string) ([]int, error) {
-	return r.storage.GetSchemaVersions(ctx, subject)
+	versions, err := r.storage.GetSchemaVersions(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, subjectNotFound(subject)
+	}
+	return versions, nil
 }
 
 // SetConfig define configuração de compatibilidade
 func (r *Registry) SetConfig(ctx context.Context, config *models.SchemaConfig) error {
-	return r.storage.SaveConfig(ctx, config)
+	previous, err := r.storage.GetConfig(ctx, config.Subject)
+	if err != nil {
+		return err
+	}
+
+	err = r.applyWithEvents(ctx, func() error {
+		return r.storage.SaveConfig(ctx, config)
+	}, &models.SchemaEvent{
+		Type:    models.EventConfigChanged,
+		Subject: config.Subject,
+		Before:  eventPayload(previous),
+		After:   eventPayload(config),
+	})
+	if err != nil {
+		return err
+	}
+
+	r.recordAudit(ctx, models.AuditConfigChanged, config.Subject, 0, previous, config)
+	return nil
 }
 
 // GetConfig obtém configuração
@@ -106,8 +557,55 @@ func (r *Registry) GetConfig(ctx context.Context, subject string) (*models.Schem
 	return r.storage.GetConfig(ctx, subject)
 }
 
-// ValidateData valida dados contra schema
+// ListConfigs lista as configurações definidas explicitamente
+func (r *Registry) ListConfigs(ctx context.Context) ([]*models.SchemaConfig, error) {
+	return r.storage.ListConfigs(ctx)
+}
+
+// SetMode define o modo de operação de um subject
+func (r *Registry) SetMode(ctx context.Context, mode *models.SchemaMode) error {
+	previous, err := r.storage.GetMode(ctx, mode.Subject)
+	if err != nil {
+		return err
+	}
+
+	err = r.applyWithEvents(ctx, func() error {
+		return r.storage.SaveMode(ctx, mode)
+	}, &models.SchemaEvent{
+		Type:    models.EventModeChanged,
+		Subject: mode.Subject,
+		Before:  eventPayload(previous),
+		After:   eventPayload(mode),
+	})
+	if err != nil {
+		return err
+	}
+
+	r.recordAudit(ctx, models.AuditModeChanged, mode.Subject, 0, previous, mode)
+	return nil
+}
+
+// GetMode obtém o modo de operação de um subject
+func (r *Registry) GetMode(ctx context.Context, subject string) (*models.SchemaMode, error) {
+	return r.storage.GetMode(ctx, subject)
+}
+
+// ListModes lista os modos definidos explicitamente
+func (r *Registry) ListModes(ctx context.Context) ([]*models.SchemaMode, error) {
+	return r.storage.ListModes(ctx)
+}
+
+// ValidateData valida dados contra a última versão do subject ou, se informado, contra req.Schema
 func (r *Registry) ValidateData(ctx context.Context, req *models.SchemaValidationRequest) (*models.SchemaValidationResult, error) {
+	// Schema informado na requisição substitui a última versão do subject
+	if req.Schema != "" {
+		schema := &models.Schema{Subject: req.Subject, Schema: req.Schema, SchemaType: DetectSchemaType(req.Schema, "")}
+		if validation := r.validator.ValidateSchema(schema); !validation.Valid {
+			return nil, invalidSchema(validation.Errors)
+		}
+		return r.validator.ValidateDataAgainst(schema, req.Data), nil
+	}
+
 	schema, err := r.storage.GetLatestSchema(ctx, req.Subject)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get schema: %w", err)
@@ -117,53 +615,244 @@ func (r *Registry) ValidateData(ctx context.Context, req *models.SchemaValidatio
 	return result, nil
 }
 
-// CheckCompatibility verifica compatibilidade entre schemas
-func (r *Registry) CheckCompatibility(ctx context.Context, subject string, schemaContent string) (*models.SchemaValidationResult, error) {
-	tempSchema := &models.Schema{
+// Versões especiais aceitas por CheckCompatibilityAgainst
+const (
+	LatestVersion = 0
+	AllVersions   = -1
+)
+
+// CheckCompatibility verifica o schema contra a última versão do subject. Sem schemaType o
+// formato é detectado pelo conteúdo.
+func (r *Registry) CheckCompatibility(ctx context.Context, subject, schemaType, schemaContent string) (*models.SchemaValidationResult, error) {
+	return r.CheckCompatibilityAgainst(ctx, &models.Schema{
 		Subject:    subject,
+		SchemaType: schemaType,
 		Schema:     schemaContent,
-		SchemaType: models.SchemaTypeJSON, // Ou detectar automaticamente
+	}, LatestVersion)
+}
+
+// CheckCompatibilityAgainst verifica o schema contra uma versão do subject, a última
+// (LatestVersion) ou todas as ativas (AllVersions), no modo de compatibilidade configurado
+func (r *Registry) CheckCompatibilityAgainst(ctx context.Context, schema *models.Schema, version int) (*models.SchemaValidationResult, error) {
+	return r.CheckCompatibilityWith(ctx, schema, version, "")
+}
+
+// CheckCompatibilityWith verifica como CheckCompatibilityAgainst, mas no nível informado em vez
+// do configurado (vazio = configurado), permitindo avaliar uma mudança de configuração pendente
+func (r *Registry) CheckCompatibilityWith(ctx context.Context, schema *models.Schema, version int, compatibility string) (*models.SchemaValidationResult, error) {
+	if compatibility != "" {
+		if err := (&models.SchemaConfig{Compatibility: compatibility}).Validate(); err != nil {
+			return nil, newError(ErrInvalidConfig, map[string]interface{}{"compatibility": compatibility}, "%w", err)
+		}
 	}
 
-	result := r.validator.ValidateCompatibility(ctx, tempSchema)
-	return result, nil
+	previous, err := r.compatibilityTargets(ctx, schema.Subject, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.SchemaType == "" {
+		fallback := ""
+		if len(previous) > 0 {
+			fallback = previous[len(previous)-1].SchemaType
+		}
+		schema.SchemaType = DetectSchemaType(schema.Schema, fallback)
+	}
+
+	if result := r.validator.ValidateSchema(schema); !result.Valid {
+		return result, nil
+	}
+
+	if compatibility == "" {
+		config, err := r.storage.GetConfig(ctx, schema.Subject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get config: %w", err)
+		}
+		compatibility = config.Compatibility
+	}
+	return r.validator.ValidateAgainst(compatibility, schema, previous...), nil
 }
 
-// DeleteSchema deleta um schema
-func (r *Registry) DeleteSchema(ctx context.Context, subject string, version int) error {
+// compatibilityTargets versões usadas na verificação; um subject sem versões não tem alvos
+func (r *Registry) compatibilityTargets(ctx context.Context, subject string, version int) ([]*models.Schema, error) {
+	switch version {
+	case LatestVersion:
+		latest, err := r.storage.GetLatestSchema(ctx, subject)
+		if errors.Is(err, ErrSubjectNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []*models.Schema{latest}, nil
+	case AllVersions:
+		versions, err := r.storage.GetSchemaVersions(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+		var targets []*models.Schema
+		for _, v := range versions {
+			found, err := r.storage.GetSchema(ctx, subject, v)
+			if errors.Is(err, ErrVersionNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !found.Deleted {
+				targets = append(targets, found)
+			}
+		}
+		return targets, nil
+	default:
+		found, err := r.FindSchema(ctx, subject, version, false)
+		if err != nil {
+			return nil, err
+		}
+		return []*models.Schema{found}, nil
+	}
+}
+
+// DeleteSchema remove uma versão. Sem permanent a versão é apenas marcada como removida
+// (soft delete); a remoção permanente exige que a versão já tenha sido marcada.
+func (r *Registry) DeleteSchema(ctx context.Context, subject string, version int, permanent bool) error {
 	schema, err := r.storage.GetSchema(ctx, subject, version)
 	if err != nil {
 		return err
 	}
 
-	if err := r.storage.DeleteSchema(ctx, subject, version); err != nil {
-		return err
+	before := *schema
+	var apply func() error
+	var events []*models.SchemaEvent
+	if permanent {
+		if !schema.Deleted {
+			return newError(ErrConflict, map[string]interface{}{"subject": subject, "version": version},
+				"version %d of subject %s must be soft deleted before permanent deletion", version, subject)
+		}
+		apply = func() error {
+			return r.storage.DeleteSchema(ctx, subject, version)
+		}
+		if events, err = r.deletionEvents(ctx, schema, nil); err != nil {
+			return err
+		}
+	} else {
+		if schema.Deleted {
+			return versionDeleted(subject, version)
+		}
+		schema.Deleted = true
+		apply = func() error {
+			if err := r.storage.SaveSchema(ctx, schema); err != nil {
+				return fmt.Errorf("failed to save schema: %w", err)
+			}
+			return nil
+		}
+		events = []*models.SchemaEvent{{
+			Type:     models.EventSchemaSoftDeleted,
+			Subject:  subject,
+			Version:  version,
+			SchemaID: schema.ID,
+			Before:   eventPayload(&before),
+			After:    eventPayload(schema),
+		}}
 	}
 
-	// Publicar evento de deleção
-	r.publishSchemaEvent(ctx, &models.SchemaEvent{
-		Type:      "SCHEMA_DELETED",
-		Subject:   subject,
-		Version:   version,
-		SchemaID:  schema.ID,
-		Timestamp: time.Now(),
-	})
+	// Aplicar deleção e publicar eventos
+	if err := r.applyWithEvents(ctx, apply, events...); err != nil {
+		return err
+	}
 
+	var after interface{}
+	if !permanent {
+		after = schema
+	}
+	r.recordAudit(ctx, models.AuditSchemaDeleted, subject, version, &before, after)
 	return nil
 }
 
+// deletionEvents eventos da remoção permanente de uma versão; remover a última versão
+// restante remove também o subject
+func (r *Registry) deletionEvents(ctx context.Context, schema *models.Schema, metadata map[string]interface{}) ([]*models.SchemaEvent, error) {
+	events := []*models.SchemaEvent{{
+		Type:     models.EventSchemaDeleted,
+		Subject:  schema.Subject,
+		Version:  schema.Version,
+		SchemaID: schema.ID,
+		Before:   eventPayload(schema),
+		Metadata: metadata,
+	}}
+
+	versions, err := r.storage.GetSchemaVersions(ctx, schema.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema versions: %w", err)
+	}
+	if len(versions) == 1 && versions[0] == schema.Version {
+		events = append(events, &models.SchemaEvent{
+			Type:     models.EventSubjectDeleted,
+			Subject:  schema.Subject,
+			Metadata: metadata,
+		})
+	}
+	return events, nil
+}
+
 // GetSchemaByID obtém schema por ID
 func (r *Registry) GetSchemaByID(ctx context.Context, schemaID string) (*models.Schema, error) {
 	return r.storage.GetSchemaByID(ctx, schemaID)
 }
 
+// FindSchemaByID obtém schema por ID, tratando schemas removidos (soft delete) como inexistentes
+// a menos que includeDeleted seja verdadeiro
+func (r *Registry) FindSchemaByID(ctx context.Context, schemaID string, includeDeleted bool) (*models.Schema, error) {
+	return r.FindVisibleSchemaByID(ctx, schemaID, includeDeleted, nil)
+}
+
+// FindVisibleSchemaByID é como FindSchemaByID, mas schemas de subjects que visible rejeita
+// recebem o mesmo erro de um ID inexistente, para não revelar quais IDs existem
+func (r *Registry) FindVisibleSchemaByID(ctx context.Context, schemaID string, includeDeleted bool, visible func(subject string) bool) (*models.Schema, error) {
+	schema, err := r.storage.GetSchemaByID(ctx, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	if visible != nil && !visible(schema.Subject) {
+		return nil, schemaIDNotFound(schemaID)
+	}
+	if schema.Deleted && !includeDeleted {
+		return nil, newError(ErrSchemaNotFound, map[string]interface{}{"id": schemaID, "deleted": true},
+			"schema %s was deleted", schemaID)
+	}
+	return schema, nil
+}
+
 func (r *Registry) publishSchemaEvent(ctx context.Context, event *models.SchemaEvent) error {
 	data, err := json.Marshal(event)
 	if err != nil {
 		return err
 	}
 
-	subject := fmt.Sprintf("schema.events.%s", event.Subject)
-	err = r.js.Publish(subject, data)
-	return err
+	return r.js.Publish(EventSubjectPrefix+event.Subject, data)
+}
+
+// recordAudit registra a alteração no trail de auditoria, sem interromper a operação em caso de falha
+func (r *Registry) recordAudit(ctx context.Context, action, subject string, version int, before, after interface{}) {
+	if r.auditor == nil {
+		return
+	}
+
+	record := &models.AuditRecord{
+		Action:    action,
+		Subject:   subject,
+		Version:   version,
+		Timestamp: time.Now(),
+	}
+
+	if before != nil {
+		record.Before, _ = json.Marshal(before)
+	}
+	if after != nil {
+		record.After, _ = json.Marshal(after)
+	}
+
+	if err := r.auditor.Record(ctx, record); err != nil {
+		log.Printf("Warning: failed to record audit: %v", err)
+	}
 }
@@ -1,7 +1,13 @@
 package schema
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
 )
 
 func TestNewRegistry(t *testing.T) {
@@ -73,3 +79,194 @@ type mockValidator struct {
 type mockJetStream struct {
 	JetStream
 }
+
+type configStorage struct {
+	mockStorage
+	config *models.SchemaConfig
+}
+
+func (s *configStorage) GetConfig(ctx context.Context, subject string) (*models.SchemaConfig, error) {
+	return s.config, nil
+}
+
+func TestCheckVersionLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultLimit int
+		subjectLimit int
+		count        int
+		wantErr      bool
+	}{
+		{name: "should allow when unlimited", count: 1000},
+		{name: "should allow below default limit", defaultLimit: 3, count: 2},
+		{name: "should reject at default limit", defaultLimit: 3, count: 3, wantErr: true},
+		{name: "should prefer subject limit over default", defaultLimit: 3, subjectLimit: 5, count: 4},
+		{name: "should reject at subject limit", defaultLimit: 10, subjectLimit: 2, count: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &configStorage{config: &models.SchemaConfig{
+				Subject:       "payments.order",
+				Compatibility: models.CompatibilityBackward,
+				MaxVersions:   tt.subjectLimit,
+			}}
+			registry := NewRegistry(storage, &mockValidator{}, nil)
+			registry.SetMaxVersions(tt.defaultLimit)
+
+			err := registry.checkVersionLimit(context.Background(), "payments.order", tt.count)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkVersionLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterSchemaConcurrent(t *testing.T) {
+	ctx := context.Background()
+	nc, kv := newTestKV(t)
+	storage := NewStorage(kv)
+	registry := NewRegistry(storage, NewValidator(storage), nc)
+	if err := storage.SaveConfig(ctx, &models.SchemaConfig{Subject: "orders", Compatibility: models.CompatibilityNone}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	const writers = 8
+	versions := make([]int, writers)
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			registered, err := registry.RegisterSchema(ctx, &models.Schema{Subject: "orders", SchemaType: models.SchemaTypeJSON,
+				Schema: fmt.Sprintf(`{"type":"object","title":"Order%d"}`, i)})
+			errs[i] = err
+			if err == nil {
+				versions[i] = registered.Version
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Nenhum registro confirmado pode ser sobrescrito; quem perde todas as tentativas recebe conflito
+	confirmed := make(map[int]bool)
+	for i, err := range errs {
+		if err != nil {
+			if !errors.Is(err, ErrConflict) {
+				t.Errorf("writer %d: unexpected error %v", i, err)
+			}
+			continue
+		}
+		if confirmed[versions[i]] {
+			t.Errorf("version %d confirmed to more than one writer", versions[i])
+		}
+		confirmed[versions[i]] = true
+	}
+	stored, err := storage.GetSchemaVersions(ctx, "orders")
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(stored) != len(confirmed) {
+		t.Errorf("stored versions %v, confirmed %d", stored, len(confirmed))
+	}
+	for _, version := range stored {
+		schema, err := storage.GetSchema(ctx, "orders", version)
+		if err != nil {
+			t.Fatalf("get version %d: %v", version, err)
+		}
+		if !confirmed[schema.Version] {
+			t.Errorf("version %d was not confirmed to any writer", version)
+		}
+	}
+}
+
+func TestRegisterSchemaDoesNotReuseDeletedVersions(t *testing.T) {
+	ctx := context.Background()
+	nc, kv := newTestKV(t)
+	storage := NewStorage(kv)
+	registry := NewRegistry(storage, NewValidator(storage), nc)
+	if err := storage.SaveConfig(ctx, &models.SchemaConfig{Subject: "orders", Compatibility: models.CompatibilityNone}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	register := func(title string) int {
+		t.Helper()
+		registered, err := registry.RegisterSchema(ctx, &models.Schema{Subject: "orders", SchemaType: models.SchemaTypeJSON,
+			Schema: fmt.Sprintf(`{"type":"object","title":%q}`, title)})
+		if err != nil {
+			t.Fatalf("register %s: %v", title, err)
+		}
+		return registered.Version
+	}
+
+	register("v1")
+	register("v2")
+	for _, permanent := range []bool{false, true} {
+		if err := registry.DeleteSchema(ctx, "orders", 2, permanent); err != nil {
+			t.Fatalf("delete version 2 (permanent=%t): %v", permanent, err)
+		}
+	}
+	if version := register("v3"); version != 3 {
+		t.Errorf("expected version 3 after permanent deletion of version 2, got %d", version)
+	}
+}
+
+func TestValidateDataSchemaOverride(t *testing.T) {
+	ctx := context.Background()
+	nc, kv := newTestKV(t)
+	storage := NewStorage(kv)
+	registry := NewRegistry(storage, NewValidator(storage), nc)
+	if _, err := registry.RegisterSchema(ctx, &models.Schema{Subject: "orders", SchemaType: models.SchemaTypeAVRO,
+		Schema: `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		req          models.SchemaValidationRequest
+		wantErr      error
+		wantWarnings int
+	}{
+		{
+			name:         "should validate against the latest version",
+			req:          models.SchemaValidationRequest{Subject: "orders", Data: map[string]interface{}{"id": "1"}},
+			wantWarnings: 1, // dados Avro ainda não são validados
+		},
+		{
+			name: "should validate against the informed schema",
+			req:  models.SchemaValidationRequest{Subject: "orders", Schema: `{"type":"object"}`, Data: map[string]interface{}{"id": "1"}},
+		},
+		{
+			name: "should not require the subject to exist with an informed schema",
+			req:  models.SchemaValidationRequest{Subject: "unknown", Schema: `{"type":"object"}`, Data: map[string]interface{}{}},
+		},
+		{
+			name:    "should reject an invalid informed schema",
+			req:     models.SchemaValidationRequest{Subject: "orders", Schema: `{`, Data: map[string]interface{}{}},
+			wantErr: ErrInvalidSchema,
+		},
+		{
+			name:    "should report unknown subject without informed schema",
+			req:     models.SchemaValidationRequest{Subject: "unknown", Data: map[string]interface{}{}},
+			wantErr: ErrSubjectNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := registry.ValidateData(ctx, &tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !result.Valid || len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("unexpected result %+v", result)
+			}
+		})
+	}
+}
@@ -3,9 +3,12 @@ package schema
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rodrigues-daniel/data-platform/internal/models"
@@ -14,17 +17,108 @@ import (
 )
 
 type Storage struct {
-	kv nats.KeyValue
+	kv    nats.KeyValue
+	cache *Cache
 }
 
 func NewStorage(kv nats.KeyValue) *Storage {
 	return &Storage{kv: kv}
 }
 
-// SaveSchema salva um schema
+// EnableCache passa a servir leituras da memória, mantida coerente via watcher do bucket
+func (s *Storage) EnableCache(ctx context.Context) error {
+	cache := newCache()
+	if err := cache.Start(ctx, s.kv); err != nil {
+		return err
+	}
+
+	s.cache = cache
+	return nil
+}
+
+// cacheReady indica se as leituras podem ser servidas pelo cache
+func (s *Storage) cacheReady() bool {
+	return s.cache != nil && s.cache.Ready()
+}
+
+// get lê uma chave do cache, recorrendo ao KV em caso de miss
+func (s *Storage) get(kind, key string) ([]byte, error) {
+	if !s.cacheReady() {
+		entry, err := s.kv.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		return entry.Value(), nil
+	}
+
+	if value, ok := s.cache.get(key); ok {
+		CacheRequests.WithLabelValues(kind, "hit").Inc()
+		return value, nil
+	}
+	CacheRequests.WithLabelValues(kind, "miss").Inc()
+
+	entry, err := s.kv.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.apply(key, entry.Value(), entry.Revision(), false)
+	return entry.Value(), nil
+}
+
+// put grava uma chave no KV e atualiza o cache imediatamente
+func (s *Storage) put(key string, value []byte) error {
+	revision, err := s.kv.Put(key, value)
+	if err != nil {
+		return err
+	}
+	if s.cache != nil {
+		s.cache.apply(key, value, revision, false)
+	}
+	return nil
+}
+
+// create grava uma chave que ainda não existe; retorna nats.ErrKeyExists caso contrário
+func (s *Storage) create(key string, value []byte) error {
+	revision, err := s.kv.Create(key, value)
+	if err != nil {
+		return err
+	}
+	if s.cache != nil {
+		s.cache.apply(key, value, revision, false)
+	}
+	return nil
+}
+
+// delete remove uma chave do KV e do cache
+func (s *Storage) delete(key string) error {
+	if err := s.kv.Delete(key); err != nil {
+		return err
+	}
+	if s.cache != nil {
+		s.cache.remove(key)
+	}
+	return nil
+}
+
+// SaveSchema salva um schema, sobrescrevendo a versão se ela existir
 func (s *Storage) SaveSchema(ctx context.Context, schema *models.Schema) error {
+	return s.saveSchema(schema, s.put)
+}
+
+// CreateSchema salva uma nova versão. Se a versão já existir (ex.: registro concorrente que
+// calculou o mesmo número), retorna ErrConflict envolvendo nats.ErrKeyExists sem sobrescrevê-la.
+func (s *Storage) CreateSchema(ctx context.Context, schema *models.Schema) error {
+	err := s.saveSchema(schema, s.create)
+	if errors.Is(err, nats.ErrKeyExists) {
+		return newError(ErrConflict, map[string]interface{}{"subject": schema.Subject, "version": schema.Version},
+			"version %d of subject %s already exists: %w", schema.Version, schema.Subject, nats.ErrKeyExists)
+	}
+	return err
+}
+
+func (s *Storage) saveSchema(schema *models.Schema, write func(key string, value []byte) error) error {
 	if err := schema.Validate(); err != nil {
-		return fmt.Errorf("invalid schema: %w", err)
+		return newError(ErrInvalidSchema, map[string]interface{}{"subject": schema.Subject}, "invalid schema: %w", err)
 	}
 
 	// Gerar ID se não existir
@@ -45,22 +139,18 @@ func (s *Storage) SaveSchema(ctx context.Context, schema *models.Schema) error {
 
 	// Salvar no KV store
 	key := fmt.Sprintf("schemas.%s.%d", schema.Subject, schema.Version)
-	_, err = s.kv.Put(key, data)
-	if err != nil {
+	if err := write(key, data); err != nil {
 		return fmt.Errorf("failed to save schema: %w", err)
 	}
 
-	// Salvar metadata separadamente para busca rápida
-	metadataKey := fmt.Sprintf("metadata.%s", schema.ID)
-	metadata := map[string]interface{}{
-		"subject":    schema.Subject,
-		"version":    schema.Version,
-		"type":       schema.SchemaType,
-		"created_at": schema.CreatedAt,
+	// Salvar metadata separadamente para busca rápida; IDs importados podem ser compartilhados
+	// por várias versões com o mesmo conteúdo
+	metadata, _ := s.getIDMetadata(schema.ID)
+	if metadata == nil {
+		metadata = &idMetadata{Type: schema.SchemaType, CreatedAt: schema.CreatedAt}
 	}
-
-	metadataData, _ := json.Marshal(metadata)
-	s.kv.Put(metadataKey, metadataData)
+	metadata.add(schema.Subject, schema.Version)
+	s.putIDMetadata(schema.ID, metadata)
 
 	log.Printf("Schema saved: %s version %d", schema.Subject, schema.Version)
 	return nil
@@ -70,118 +160,249 @@ func (s *Storage) SaveSchema(ctx context.Context, schema *models.Schema) error {
 func (s *Storage) GetSchema(ctx context.Context, subject string, version int) (*models.Schema, error) {
 	key := fmt.Sprintf("schemas.%s.%d", subject, version)
 
-	entry, err := s.kv.Get(key)
+	value, err := s.get("schema", key)
 	if err != nil {
 		if err == nats.ErrKeyNotFound {
-			return nil, fmt.Errorf("schema not found: %s version %d", subject, version)
+			return nil, s.versionNotFound(ctx, subject, version)
 		}
 		return nil, fmt.Errorf("failed to get schema: %w", err)
 	}
 
 	var schema models.Schema
-	if err := json.Unmarshal(entry.Value(), &schema); err != nil {
+	if err := json.Unmarshal(value, &schema); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal schema: %w", err)
 	}
 
 	return &schema, nil
 }
 
-// GetLatestSchema obtém a última versão de um schema
+// GetLatestSchema obtém a última versão ativa (não removida) de um schema
 func (s *Storage) GetLatestSchema(ctx context.Context, subject string) (*models.Schema, error) {
-	// Listar todas as versões
-	versions, err := s.GetSchemaVersions(ctx, subject)
+	var versions []int
+	if s.cacheReady() {
+		// Com o cache pronto, subject ausente não existe: não há varredura do KV
+		version, ok := s.cache.latestVersion(subject)
+		if !ok {
+			CacheRequests.WithLabelValues("latest", "miss").Inc()
+			return nil, subjectNotFound(subject)
+		}
+		CacheRequests.WithLabelValues("latest", "hit").Inc()
+
+		latest, err := s.GetSchema(ctx, subject, version)
+		if err == nil && !latest.Deleted {
+			return latest, nil
+		}
+		// Última versão removida (soft delete ou entre as leituras): procura a anterior ativa
+		versions = s.cache.schemaVersions(subject)
+	} else {
+		listed, err := s.listSchemaVersions(subject)
+		if err != nil {
+			return nil, err
+		}
+		versions = listed
+	}
+
+	latest, err := s.latestSchema(ctx, subject, versions)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(versions) == 0 {
-		return nil, fmt.Errorf("no schemas found for subject: %s", subject)
+	if latest == nil || latest.Deleted {
+		return nil, subjectNotFound(subject)
 	}
+	return latest, nil
+}
 
-	// Encontrar a versão mais recente
-	latestVersion := 0
-	for _, v := range versions {
-		if v > latestVersion {
-			latestVersion = v
-		}
+// versionNotFound distingue subject inexistente de versão inexistente
+func (s *Storage) versionNotFound(ctx context.Context, subject string, version int) error {
+	if versions, err := s.GetSchemaVersions(ctx, subject); err == nil && len(versions) == 0 {
+		return subjectNotFound(subject)
 	}
+	return newError(ErrVersionNotFound, map[string]interface{}{"subject": subject, "version": version},
+		"version %d not found for subject %s", version, subject)
+}
 
-	return s.GetSchema(ctx, subject, latestVersion)
+func subjectNotFound(subject string) error {
+	return newError(ErrSubjectNotFound, map[string]interface{}{"subject": subject}, "subject %s not found", subject)
 }
 
 // GetSchemaVersions lista todas as versões de um subject
 func (s *Storage) GetSchemaVersions(ctx context.Context, subject string) ([]int, error) {
-	// prefix := fmt.Sprintf("schemas.%s.", subject)
-
-	// keys, err := s.kv.Keys()
-	// if err != nil {
-	// 	if err == nats.ErrNoKeysFound {
-	// 		return []int{}, nil
-	// 	}
-	// 	return nil, fmt.Errorf("failed to list keys: %w", err)
-	// }
-
-	// var versions []int
-	// for _, key := range keys {
-	// 	if len(key) > len(prefix) && key[:len(prefix)] == prefix {
-	// 		var version int
-	// 		_, err := fmt.Sscanf(key, "schemas:%s:%d", &subject, &version)
-	// 		if err == nil {
-	// 			versions = append(versions, version)
-	// 		}
-	// 	}
-	// }
-
-	versions = []int{1}
+	if s.cacheReady() {
+		return s.cache.schemaVersions(subject), nil
+	}
+	return s.listSchemaVersions(subject)
+}
+
+// listSchemaVersions lista as versões de um subject diretamente no KV; o filtro é aplicado
+// pelo servidor, então só as chaves do subject são lidas
+func (s *Storage) listSchemaVersions(subject string) ([]int, error) {
+	keys, err := s.keysMatching("schemas." + subject + ".*")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []int{}
+	for _, key := range keys {
+		if keySubject, version, ok := parseSchemaKey(key); ok && keySubject == subject {
+			versions = append(versions, version)
+		}
+	}
+
 	sort.Ints(versions)
 	return versions, nil
 }
 
-// ListSubjects lista todos os subjects
-func (s *Storage) ListSubjects(ctx context.Context) ([]string, error) {
-	keys, err := s.kv.Keys()
+// highWaterKey maior versão removida permanentemente do subject
+func highWaterKey(subject string) string {
+	return fmt.Sprintf("subjects.%s.highwater", subject)
+}
+
+// LastVersion maior número de versão já usado pelo subject, inclusive por versões removidas
+// permanentemente, para que um número nunca seja reatribuído a outro conteúdo (0 = nenhum)
+func (s *Storage) LastVersion(ctx context.Context, subject string) (int, error) {
+	versions, err := s.GetSchemaVersions(ctx, subject)
 	if err != nil {
-		if err == nats.ErrNoKeysFound {
-			return []string{}, nil
+		return 0, err
+	}
+	last := 0
+	if len(versions) > 0 {
+		last = versions[len(versions)-1]
+	}
+
+	value, err := s.get("highwater", highWaterKey(subject))
+	if err != nil {
+		if err == nats.ErrKeyNotFound {
+			return last, nil
 		}
-		return nil, fmt.Errorf("failed to list keys: %w", err)
+		return 0, fmt.Errorf("failed to get version high-water mark: %w", err)
+	}
+	if reserved, err := strconv.Atoi(string(value)); err == nil && reserved > last {
+		last = reserved
+	}
+	return last, nil
+}
+
+// reserveVersion eleva a marca do subject até version antes de a versão ser removida.
+// Atualização condicional pela revisão, para que remoções concorrentes não reduzam a marca.
+func (s *Storage) reserveVersion(subject string, version int) error {
+	key := highWaterKey(subject)
+	value := []byte(strconv.Itoa(version))
+
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		var entry nats.KeyValueEntry
+		entry, err = s.kv.Get(key)
+		if err == nats.ErrKeyNotFound {
+			if err = s.create(key, value); err == nil {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if current, _ := strconv.Atoi(string(entry.Value())); current >= version {
+			return nil
+		}
+
+		var revision uint64
+		if revision, err = s.kv.Update(key, value, entry.Revision()); err == nil {
+			if s.cache != nil {
+				s.cache.apply(key, value, revision, false)
+			}
+			return nil
+		}
+	}
+	return err
+}
+
+// schemaIndex versões de todos os subjects, do cache ou de uma única listagem das chaves de
+// schemas no KV
+func (s *Storage) schemaIndex() (map[string][]int, error) {
+	if s.cacheReady() {
+		return s.cache.versionIndex(), nil
 	}
 
-	subjects := make(map[string]bool)
+	keys, err := s.keysMatching("schemas.>")
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string][]int)
 	for _, key := range keys {
-		var subject string
-		if _, err := fmt.Sscanf(key, "schemas.%s.", &subject); err == nil {
-			subjects[subject] = true
+		if subject, version, ok := parseSchemaKey(key); ok {
+			index[subject] = append(index[subject], version)
 		}
 	}
+	for _, versions := range index {
+		sort.Ints(versions)
+	}
+	return index, nil
+}
 
-	result := make([]string, 0, len(subjects))
-	for subject := range subjects {
-		result = append(result, subject)
+// keysMatching lista as chaves que casam com o filtro NATS (ex.: schemas.orders.*), sem ler
+// os valores nem chaves de outros prefixos
+func (s *Storage) keysMatching(filter string) ([]string, error) {
+	watcher, err := s.kv.Watch(filter, nats.MetaOnly(), nats.IgnoreDeletes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	defer watcher.Stop()
+
+	// Valores iniciais terminam com uma entrada nil
+	var keys []string
+	for entry := range watcher.Updates() {
+		if entry == nil {
+			break
+		}
+		keys = append(keys, entry.Key())
+	}
+	return keys, nil
+}
+
+// ListSubjects lista todos os subjects
+func (s *Storage) ListSubjects(ctx context.Context) ([]string, error) {
+	index, err := s.schemaIndex()
+	if err != nil {
+		return nil, err
 	}
+	return sortedSubjects(index), nil
+}
 
+func sortedSubjects(index map[string][]int) []string {
+	result := make([]string, 0, len(index))
+	for subject := range index {
+		result = append(result, subject)
+	}
 	sort.Strings(result)
-	return result, nil
+	return result
 }
 
-// DeleteSchema deleta um schema
+// DeleteSchema deleta um schema. O número da versão continua reservado (ver LastVersion).
 func (s *Storage) DeleteSchema(ctx context.Context, subject string, version int) error {
 	key := fmt.Sprintf("schemas.%s.%d", subject, version)
 
+	if err := s.reserveVersion(subject, version); err != nil {
+		return fmt.Errorf("failed to reserve version %d of %s: %w", version, subject, err)
+	}
+
 	// Obter schema para remover metadata
 	schema, err := s.GetSchema(ctx, subject, version)
 	if err == nil {
-		metadataKey := fmt.Sprintf("metadata.%s", schema.ID)
-		s.kv.Delete(metadataKey)
+		if metadata, _ := s.getIDMetadata(schema.ID); metadata != nil && metadata.remove(subject, version) {
+			s.putIDMetadata(schema.ID, metadata)
+		} else {
+			s.delete(fmt.Sprintf("metadata.%s", schema.ID))
+		}
 	}
 
-	return s.kv.Delete(key)
+	return s.delete(key)
 }
 
 // SaveConfig salva configuração de compatibilidade
 func (s *Storage) SaveConfig(ctx context.Context, config *models.SchemaConfig) error {
 	if err := config.Validate(); err != nil {
-		return err
+		return newError(ErrInvalidConfig, map[string]interface{}{"subject": config.Subject}, "%w", err)
 	}
 
 	data, err := json.Marshal(config)
@@ -190,15 +411,14 @@ func (s *Storage) SaveConfig(ctx context.Context, config *models.SchemaConfig) e
 	}
 
 	key := fmt.Sprintf("subjects.%s.config", config.Subject)
-	_, err = s.kv.Put(key, data)
-	return err
+	return s.put(key, data)
 }
 
 // GetConfig obtém configuração de compatibilidade
 func (s *Storage) GetConfig(ctx context.Context, subject string) (*models.SchemaConfig, error) {
 	key := fmt.Sprintf("subjects.%s.config", subject)
 
-	entry, err := s.kv.Get(key)
+	value, err := s.get("config", key)
 	if err != nil {
 		if err == nats.ErrKeyNotFound {
 			// Retornar configuração padrão
@@ -211,31 +431,238 @@ func (s *Storage) GetConfig(ctx context.Context, subject string) (*models.Schema
 	}
 
 	var config models.SchemaConfig
-	if err := json.Unmarshal(entry.Value(), &config); err != nil {
+	if err := json.Unmarshal(value, &config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
 	return &config, nil
 }
 
-// GetSchemaByID obtém schema por ID
+// ListConfigs lista as configurações definidas explicitamente
+func (s *Storage) ListConfigs(ctx context.Context) ([]*models.SchemaConfig, error) {
+	keys, err := s.listKeys("subjects.", ".config")
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make([]*models.SchemaConfig, 0, len(keys))
+	for _, key := range keys {
+		value, err := s.get("config", key)
+		if err != nil {
+			if err == nats.ErrKeyNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get config: %w", err)
+		}
+
+		var config models.SchemaConfig
+		if err := json.Unmarshal(value, &config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+		configs = append(configs, &config)
+	}
+
+	return configs, nil
+}
+
+// SaveMode salva o modo de operação de um subject
+func (s *Storage) SaveMode(ctx context.Context, mode *models.SchemaMode) error {
+	if err := mode.Validate(); err != nil {
+		return newError(ErrInvalidMode, map[string]interface{}{"subject": mode.Subject}, "%w", err)
+	}
+
+	data, err := json.Marshal(mode)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mode: %w", err)
+	}
+
+	key := fmt.Sprintf("subjects.%s.mode", mode.Subject)
+	return s.put(key, data)
+}
+
+// GetMode obtém o modo de operação de um subject
+func (s *Storage) GetMode(ctx context.Context, subject string) (*models.SchemaMode, error) {
+	key := fmt.Sprintf("subjects.%s.mode", subject)
+
+	value, err := s.get("mode", key)
+	if err != nil {
+		if err == nats.ErrKeyNotFound {
+			// Retornar modo padrão
+			return &models.SchemaMode{
+				Subject: subject,
+				Mode:    models.ModeReadWrite,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get mode: %w", err)
+	}
+
+	var mode models.SchemaMode
+	if err := json.Unmarshal(value, &mode); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mode: %w", err)
+	}
+
+	return &mode, nil
+}
+
+// ListModes lista os modos definidos explicitamente
+func (s *Storage) ListModes(ctx context.Context) ([]*models.SchemaMode, error) {
+	keys, err := s.listKeys("subjects.", ".mode")
+	if err != nil {
+		return nil, err
+	}
+
+	modes := make([]*models.SchemaMode, 0, len(keys))
+	for _, key := range keys {
+		value, err := s.get("mode", key)
+		if err != nil {
+			if err == nats.ErrKeyNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get mode: %w", err)
+		}
+
+		var mode models.SchemaMode
+		if err := json.Unmarshal(value, &mode); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal mode: %w", err)
+		}
+		modes = append(modes, &mode)
+	}
+
+	return modes, nil
+}
+
+// listKeys lista as chaves com o prefixo e sufixo informados
+func (s *Storage) listKeys(prefix, suffix string) ([]string, error) {
+	if s.cacheReady() {
+		return s.cache.keys(prefix, suffix), nil
+	}
+
+	keys, err := s.keysMatching(prefix + ">")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, suffix) {
+			result = append(result, key)
+		}
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
+
+// schemaIDNotFound erro de ID inexistente
+func schemaIDNotFound(schemaID string) error {
+	return newError(ErrSchemaNotFound, map[string]interface{}{"id": schemaID}, "schema %s not found", schemaID)
+}
+
+// GetSchemaByID obtém schema por ID. Se o ID for compartilhado, prefere uma versão ativa.
 func (s *Storage) GetSchemaByID(ctx context.Context, schemaID string) (*models.Schema, error) {
 	// Primeiro buscar metadata
-	metadataKey := fmt.Sprintf("metadata.%s", schemaID)
-	entry, err := s.kv.Get(metadataKey)
+	metadata, err := s.getIDMetadata(schemaID)
 	if err != nil {
-		return nil, fmt.Errorf("schema not found: %s", schemaID)
+		return nil, err
+	}
+	if metadata == nil {
+		return nil, schemaIDNotFound(schemaID)
 	}
 
-	var metadata map[string]interface{}
-	if err := json.Unmarshal(entry.Value(), &metadata); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	var found *models.Schema
+	for _, ref := range metadata.refs() {
+		schema, err := s.GetSchema(ctx, ref.Subject, ref.Version)
+		if err != nil {
+			if errors.Is(err, ErrVersionNotFound) || errors.Is(err, ErrSubjectNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if !schema.Deleted {
+			return schema, nil
+		}
+		if found == nil {
+			found = schema
+		}
+	}
+	if found == nil {
+		return nil, schemaIDNotFound(schemaID)
+	}
+	return found, nil
+}
+
+// idMetadata índice de um ID para as versões que o usam. Subject e Version guardam a primeira
+// versão, formato usado antes de IDs compartilhados.
+type idMetadata struct {
+	Subject   string      `json:"subject"`
+	Version   int         `json:"version"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Versions  []idVersion `json:"versions,omitempty"`
+}
+
+type idVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+func (m *idMetadata) refs() []idVersion {
+	if len(m.Versions) == 0 && m.Subject != "" {
+		return []idVersion{{Subject: m.Subject, Version: m.Version}}
 	}
+	return m.Versions
+}
 
-	subject, _ := metadata["subject"].(string)
-	version, _ := metadata["version"].(float64)
+func (m *idMetadata) add(subject string, version int) {
+	refs := m.refs()
+	for _, ref := range refs {
+		if ref.Subject == subject && ref.Version == version {
+			return
+		}
+	}
+	m.Versions = append(refs, idVersion{Subject: subject, Version: version})
+	m.Subject, m.Version = m.Versions[0].Subject, m.Versions[0].Version
+}
 
-	return s.GetSchema(ctx, subject, int(version))
+// remove tira a versão do índice e retorna se ainda restam versões com o ID
+func (m *idMetadata) remove(subject string, version int) bool {
+	refs := make([]idVersion, 0, len(m.refs()))
+	for _, ref := range m.refs() {
+		if ref.Subject != subject || ref.Version != version {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == 0 {
+		return false
+	}
+	m.Versions = refs
+	m.Subject, m.Version = refs[0].Subject, refs[0].Version
+	return true
+}
+
+// getIDMetadata lê o índice de um ID; retorna nil se o ID não existir
+func (s *Storage) getIDMetadata(schemaID string) (*idMetadata, error) {
+	value, err := s.get("id", fmt.Sprintf("metadata.%s", schemaID))
+	if err != nil {
+		if err == nats.ErrKeyNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get schema metadata: %w", err)
+	}
+
+	var metadata idMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	return &metadata, nil
+}
+
+func (s *Storage) putIDMetadata(schemaID string, metadata *idMetadata) error {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	return s.put(fmt.Sprintf("metadata.%s", schemaID), data)
 }
 
 func generateSchemaID(subject string, version int) string {
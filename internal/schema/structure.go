@@ -0,0 +1,568 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+// Tipos de elemento estrutural
+const (
+	kindSchema     = "schema"     // raiz de um JSON Schema
+	kindDefinition = "definition" // JSON Schema: $defs/definitions
+	kindRecord     = "record"     // Avro record/error
+	kindEnum       = "enum"
+	kindFixed      = "fixed"
+	kindMessage    = "message" // Protobuf
+	kindField      = "field"
+	kindEnumValue  = "enum_value" // Protobuf: valor de enum, com número
+)
+
+// element item estrutural de um schema (tipo nomeado, campo ou valor de enum), identificado pelo caminho
+type element struct {
+	path     string
+	kind     string
+	typ      string          // representação do tipo (ex: string, null|string, array<int>, repeated int32)
+	def      json.RawMessage // default em JSON; nil quando ausente
+	required bool            // JSON Schema: listado em required; Avro: sem default; Protobuf: label required
+	number   int             // Protobuf: número do campo ou valor
+	symbols  []string        // símbolos do enum (Avro e JSON Schema)
+}
+
+// structure elementos de um schema indexados pelo caminho
+type structure map[string]*element
+
+func (s structure) add(e *element) {
+	s[e.path] = e
+}
+
+// parseStructure interpreta o schema conforme o formato
+func parseStructure(schemaType, content string) (structure, error) {
+	switch schemaType {
+	case models.SchemaTypeAVRO:
+		return parseAvro(content)
+	case models.SchemaTypeProtobuf:
+		return parseProtobuf(content)
+	default:
+		return parseJSONSchema(content)
+	}
+}
+
+// --- JSON Schema ---
+
+func parseJSONSchema(content string) (structure, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &root); err != nil {
+		return nil, err
+	}
+
+	s := structure{}
+	walkJSONSchema(s, "$", kindSchema, root, false)
+	for _, key := range []string{"$defs", "definitions"} {
+		defs, _ := root[key].(map[string]interface{})
+		for name, def := range defs {
+			if node, ok := def.(map[string]interface{}); ok {
+				walkJSONSchema(s, "#/"+key+"/"+name, kindDefinition, node, false)
+			}
+		}
+	}
+	return s, nil
+}
+
+func walkJSONSchema(s structure, path, kind string, node map[string]interface{}, required bool) {
+	e := &element{path: path, kind: kind, typ: jsonSchemaType(node), required: required}
+	if def, ok := node["default"]; ok {
+		e.def = canonicalJSON(def)
+	}
+	if values, ok := node["enum"].([]interface{}); ok {
+		for _, value := range values {
+			e.symbols = append(e.symbols, string(canonicalJSON(value)))
+		}
+	}
+	s.add(e)
+
+	requiredSet := make(map[string]bool)
+	if names, ok := node["required"].([]interface{}); ok {
+		for _, name := range names {
+			if name, ok := name.(string); ok {
+				requiredSet[name] = true
+			}
+		}
+	}
+	if properties, ok := node["properties"].(map[string]interface{}); ok {
+		for name, property := range properties {
+			if child, ok := property.(map[string]interface{}); ok {
+				walkJSONSchema(s, path+"."+name, kindField, child, requiredSet[name])
+			}
+		}
+	}
+	if items, ok := node["items"].(map[string]interface{}); ok {
+		walkJSONSchema(s, path+"[]", kindField, items, false)
+	}
+}
+
+// jsonSchemaType tipo declarado (tipos múltiplos ordenados e unidos por |)
+func jsonSchemaType(node map[string]interface{}) string {
+	switch t := node["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			types = append(types, fmt.Sprint(item))
+		}
+		sort.Strings(types)
+		return strings.Join(types, "|")
+	}
+	if ref, ok := node["$ref"].(string); ok {
+		return "$ref:" + ref
+	}
+	if _, ok := node["properties"]; ok {
+		return "object"
+	}
+	return ""
+}
+
+// canonicalJSON serializa o valor com chaves ordenadas
+func canonicalJSON(value interface{}) json.RawMessage {
+	data, _ := json.Marshal(value)
+	return data
+}
+
+// --- Avro ---
+
+var avroPrimitives = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true, "float": true,
+	"double": true, "bytes": true, "string": true,
+}
+
+func parseAvro(content string) (structure, error) {
+	var root interface{}
+	if err := json.Unmarshal([]byte(content), &root); err != nil {
+		return nil, err
+	}
+
+	s := structure{}
+	if _, err := avroType(s, root, ""); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// avroType retorna a representação do tipo, registrando os tipos nomeados e seus campos
+func avroType(s structure, node interface{}, namespace string) (string, error) {
+	switch t := node.(type) {
+	case string:
+		if avroPrimitives[t] {
+			return t, nil
+		}
+		return avroFullName(t, namespace), nil
+	case []interface{}:
+		members := make([]string, 0, len(t))
+		for _, member := range t {
+			name, err := avroType(s, member, namespace)
+			if err != nil {
+				return "", err
+			}
+			members = append(members, name)
+		}
+		return strings.Join(members, "|"), nil
+	case map[string]interface{}:
+		return avroComplexType(s, t, namespace)
+	default:
+		return "", fmt.Errorf("invalid avro type: %v", node)
+	}
+}
+
+func avroComplexType(s structure, node map[string]interface{}, namespace string) (string, error) {
+	typeName, ok := node["type"].(string)
+	if !ok {
+		// {"type": {...}} ou {"type": [...]}: tipo aninhado
+		if nested, exists := node["type"]; exists {
+			return avroType(s, nested, namespace)
+		}
+		return "", fmt.Errorf("avro schema must have 'type' field")
+	}
+
+	switch typeName {
+	case "record", "error", "enum", "fixed":
+	case "array":
+		items, err := avroType(s, node["items"], namespace)
+		if err != nil {
+			return "", err
+		}
+		return "array<" + items + ">", nil
+	case "map":
+		values, err := avroType(s, node["values"], namespace)
+		if err != nil {
+			return "", err
+		}
+		return "map<" + values + ">", nil
+	default:
+		// Primitivo, possivelmente com logicalType
+		if logical, ok := node["logicalType"].(string); ok {
+			return typeName + ":" + logical, nil
+		}
+		return avroType(s, typeName, namespace)
+	}
+
+	name, _ := node["name"].(string)
+	if name == "" {
+		return "", fmt.Errorf("avro %s must have a name", typeName)
+	}
+	if ns, ok := node["namespace"].(string); ok && !strings.Contains(name, ".") {
+		namespace = ns
+	}
+	fullName := avroFullName(name, namespace)
+	if i := strings.LastIndex(fullName, "."); i >= 0 {
+		namespace = fullName[:i]
+	} else {
+		namespace = ""
+	}
+
+	switch typeName {
+	case "enum":
+		e := &element{path: fullName, kind: kindEnum, typ: "enum"}
+		symbols, _ := node["symbols"].([]interface{})
+		for _, symbol := range symbols {
+			e.symbols = append(e.symbols, fmt.Sprint(symbol))
+		}
+		if def, ok := node["default"]; ok {
+			e.def = canonicalJSON(def)
+		}
+		s.add(e)
+	case "fixed":
+		s.add(&element{path: fullName, kind: kindFixed, typ: fmt.Sprintf("fixed(%v)", node["size"])})
+	default:
+		s.add(&element{path: fullName, kind: kindRecord, typ: typeName})
+		fields, _ := node["fields"].([]interface{})
+		for _, raw := range fields {
+			field, ok := raw.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("invalid field in record %s", fullName)
+			}
+			fieldName, _ := field["name"].(string)
+			if fieldName == "" {
+				return "", fmt.Errorf("field without name in record %s", fullName)
+			}
+			fieldType, err := avroType(s, field["type"], namespace)
+			if err != nil {
+				return "", fmt.Errorf("field %s.%s: %w", fullName, fieldName, err)
+			}
+			e := &element{path: fullName + "." + fieldName, kind: kindField, typ: fieldType, required: true}
+			if def, ok := field["default"]; ok {
+				e.def = canonicalJSON(def)
+				e.required = false
+			}
+			s.add(e)
+		}
+	}
+	return fullName, nil
+}
+
+// avroFullName resolve o nome completo herdando o namespace do tipo envolvente
+func avroFullName(name, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// --- Protobuf ---
+
+// protoParser parser de arquivos .proto: mensagens, enums e campos (serviços e opções são ignorados)
+type protoParser struct {
+	tokens []string
+	pos    int
+	s      structure
+	pkg    string
+	syntax string
+}
+
+func parseProtobuf(content string) (structure, error) {
+	p := &protoParser{tokens: tokenizeProto(content), s: structure{}}
+	if err := p.parseFile(); err != nil {
+		return nil, err
+	}
+	if p.syntax == "" {
+		return nil, fmt.Errorf("protobuf schema must specify syntax version")
+	}
+	return p.s, nil
+}
+
+// tokenizeProto separa identificadores, números, strings e símbolos, descartando comentários
+func tokenizeProto(content string) []string {
+	var tokens []string
+	runes := []rune(content)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case r == '"' || r == '\'':
+			j := i + 1
+			for j < len(runes) && runes[j] != r {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(runes) {
+				j = len(runes) - 1
+			}
+			tokens = append(tokens, string(runes[i:j+1]))
+			i = j + 1
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-' || r == '+':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '.' || runes[j] == '-' || runes[j] == '+') {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+	return tokens
+}
+
+func (p *protoParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *protoParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *protoParser) expect(token string) error {
+	if got := p.next(); got != token {
+		return fmt.Errorf("expected %q, got %q", token, got)
+	}
+	return nil
+}
+
+// skipStatement avança até o ';' ou até o fim do bloco iniciado na instrução
+func (p *protoParser) skipStatement() error {
+	for p.pos < len(p.tokens) {
+		switch p.next() {
+		case ";":
+			return nil
+		case "{":
+			return p.skipBlock()
+		}
+	}
+	return fmt.Errorf("unexpected end of schema")
+}
+
+// skipBlock avança até o '}' que fecha o bloco já aberto
+func (p *protoParser) skipBlock() error {
+	depth := 1
+	for p.pos < len(p.tokens) {
+		switch p.next() {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("unbalanced braces")
+}
+
+func (p *protoParser) parseFile() error {
+	for p.pos < len(p.tokens) {
+		switch token := p.next(); token {
+		case "syntax", "edition":
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			p.syntax = strings.Trim(p.next(), `"'`)
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "package":
+			p.pkg = p.next()
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "message":
+			if err := p.parseMessage(p.pkg); err != nil {
+				return err
+			}
+		case "enum":
+			if err := p.parseEnum(p.pkg); err != nil {
+				return err
+			}
+		case ";":
+		default:
+			// import, option, service, extend
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func (p *protoParser) parseMessage(scope string) error {
+	name := p.next()
+	path := qualify(scope, name)
+	if err := p.expect("{"); err != nil {
+		return fmt.Errorf("message %s: %w", name, err)
+	}
+	p.s.add(&element{path: path, kind: kindMessage, typ: "message"})
+	return p.parseMessageBody(path)
+}
+
+func (p *protoParser) parseMessageBody(path string) error {
+	for {
+		token := p.peek()
+		switch token {
+		case "":
+			return fmt.Errorf("message %s: unbalanced braces", path)
+		case "}":
+			p.next()
+			return nil
+		case ";":
+			p.next()
+		case "message":
+			p.next()
+			if err := p.parseMessage(path); err != nil {
+				return err
+			}
+		case "enum":
+			p.next()
+			if err := p.parseEnum(path); err != nil {
+				return err
+			}
+		case "oneof":
+			// Campos do oneof pertencem à mensagem
+			p.next()
+			p.next()
+			if err := p.expect("{"); err != nil {
+				return fmt.Errorf("message %s: %w", path, err)
+			}
+			if err := p.parseMessageBody(path); err != nil {
+				return err
+			}
+		case "option", "reserved", "extensions", "extend":
+			p.next()
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			if err := p.parseField(path); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseField lê [label] tipo nome = número [opções];
+func (p *protoParser) parseField(path string) error {
+	label := ""
+	switch p.peek() {
+	case "repeated", "optional", "required":
+		label = p.next()
+	}
+
+	typ := p.next()
+	if typ == "map" {
+		var b strings.Builder
+		b.WriteString("map")
+		for token := p.next(); ; token = p.next() {
+			if token == "" {
+				return fmt.Errorf("message %s: invalid map field", path)
+			}
+			b.WriteString(token)
+			if token == ">" {
+				break
+			}
+		}
+		typ = b.String()
+	}
+
+	name := p.next()
+	if err := p.expect("="); err != nil {
+		return fmt.Errorf("field %s.%s: %w", path, name, err)
+	}
+	number, err := strconv.Atoi(p.next())
+	if err != nil {
+		return fmt.Errorf("field %s.%s: invalid field number", path, name)
+	}
+	if err := p.skipStatement(); err != nil {
+		return err
+	}
+
+	if label == "repeated" {
+		typ = "repeated " + typ
+	}
+	p.s.add(&element{path: path + "." + name, kind: kindField, typ: typ, number: number, required: label == "required"})
+	return nil
+}
+
+func (p *protoParser) parseEnum(scope string) error {
+	name := p.next()
+	path := qualify(scope, name)
+	if err := p.expect("{"); err != nil {
+		return fmt.Errorf("enum %s: %w", name, err)
+	}
+	p.s.add(&element{path: path, kind: kindEnum, typ: "enum"})
+
+	for {
+		token := p.next()
+		switch token {
+		case "":
+			return fmt.Errorf("enum %s: unbalanced braces", path)
+		case "}":
+			return nil
+		case ";":
+		case "option", "reserved":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			if err := p.expect("="); err != nil {
+				return fmt.Errorf("enum value %s.%s: %w", path, token, err)
+			}
+			number, err := strconv.Atoi(p.next())
+			if err != nil {
+				return fmt.Errorf("enum value %s.%s: invalid number", path, token)
+			}
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+			p.s.add(&element{path: path + "." + token, kind: kindEnumValue, typ: "enum_value", number: number})
+		}
+	}
+}
@@ -84,26 +84,35 @@ func (v *Validator) ValidateCompatibility(ctx context.Context, newSchema *models
 		return result
 	}
 
-	// Validar compatibilidade baseada no tipo
-	switch config.Compatibility {
-	case models.CompatibilityBackward:
-		return v.validateBackwardCompatibility(previousSchema, newSchema)
-	case models.CompatibilityForward:
-		return v.validateForwardCompatibility(previousSchema, newSchema)
-	case models.CompatibilityFull:
-		backwardResult := v.validateBackwardCompatibility(previousSchema, newSchema)
-		forwardResult := v.validateForwardCompatibility(previousSchema, newSchema)
-
-		if !backwardResult.Valid || !forwardResult.Valid {
-			result.Valid = false
-			result.Errors = append(result.Errors, backwardResult.Errors...)
-			result.Errors = append(result.Errors, forwardResult.Errors...)
+	return v.ValidateAgainst(config.Compatibility, newSchema, previousSchema)
+}
+
+// ValidateAgainst compara o schema com as versões informadas no modo de compatibilidade dado
+func (v *Validator) ValidateAgainst(mode string, newSchema *models.Schema, previous ...*models.Schema) *models.SchemaValidationResult {
+	result := &models.SchemaValidationResult{Valid: true}
+
+	for _, previousSchema := range previous {
+		switch mode {
+		case models.CompatibilityBackward:
+			mergeResult(result, v.validateBackwardCompatibility(previousSchema, newSchema))
+		case models.CompatibilityForward:
+			mergeResult(result, v.validateForwardCompatibility(previousSchema, newSchema))
+		case models.CompatibilityFull:
+			mergeResult(result, v.validateBackwardCompatibility(previousSchema, newSchema))
+			mergeResult(result, v.validateForwardCompatibility(previousSchema, newSchema))
 		}
 	}
 
 	return result
 }
 
+func mergeResult(result, other *models.SchemaValidationResult) {
+	result.Valid = result.Valid && other.Valid
+	result.Errors = append(result.Errors, other.Errors...)
+	result.Warnings = append(result.Warnings, other.Warnings...)
+	result.Incompatibilities = append(result.Incompatibilities, other.Incompatibilities...)
+}
+
 // ValidateData valida dados contra um schema
 func (v *Validator) ValidateData(ctx context.Context, subject string, version int, data interface{}) *models.SchemaValidationResult {
 	result := &models.SchemaValidationResult{Valid: true}
@@ -116,6 +125,13 @@ func (v *Validator) ValidateData(ctx context.Context, subject string, version in
 		return result
 	}
 
+	return v.ValidateDataAgainst(schema, data)
+}
+
+// ValidateDataAgainst valida dados contra um schema informado, registrado ou não
+func (v *Validator) ValidateDataAgainst(schema *models.Schema, data interface{}) *models.SchemaValidationResult {
+	result := &models.SchemaValidationResult{Valid: true}
+
 	// Validar dados baseado no tipo de schema
 	switch schema.SchemaType {
 	case models.SchemaTypeJSON:
@@ -132,8 +148,8 @@ func (v *Validator) ValidateData(ctx context.Context, subject string, version in
 
 // Validações específicas de implementação
 func (v *Validator) validateJSONSchema(schemaContent string) error {
-	var schema map[string]interface{}
-	return json.Unmarshal([]byte(schemaContent), &schema)
+	_, err := parseJSONSchema(schemaContent)
+	return err
 }
 
 func (v *Validator) validateAvroSchema(schemaContent string) error {
@@ -148,7 +164,9 @@ func (v *Validator) validateAvroSchema(schemaContent string) error {
 		return fmt.Errorf("avro schema must have 'type' field")
 	}
 
-	return nil
+	// Mesma estrutura usada no diff: nomes de tipos e campos
+	_, err := parseAvro(schemaContent)
+	return err
 }
 
 func (v *Validator) validateProtobufSchema(schemaContent string) error {
@@ -159,7 +177,8 @@ func (v *Validator) validateProtobufSchema(schemaContent string) error {
 	if !strings.Contains(schemaContent, "message") {
 		return fmt.Errorf("protobuf schema must contain at least one message")
 	}
-	return nil
+	_, err := parseProtobuf(schemaContent)
+	return err
 }
 
 func (v *Validator) validateJSONData(schemaContent string, data interface{}) error {
@@ -178,22 +197,103 @@ func (v *Validator) validateJSONData(schemaContent string, data interface{}) err
 	return nil
 }
 
+// validateBackwardCompatibility o novo schema deve ler dados escritos com o antigo
 func (v *Validator) validateBackwardCompatibility(oldSchema, newSchema *models.Schema) *models.SchemaValidationResult {
-	result := &models.SchemaValidationResult{Valid: true}
-	// Implementar lógica de compatibilidade backward
-	// Novo schema pode ler dados escritos com old schema
-	result.Warnings = append(result.Warnings, "Backward compatibility check not fully implemented")
-	return result
+	return checkRule(models.CompatibilityBackward, oldSchema, newSchema)
 }
 
+// validateForwardCompatibility o schema antigo deve ler dados escritos com o novo
 func (v *Validator) validateForwardCompatibility(oldSchema, newSchema *models.Schema) *models.SchemaValidationResult {
+	return checkRule(models.CompatibilityForward, oldSchema, newSchema)
+}
+
+// checkRule reporta as alterações do diff que não preservam a direção rule
+func checkRule(rule string, oldSchema, newSchema *models.Schema) *models.SchemaValidationResult {
 	result := &models.SchemaValidationResult{Valid: true}
-	// Implementar lógica de compatibilidade forward
-	// Old schema pode ler dados escritos com novo schema
-	result.Warnings = append(result.Warnings, "Forward compatibility check not fully implemented")
+
+	diff, err := Diff(oldSchema, newSchema)
+	if err != nil {
+		// Sem comparação não há como garantir a regra; só o modo NONE (que não chega aqui) dispensa
+		result.Valid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("Could not check %s compatibility with version %d: %v", rule, oldSchema.Version, err))
+		return result
+	}
+
+	for _, change := range diff.Changes {
+		if change.Compatibility == models.CompatibilityFull || change.Compatibility == rule {
+			continue
+		}
+		incompatibility := models.SchemaIncompatibility{
+			Rule:    rule,
+			Type:    change.Type,
+			Path:    change.Path,
+			Old:     change.Old,
+			New:     change.New,
+			Version: oldSchema.Version,
+			Message: fmt.Sprintf("%s at %s breaks %s compatibility with version %d", change.Type, change.Path, rule, oldSchema.Version),
+		}
+		result.Valid = false
+		result.Errors = append(result.Errors, incompatibility.Message)
+		result.Incompatibilities = append(result.Incompatibilities, incompatibility)
+	}
 	return result
 }
 
+// avroOnlyTypes tipos que não existem no JSON Schema
+var avroOnlyTypes = map[string]bool{
+	"record": true, "enum": true, "fixed": true, "map": true,
+	"int": true, "long": true, "float": true, "double": true, "bytes": true,
+}
+
+// jsonOnlyTypes tipos que não existem no Avro
+var jsonOnlyTypes = map[string]bool{"object": true, "integer": true, "number": true}
+
+// DetectSchemaType identifica o formato pelo conteúdo. Quando o conteúdo é válido em mais de um
+// formato (ex: {"type":"string"}) retorna fallback, ou JSON se fallback for vazio.
+func DetectSchemaType(content, fallback string) string {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+		if strings.Contains(content, "syntax") || strings.Contains(content, "message") {
+			return models.SchemaTypeProtobuf
+		}
+		return orDefault(fallback)
+	}
+
+	switch value := parsed.(type) {
+	case string:
+		// Tipo primitivo do Avro, ex: "string"
+		return models.SchemaTypeAVRO
+	case []interface{}:
+		// União do Avro
+		return models.SchemaTypeAVRO
+	case map[string]interface{}:
+		for _, key := range []string{"$schema", "$defs", "definitions", "properties", "$ref"} {
+			if _, ok := value[key]; ok {
+				return models.SchemaTypeJSON
+			}
+		}
+		if _, ok := value["fields"]; ok {
+			return models.SchemaTypeAVRO
+		}
+		if typ, ok := value["type"].(string); ok {
+			switch {
+			case avroOnlyTypes[typ]:
+				return models.SchemaTypeAVRO
+			case jsonOnlyTypes[typ]:
+				return models.SchemaTypeJSON
+			}
+		}
+	}
+	return orDefault(fallback)
+}
+
+func orDefault(schemaType string) string {
+	if schemaType == "" {
+		return models.SchemaTypeJSON
+	}
+	return schemaType
+}
+
 func isValidSubject(subject string) bool {
 	// Validar formato do subject (ex: team.service.entity)
 	pattern := `^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)*$`
@@ -0,0 +1,111 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+)
+
+func TestValidateAgainst(t *testing.T) {
+	v1 := &models.Schema{Version: 1, SchemaType: models.SchemaTypeAVRO,
+		Schema: `{"type":"record","name":"User","fields":[{"name":"id","type":"string"}]}`}
+	v2 := &models.Schema{Version: 2, SchemaType: models.SchemaTypeAVRO,
+		Schema: `{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"age","type":"int","default":0}]}`}
+
+	tests := []struct {
+		name      string
+		mode      string
+		schema    string
+		previous  []*models.Schema
+		wantValid bool
+		wantRules []string
+	}{
+		{
+			name:      "should accept field added with default in full mode",
+			mode:      models.CompatibilityFull,
+			schema:    `{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"age","type":"int","default":0}]}`,
+			previous:  []*models.Schema{v1},
+			wantValid: true,
+		},
+		{
+			name:      "should reject field added without default in backward mode",
+			mode:      models.CompatibilityBackward,
+			schema:    `{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"email","type":"string"}]}`,
+			previous:  []*models.Schema{v1},
+			wantRules: []string{models.CompatibilityBackward},
+		},
+		{
+			name:      "should accept field added without default in forward mode",
+			mode:      models.CompatibilityForward,
+			schema:    `{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"email","type":"string"}]}`,
+			previous:  []*models.Schema{v1},
+			wantValid: true,
+		},
+		{
+			name:      "should report each violated rule in full mode",
+			mode:      models.CompatibilityFull,
+			schema:    `{"type":"record","name":"User","fields":[{"name":"id","type":"int"}]}`,
+			previous:  []*models.Schema{v1},
+			wantRules: []string{models.CompatibilityBackward, models.CompatibilityForward},
+		},
+		{
+			name:      "should check every previous version",
+			mode:      models.CompatibilityForward,
+			schema:    `{"type":"record","name":"User","fields":[{"name":"id","type":"string"},{"name":"age","type":"long","default":0}]}`,
+			previous:  []*models.Schema{v1, v2},
+			wantRules: []string{models.CompatibilityForward},
+		},
+		{
+			name:      "should skip checks in none mode",
+			mode:      models.CompatibilityNone,
+			schema:    `{"type":"record","name":"User","fields":[{"name":"id","type":"int"}]}`,
+			previous:  []*models.Schema{v1},
+			wantValid: true,
+		},
+	}
+
+	validator := &Validator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &models.Schema{Version: 3, SchemaType: models.SchemaTypeAVRO, Schema: tt.schema}
+			result := validator.ValidateAgainst(tt.mode, schema, tt.previous...)
+
+			if result.Valid != tt.wantValid {
+				t.Fatalf("valid = %v, want %v: %v", result.Valid, tt.wantValid, result.Errors)
+			}
+			if len(result.Incompatibilities) != len(tt.wantRules) || len(result.Errors) != len(tt.wantRules) {
+				t.Fatalf("incompatibilities = %+v, want rules %v", result.Incompatibilities, tt.wantRules)
+			}
+			for i, rule := range tt.wantRules {
+				if result.Incompatibilities[i].Rule != rule {
+					t.Errorf("incompatibility %d rule = %s, want %s", i, result.Incompatibilities[i].Rule, rule)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectSchemaType(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		fallback string
+		want     string
+	}{
+		{name: "should detect avro record", content: `{"type":"record","name":"A","fields":[]}`, want: models.SchemaTypeAVRO},
+		{name: "should detect avro union", content: `["null","string"]`, want: models.SchemaTypeAVRO},
+		{name: "should detect json schema object", content: `{"type":"object"}`, want: models.SchemaTypeJSON},
+		{name: "should detect json schema by keyword", content: `{"$schema":"https://json-schema.org/draft/2020-12/schema"}`, want: models.SchemaTypeJSON},
+		{name: "should detect protobuf", content: `syntax = "proto3"; message A {}`, want: models.SchemaTypeProtobuf},
+		{name: "should use fallback when ambiguous", content: `{"type":"string"}`, fallback: models.SchemaTypeAVRO, want: models.SchemaTypeAVRO},
+		{name: "should default to json when ambiguous", content: `{"type":"string"}`, want: models.SchemaTypeJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectSchemaType(tt.content, tt.fallback); got != tt.want {
+				t.Errorf("DetectSchemaType() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config caminhos e parâmetros de um listener TLS
+type Config struct {
+	CertFile   string
+	KeyFile    string
+	CAFile     string
+	MinVersion string
+	ClientAuth string
+}
+
+// Enabled indica se há certificado configurado
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// Reloader mantém a configuração TLS atual e a recarrega do disco sem derrubar conexões:
+// o handshake de cada nova conexão usa a configuração vigente, as abertas não são afetadas.
+type Reloader struct {
+	config     Config
+	minVersion uint16
+	clientAuth tls.ClientAuthType
+
+	mu       sync.RWMutex
+	current  *tls.Config
+	modTimes map[string]time.Time
+}
+
+func NewReloader(config Config) (*Reloader, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, fmt.Errorf("both cert and key files are required")
+	}
+
+	minVersion, err := ParseMinVersion(config.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	clientAuth, err := ParseClientAuth(config.ClientAuth)
+	if err != nil {
+		return nil, err
+	}
+	if clientAuth >= tls.VerifyClientCertIfGiven && config.CAFile == "" {
+		return nil, fmt.Errorf("client auth %q requires a CA file", config.ClientAuth)
+	}
+
+	r := &Reloader{config: config, minVersion: minVersion, clientAuth: clientAuth}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TLSConfig retorna a configuração a ser usada pelo listener
+func (r *Reloader) TLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: r.minVersion,
+		ClientAuth: r.clientAuth,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			config := r.current.Clone()
+			r.mu.RUnlock()
+
+			config.NextProtos = nextProtos
+			return config, nil
+		},
+	}
+}
+
+// Reload relê certificado, chave e CA. Em caso de erro a configuração anterior é mantida.
+func (r *Reloader) Reload() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   r.minVersion,
+		ClientAuth:   r.clientAuth,
+	}
+
+	if r.config.CAFile != "" {
+		data, err := os.ReadFile(r.config.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in CA file %s", r.config.CAFile)
+		}
+		config.ClientCAs = pool
+	}
+
+	r.mu.Lock()
+	r.current = config
+	r.modTimes = modTimes
+	r.mu.Unlock()
+	return nil
+}
+
+// Watch verifica periodicamente se os arquivos mudaram e recarrega quando necessário
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			if err := r.Reload(); err != nil {
+				log.Printf("Warning: failed to reload TLS certificate %s: %v", r.config.CertFile, err)
+				continue
+			}
+			log.Printf("TLS certificate reloaded: %s", r.config.CertFile)
+		}
+	}
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.CAFile != "" {
+		files = append(files, r.config.CAFile)
+	}
+	return files
+}
+
+func (r *Reloader) stat() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", file, err)
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (r *Reloader) changed() bool {
+	modTimes, err := r.stat()
+	if err != nil {
+		// Arquivo ausente durante a rotação: tenta de novo no próximo ciclo
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for file, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMinVersion converte "1.2"/"1.3" (padrão 1.2)
+func ParseMinVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS min version: %s", version)
+}
+
+// ParseClientAuth converte o modo de autenticação por certificado de cliente (padrão none)
+func ParseClientAuth(mode string) (tls.ClientAuthType, error) {
+	switch strings.ToLower(mode) {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.RequestClientCert, nil
+	case "require":
+		return tls.RequireAnyClientCert, nil
+	case "verify-if-given":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require-and-verify":
+		return tls.RequireAndVerifyClientCert, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS client auth mode: %s", mode)
+}
@@ -0,0 +1,161 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCertificate(t *testing.T, dir, commonName string) Config {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := Config{
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	for file, data := range map[string][]byte{config.CertFile: certPEM, config.KeyFile: keyPEM, config.CAFile: certPEM} {
+		if err := os.WriteFile(file, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return config
+}
+
+func servedCommonName(t *testing.T, r *Reloader) string {
+	t.Helper()
+
+	config, err := r.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert.Subject.CommonName
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	config := writeCertificate(t, dir, "first")
+	config.ClientAuth = "require-and-verify"
+
+	r, err := NewReloader(config)
+	if err != nil {
+		t.Fatalf("failed to create reloader: %v", err)
+	}
+	if got := servedCommonName(t, r); got != "first" {
+		t.Fatalf("expected first certificate, got %s", got)
+	}
+	if r.changed() {
+		t.Fatal("expected no change before rotation")
+	}
+
+	// Rotação: novos arquivos com mtime diferente
+	writeCertificate(t, dir, "second")
+	future := time.Now().Add(time.Minute)
+	for _, file := range []string{config.CertFile, config.KeyFile, config.CAFile} {
+		if err := os.Chtimes(file, future, future); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !r.changed() {
+		t.Fatal("expected change after rotation")
+	}
+	if err := r.Reload(); err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if got := servedCommonName(t, r); got != "second" {
+		t.Fatalf("expected second certificate, got %s", got)
+	}
+
+	// Arquivo inválido mantém a configuração anterior
+	if err := os.WriteFile(config.CertFile, []byte("invalid"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected reload error for invalid certificate")
+	}
+	if got := servedCommonName(t, r); got != "second" {
+		t.Fatalf("expected previous certificate to be kept, got %s", got)
+	}
+}
+
+func TestNewReloaderValidation(t *testing.T) {
+	dir := t.TempDir()
+	valid := writeCertificate(t, dir, "registry")
+
+	tests := []struct {
+		name    string
+		modify  func(c Config) Config
+		wantErr bool
+	}{
+		{
+			name:   "should accept TLS 1.3 without client auth",
+			modify: func(c Config) Config { c.MinVersion = "1.3"; return c },
+		},
+		{
+			name:    "should reject unsupported min version",
+			modify:  func(c Config) Config { c.MinVersion = "1.0"; return c },
+			wantErr: true,
+		},
+		{
+			name:    "should reject unknown client auth mode",
+			modify:  func(c Config) Config { c.ClientAuth = "optional"; return c },
+			wantErr: true,
+		},
+		{
+			name:    "should require CA file to verify clients",
+			modify:  func(c Config) Config { c.ClientAuth = "require-and-verify"; c.CAFile = ""; return c },
+			wantErr: true,
+		},
+		{
+			name:    "should require key file",
+			modify:  func(c Config) Config { c.KeyFile = ""; return c },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReloader(tt.modify(valid))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewReloader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
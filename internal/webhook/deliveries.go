@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// DeliveryStreamName stream dedicado ao log de entregas dos webhooks
+	DeliveryStreamName = "SCHEMA_WEBHOOK_DELIVERIES"
+	// DeliverySubjectPrefix prefixo dos subjects NATS do log, seguido do ID do webhook
+	DeliverySubjectPrefix = "schema.webhooks.deliveries."
+
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// DeliveryStreamConfig configuração do stream do log de entregas (maxAge 0 = sem expiração)
+func DeliveryStreamConfig(maxAge time.Duration) *nats.StreamConfig {
+	return &nats.StreamConfig{
+		Name:      DeliveryStreamName,
+		Subjects:  []string{DeliverySubjectPrefix + ">"},
+		Storage:   nats.FileStorage,
+		Retention: nats.LimitsPolicy,
+		MaxAge:    maxAge,
+		Replicas:  1,
+	}
+}
+
+// DeliveryLog registra cada tentativa de entrega no stream
+type DeliveryLog struct {
+	js nats.JetStreamContext
+}
+
+func NewDeliveryLog(js nats.JetStreamContext) *DeliveryLog {
+	return &DeliveryLog{js: js}
+}
+
+// Record persiste uma tentativa de entrega
+func (l *DeliveryLog) Record(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if delivery.Timestamp.IsZero() {
+		delivery.Timestamp = time.Now()
+	}
+
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook delivery: %w", err)
+	}
+
+	if _, err := l.js.Publish(DeliverySubjectPrefix+delivery.WebhookID, data, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("failed to publish webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// Query lista as tentativas de entrega de um webhook em ordem cronológica, paginadas pela sequência do stream
+func (l *DeliveryLog) Query(ctx context.Context, webhookID string, after uint64, limit int) (*models.WebhookDeliveryPage, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	filter := DeliverySubjectPrefix + webhookID
+	page := &models.WebhookDeliveryPage{Deliveries: []models.WebhookDelivery{}}
+
+	// A última tentativa registrada delimita a leitura
+	last, err := l.js.GetLastMsg(DeliveryStreamName, filter, nats.Context(ctx))
+	if err != nil {
+		if errors.Is(err, nats.ErrMsgNotFound) {
+			return page, nil
+		}
+		return nil, fmt.Errorf("failed to get last webhook delivery: %w", err)
+	}
+	if last.Sequence <= after {
+		return page, nil
+	}
+
+	opts := []nats.SubOpt{nats.OrderedConsumer(), nats.BindStream(DeliveryStreamName)}
+	if after > 0 {
+		opts = append(opts, nats.StartSequence(after+1))
+	} else {
+		opts = append(opts, nats.DeliverAll())
+	}
+
+	sub, err := l.js.SubscribeSync(filter, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to webhook deliveries: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	for len(page.Deliveries) < limit {
+		msg, err := sub.NextMsgWithContext(readCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook deliveries: %w", err)
+		}
+
+		meta, err := msg.Metadata()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook delivery metadata: %w", err)
+		}
+
+		var delivery models.WebhookDelivery
+		if err := json.Unmarshal(msg.Data, &delivery); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webhook delivery: %w", err)
+		}
+		delivery.Sequence = meta.Sequence.Stream
+		page.Deliveries = append(page.Deliveries, delivery)
+
+		if delivery.Sequence >= last.Sequence {
+			return page, nil
+		}
+	}
+
+	page.NextCursor = page.Deliveries[len(page.Deliveries)-1].Sequence
+	return page, nil
+}
@@ -0,0 +1,386 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/acl"
+	"github.com/rodrigues-daniel/data-platform/internal/events"
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Cabeçalhos enviados em cada entrega
+	SignatureHeader = "X-Schema-Registry-Signature"
+	TimestampHeader = "X-Schema-Registry-Timestamp"
+	EventHeader     = "X-Schema-Registry-Event"
+	DeliveryHeader  = "X-Schema-Registry-Delivery"
+	AttemptHeader   = "X-Schema-Registry-Attempt"
+
+	// consumerPrefix prefixo dos consumers duráveis, um por webhook
+	consumerPrefix = "webhook-"
+
+	// reconcileInterval intervalo de verificação dos workers, além das notificações do store
+	reconcileInterval = 30 * time.Second
+
+	// maxResponseBody bytes lidos da resposta do destino antes de descartá-la
+	maxResponseBody = 64 << 10
+)
+
+// Deliveries tentativas de entrega por resultado
+var Deliveries = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "schema_registry_webhook_deliveries_total",
+		Help: "Tentativas de entrega de eventos de schema a webhooks.",
+	},
+	[]string{"status"},
+)
+
+// Config política de entrega dos webhooks
+type Config struct {
+	// MaxAttempts tentativas por evento antes de desistir dele
+	MaxAttempts int
+	// BaseBackoff espera antes da segunda tentativa; dobra a cada nova falha até MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// DisableAfter tentativas consecutivas com falha que desabilitam o webhook (0 = nunca)
+	DisableAfter int
+	// Timeout limite de cada requisição ao destino
+	Timeout time.Duration
+}
+
+// DefaultConfig política padrão: 8 tentativas por evento (de 1s a 5min) e desabilitação após 20 falhas seguidas
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts:  8,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   5 * time.Minute,
+		DisableAfter: 20,
+		Timeout:      10 * time.Second,
+	}
+}
+
+// backoff espera antes da próxima tentativa, após a falha da tentativa attempt
+func (c Config) backoff(attempt int) time.Duration {
+	delay := c.BaseBackoff
+	for i := 1; i < attempt && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	return delay
+}
+
+// Sign assinatura HMAC-SHA256 de uma entrega: sha256=hex(HMAC(secret, "<timestamp>.<body>"))
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Matches indica se o webhook recebe o evento (tipo e padrão de subjects)
+func Matches(webhook *models.Webhook, event *models.SchemaEvent) bool {
+	if len(webhook.EventTypes) > 0 {
+		found := false
+		for _, eventType := range webhook.EventTypes {
+			if eventType == event.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return acl.MatchPattern(webhook.Subjects, event.Subject)
+}
+
+// worker entrega os eventos de um webhook
+type worker struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Dispatcher entrega os eventos do stream SCHEMA_EVENTS aos webhooks habilitados.
+// Cada webhook tem um consumer durável próprio com um evento pendente por vez,
+// preservando a ordem e isolando destinos lentos ou fora do ar.
+type Dispatcher struct {
+	js         nats.JetStreamContext
+	store      *Store
+	deliveries *DeliveryLog
+	client     *http.Client
+	config     Config
+
+	mu      sync.Mutex
+	workers map[string]*worker
+}
+
+func NewDispatcher(js nats.JetStreamContext, store *Store, deliveries *DeliveryLog, config Config) *Dispatcher {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 1
+	}
+
+	// Cada conexão, inclusive as de redirecionamentos, passa pela política de redes do store
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: config.Timeout, Control: store.dialControl}).DialContext
+	return &Dispatcher{
+		js:         js,
+		store:      store,
+		deliveries: deliveries,
+		client:     &http.Client{Timeout: config.Timeout, Transport: transport},
+		config:     config,
+		workers:    make(map[string]*worker),
+	}
+}
+
+// Run mantém um worker por webhook habilitado até ctx ser cancelado
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		d.reconcile(ctx)
+
+		select {
+		case <-ctx.Done():
+			d.stopAll()
+			return
+		case <-d.store.Changes():
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile inicia workers de webhooks habilitados, encerra os demais e remove
+// os consumers de webhooks apagados
+func (d *Dispatcher) reconcile(ctx context.Context) {
+	known := make(map[string]bool)
+	enabled := make(map[string]bool)
+	for _, webhook := range d.store.List() {
+		known[webhook.ID] = true
+		enabled[webhook.ID] = webhook.Enabled
+	}
+
+	d.mu.Lock()
+	for id, w := range d.workers {
+		select {
+		case <-w.done:
+			delete(d.workers, id)
+			continue
+		default:
+		}
+		if !enabled[id] {
+			w.cancel()
+			delete(d.workers, id)
+		}
+	}
+	for id := range enabled {
+		if _, running := d.workers[id]; running || !enabled[id] {
+			continue
+		}
+		workerCtx, cancel := context.WithCancel(ctx)
+		w := &worker{cancel: cancel, done: make(chan struct{})}
+		d.workers[id] = w
+		go func(id string) {
+			defer close(w.done)
+			d.work(workerCtx, id)
+		}(id)
+	}
+	d.mu.Unlock()
+
+	// Webhooks desabilitados mantêm o consumer e retomam do ponto em que pararam
+	for name := range d.js.ConsumerNames(schema.EventStreamName) {
+		id, ok := strings.CutPrefix(name, consumerPrefix)
+		if ok && !known[id] {
+			if err := events.Remove(d.js, name); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) stopAll() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id, w := range d.workers {
+		w.cancel()
+		<-w.done
+		delete(d.workers, id)
+	}
+}
+
+// work lê os eventos do consumer do webhook e os entrega, um de cada vez
+func (d *Dispatcher) work(ctx context.Context, id string) {
+	webhook, err := d.store.Get(id)
+	if err != nil {
+		return
+	}
+
+	// O consumer é criado no primeiro start: recebe os eventos publicados após o cadastro
+	consumer, err := events.NewConsumer(d.js, events.ConsumerConfig{
+		Durable:       consumerPrefix + id,
+		StartTime:     webhook.CreatedAt,
+		MaxAckPending: 1,
+		AckWait:       d.config.Timeout + 30*time.Second,
+	})
+	if err != nil {
+		log.Printf("Warning: webhook %s: %v", id, err)
+		return
+	}
+	defer consumer.Close()
+
+	for ctx.Err() == nil {
+		fetchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		batch, err := consumer.Fetch(fetchCtx, 1)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Warning: webhook %s: %v", id, err)
+			}
+			return
+		}
+
+		for _, event := range batch {
+			if !d.process(ctx, id, event) {
+				return
+			}
+		}
+	}
+}
+
+// process entrega um evento e decide entre ack, nova tentativa com backoff ou desistência;
+// retorna false quando o worker deve parar (webhook removido, desabilitado ou shutdown)
+func (d *Dispatcher) process(ctx context.Context, id string, event *events.Event) bool {
+	webhook, err := d.store.Get(id)
+	if err != nil || !webhook.Enabled {
+		event.Nak()
+		return false
+	}
+	if !Matches(webhook, &event.SchemaEvent) {
+		event.Ack()
+		return true
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:     id,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		EventSequence: event.Sequence,
+		Subject:       event.Subject,
+		Attempt:       event.Attempt,
+	}
+
+	start := time.Now()
+	statusCode, deliverErr := d.deliver(ctx, webhook, event)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	delivery.StatusCode = statusCode
+
+	// Interrompido pelo shutdown: o evento é reentregue no próximo start, sem contar como falha
+	if ctx.Err() != nil {
+		event.Nak()
+		return false
+	}
+
+	keepRunning := true
+	switch {
+	case deliverErr == nil:
+		delivery.Status = models.DeliverySuccess
+		event.Ack()
+		if err := d.store.recordSuccess(id); err != nil {
+			log.Printf("Warning: webhook %s: %v", id, err)
+		}
+	default:
+		delivery.Error = deliverErr.Error()
+		updated, err := d.store.recordFailure(id, delivery.Error, d.config.DisableAfter)
+		if err != nil {
+			log.Printf("Warning: webhook %s: %v", id, err)
+		}
+
+		switch {
+		case updated != nil && !updated.Enabled:
+			// Evento mantido no consumer e reenviado quando o webhook for reabilitado
+			delivery.Status = models.DeliveryRetrying
+			event.Nak()
+			keepRunning = false
+			log.Printf("Warning: webhook %s disabled: %s", id, updated.DisabledReason)
+		case event.Attempt >= d.config.MaxAttempts:
+			delivery.Status = models.DeliveryFailed
+			event.Ack()
+		default:
+			delay := d.config.backoff(event.Attempt)
+			next := time.Now().Add(delay)
+			delivery.Status = models.DeliveryRetrying
+			delivery.NextAttemptAt = &next
+			event.Retry(delay)
+		}
+	}
+
+	Deliveries.WithLabelValues(delivery.Status).Inc()
+
+	// O registro não depende do worker, que pode ser encerrado pela própria desabilitação
+	recordCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.deliveries.Record(recordCtx, delivery); err != nil {
+		log.Printf("Warning: webhook %s: %v", id, err)
+	}
+	return keepRunning
+}
+
+// deliver envia o evento ao destino; respostas fora de 2xx são falhas
+func (d *Dispatcher) deliver(ctx context.Context, webhook *models.Webhook, event *events.Event) (int, error) {
+	// Webhooks cadastrados antes da exigência de secret não recebem entregas sem assinatura
+	if webhook.Secret == "" {
+		return 0, fmt.Errorf("webhook has no secret, set one to resume deliveries")
+	}
+
+	body, err := json.Marshal(&event.SchemaEvent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "schema-registry-webhook")
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(DeliveryHeader, event.ID)
+	req.Header.Set(AttemptHeader, strconv.Itoa(event.Attempt))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,318 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+	"github.com/rodrigues-daniel/data-platform/internal/natstest"
+	"github.com/rodrigues-daniel/data-platform/internal/schema"
+
+	"github.com/nats-io/nats.go"
+)
+
+func newTestJetStream(t *testing.T) (nats.JetStreamContext, nats.KeyValue) {
+	t.Helper()
+	env := natstest.Start(t, natstest.Options{
+		Streams: []*nats.StreamConfig{schema.EventStreamConfig(schema.EventRetention{}), DeliveryStreamConfig(0)},
+		Bucket:  "schemadb",
+	})
+	return env.JS, env.KV
+}
+
+// receiver destino de teste que responde com os status de responses (o último se repete)
+type receiver struct {
+	mu        sync.Mutex
+	responses []int
+	requests  []*http.Request
+	bodies    [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	status := r.responses[0]
+	if len(r.responses) > 1 {
+		r.responses = r.responses[1:]
+	}
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	r.mu.Unlock()
+
+	w.WriteHeader(status)
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+// startDispatcher inicia store e dispatcher com backoff curto para os testes
+func startDispatcher(t *testing.T, js nats.JetStreamContext, kv nats.KeyValue, config Config) (*Store, *DeliveryLog) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	store := NewStore(kv)
+	// Os destinos dos testes são servidores httptest em loopback
+	store.SetAllowedNetworks([]netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")})
+	if err := store.Start(ctx); err != nil {
+		t.Fatalf("failed to start store: %v", err)
+	}
+	deliveries := NewDeliveryLog(js)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewDispatcher(js, store, deliveries, config).Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return store, deliveries
+}
+
+func publishEvent(t *testing.T, js nats.JetStreamContext, event models.SchemaEvent) {
+	t.Helper()
+
+	data, _ := json.Marshal(event)
+	if _, err := js.Publish(schema.EventSubjectPrefix+event.Subject, data, nats.MsgId(event.ID)); err != nil {
+		t.Fatalf("failed to publish %s: %v", event.ID, err)
+	}
+}
+
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDispatcherDelivery(t *testing.T) {
+	js, kv := newTestJetStream(t)
+	target := &receiver{responses: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK}}
+	endpoint := httptest.NewServer(target)
+	defer endpoint.Close()
+
+	config := Config{MaxAttempts: 5, BaseBackoff: 20 * time.Millisecond, MaxBackoff: time.Second, DisableAfter: 10, Timeout: time.Second}
+	store, deliveries := startDispatcher(t, js, kv, config)
+
+	webhook, err := store.Create(&models.WebhookRequest{URL: endpoint.URL, Subjects: "payments.*", Secret: "s3cret"})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	// Eventos fora do padrão de subjects são ignorados; a entrega segue a ordem do stream
+	publishEvent(t, js, models.SchemaEvent{ID: "users-1", Type: models.EventSchemaCreated, Subject: "users"})
+	publishEvent(t, js, models.SchemaEvent{ID: "payments-1", Type: models.EventSchemaCreated, Subject: "payments.order"})
+	publishEvent(t, js, models.SchemaEvent{ID: "payments-2", Type: models.EventConfigChanged, Subject: "payments.order"})
+
+	waitFor(t, "deliveries", func() bool { return target.count() == 4 })
+
+	target.mu.Lock()
+	for i, want := range []string{"payments-1", "payments-1", "payments-1", "payments-2"} {
+		req := target.requests[i]
+		if got := req.Header.Get(DeliveryHeader); got != want {
+			t.Errorf("request %d: delivery = %s, want %s", i, got, want)
+		}
+		timestamp, _ := strconv.ParseInt(req.Header.Get(TimestampHeader), 10, 64)
+		if got := req.Header.Get(SignatureHeader); got != Sign("s3cret", timestamp, target.bodies[i]) {
+			t.Errorf("request %d: invalid signature %s", i, got)
+		}
+	}
+	if got := target.requests[2].Header.Get(AttemptHeader); got != "3" {
+		t.Errorf("expected third attempt header, got %s", got)
+	}
+	var event models.SchemaEvent
+	json.Unmarshal(target.bodies[3], &event)
+	if event.Type != models.EventConfigChanged || event.Sequence == 0 {
+		t.Errorf("unexpected payload: %s", target.bodies[3])
+	}
+	target.mu.Unlock()
+
+	var page *models.WebhookDeliveryPage
+	waitFor(t, "delivery log", func() bool {
+		page, err = deliveries.Query(context.Background(), webhook.ID, 0, 0)
+		return err == nil && len(page.Deliveries) == 4
+	})
+	wantStatus := []string{models.DeliveryRetrying, models.DeliveryRetrying, models.DeliverySuccess, models.DeliverySuccess}
+	for i, delivery := range page.Deliveries {
+		if delivery.Status != wantStatus[i] {
+			t.Errorf("delivery %d: status = %s, want %s", i, delivery.Status, wantStatus[i])
+		}
+	}
+	if page.Deliveries[0].StatusCode != http.StatusInternalServerError || page.Deliveries[0].NextAttemptAt == nil {
+		t.Errorf("expected failed attempt with next retry, got %+v", page.Deliveries[0])
+	}
+
+	// Sucesso zera o contador de falhas consecutivas
+	if current, _ := store.Get(webhook.ID); current.ConsecutiveFailures != 0 {
+		t.Errorf("expected failures reset after success, got %d", current.ConsecutiveFailures)
+	}
+}
+
+func TestDispatcherGivesUpAndDisables(t *testing.T) {
+	js, kv := newTestJetStream(t)
+	target := &receiver{responses: []int{http.StatusServiceUnavailable}}
+	endpoint := httptest.NewServer(target)
+	defer endpoint.Close()
+
+	config := Config{MaxAttempts: 2, BaseBackoff: 10 * time.Millisecond, MaxBackoff: time.Second, DisableAfter: 3, Timeout: time.Second}
+	store, deliveries := startDispatcher(t, js, kv, config)
+
+	webhook, err := store.Create(&models.WebhookRequest{URL: endpoint.URL})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	publishEvent(t, js, models.SchemaEvent{ID: "1", Type: models.EventSchemaCreated, Subject: "orders"})
+	publishEvent(t, js, models.SchemaEvent{ID: "2", Type: models.EventSchemaCreated, Subject: "orders"})
+
+	// Duas tentativas no evento 1 (desistência) e a terceira falha, no evento 2, desabilita
+	waitFor(t, "webhook disabled", func() bool {
+		current, _ := store.Get(webhook.ID)
+		return !current.Enabled
+	})
+	current, _ := store.Get(webhook.ID)
+	if current.ConsecutiveFailures != 3 || current.DisabledReason == "" {
+		t.Errorf("unexpected disabled webhook: %+v", current)
+	}
+
+	var page *models.WebhookDeliveryPage
+	waitFor(t, "delivery log", func() bool {
+		page, err = deliveries.Query(context.Background(), webhook.ID, 0, 0)
+		return err == nil && len(page.Deliveries) == 3
+	})
+	if page.Deliveries[1].EventID != "1" || page.Deliveries[1].Status != models.DeliveryFailed {
+		t.Errorf("expected event 1 to be given up, got %+v", page.Deliveries[1])
+	}
+
+	// Desabilitado, o webhook não recebe novas tentativas
+	time.Sleep(100 * time.Millisecond)
+	if got := target.count(); got != 3 {
+		t.Errorf("expected 3 requests while disabled, got %d", got)
+	}
+
+	// Reabilitado, retoma do evento pendente
+	target.mu.Lock()
+	target.responses = []int{http.StatusNoContent}
+	target.mu.Unlock()
+	enabled := true
+	if _, err := store.Update(webhook.ID, &models.WebhookRequest{Enabled: &enabled}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	waitFor(t, "redelivery", func() bool { return target.count() == 4 })
+	target.mu.Lock()
+	if got := target.requests[3].Header.Get(DeliveryHeader); got != "2" {
+		t.Errorf("expected pending event 2 after re-enable, got %s", got)
+	}
+	target.mu.Unlock()
+}
+
+func TestStoreValidation(t *testing.T) {
+	_, kv := newTestJetStream(t)
+	store := NewStore(kv)
+	store.SetAllowedNetworks([]netip.Prefix{netip.MustParsePrefix("192.168.10.0/24")})
+
+	tests := []struct {
+		name         string
+		request      models.WebhookRequest
+		wantErr      bool
+		wantSubjects string
+	}{
+		{name: "should default to all subjects", request: models.WebhookRequest{URL: "https://example.com/hook"}, wantSubjects: "*"},
+		{name: "should keep subject pattern", request: models.WebhookRequest{URL: "http://example.com", Subjects: "payments.*"}, wantSubjects: "payments.*"},
+		{name: "should reject missing url", request: models.WebhookRequest{}, wantErr: true},
+		{name: "should reject non http url", request: models.WebhookRequest{URL: "ftp://example.com"}, wantErr: true},
+		{name: "should reject unknown event type", request: models.WebhookRequest{URL: "https://example.com", EventTypes: []string{"SCHEMA_RENAMED"}}, wantErr: true},
+		{name: "should reject loopback", request: models.WebhookRequest{URL: "http://127.0.0.1:8080/hook"}, wantErr: true},
+		{name: "should reject localhost", request: models.WebhookRequest{URL: "http://localhost/hook"}, wantErr: true},
+		{name: "should reject private networks", request: models.WebhookRequest{URL: "http://10.1.2.3/hook"}, wantErr: true},
+		{name: "should reject metadata address", request: models.WebhookRequest{URL: "http://169.254.169.254/latest/meta-data"}, wantErr: true},
+		{name: "should reject ipv6 loopback", request: models.WebhookRequest{URL: "http://[::1]/hook"}, wantErr: true},
+		{name: "should allow listed networks", request: models.WebhookRequest{URL: "http://192.168.10.5/hook"}, wantSubjects: "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook, err := store.Create(&tt.request)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Fatalf("expected ErrInvalid, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("create failed: %v", err)
+			}
+			if webhook.Subjects != tt.wantSubjects || !webhook.Enabled || webhook.Secret == "" {
+				t.Errorf("unexpected webhook: %+v", webhook)
+			}
+		})
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	store := NewStore(nil)
+	store.SetAllowedNetworks([]netip.Prefix{netip.MustParsePrefix("10.20.0.0/16")})
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{name: "should allow public addresses", address: "93.184.216.34:443"},
+		{name: "should allow listed networks", address: "10.20.1.1:80"},
+		{name: "should block loopback", address: "127.0.0.1:80", wantErr: true},
+		{name: "should block other private networks", address: "10.21.1.1:80", wantErr: true},
+		{name: "should block metadata address", address: "169.254.169.254:80", wantErr: true},
+		{name: "should block ipv4-mapped loopback", address: "[::ffff:127.0.0.1]:80", wantErr: true},
+		{name: "should block unique local ipv6", address: "[fd00:ec2::254]:80", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := store.dialControl("tcp", tt.address, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("dialControl(%s) = %v, wantErr %v", tt.address, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook models.Webhook
+		event   models.SchemaEvent
+		want    bool
+	}{
+		{name: "should match all", webhook: models.Webhook{Subjects: "*"}, event: models.SchemaEvent{Type: models.EventSchemaCreated, Subject: "users"}, want: true},
+		{name: "should match pattern", webhook: models.Webhook{Subjects: "payments.*"}, event: models.SchemaEvent{Subject: "payments.order"}, want: true},
+		{name: "should skip other subjects", webhook: models.Webhook{Subjects: "payments.*"}, event: models.SchemaEvent{Subject: "users"}, want: false},
+		{name: "should filter event types", webhook: models.Webhook{Subjects: "*", EventTypes: []string{models.EventSchemaDeleted}}, event: models.SchemaEvent{Type: models.EventSchemaCreated, Subject: "users"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Matches(&tt.webhook, &tt.event); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// sharedAddressSpace faixa 100.64.0.0/10 (CGNAT), onde alguns provedores expõem o metadata
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// ParseNetworks interpreta uma lista de redes separadas por vírgula (CIDR ou IP isolado)
+func ParseNetworks(list string) ([]netip.Prefix, error) {
+	var networks []netip.Prefix
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			addr, err := netip.ParseAddr(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid network %q: %w", item, err)
+			}
+			networks = append(networks, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", item, err)
+		}
+		networks = append(networks, prefix.Masked())
+	}
+	return networks, nil
+}
+
+// internalAddress indica endereços que não devem receber entregas: loopback, redes privadas,
+// link-local (inclui o metadata 169.254.169.254), CGNAT, multicast e não especificados
+func internalAddress(addr netip.Addr) bool {
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() ||
+		addr.IsUnspecified() || sharedAddressSpace.Contains(addr)
+}
+
+// checkAddress recusa endereços internos fora das redes liberadas
+func (s *Store) checkAddress(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if !internalAddress(addr) {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, network := range s.allowedNetworks {
+		if network.Contains(addr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: address %s is internal and not in the allowed networks", ErrInvalid, addr)
+}
+
+// checkHost valida o host da URL na criação; nomes só são resolvidos na entrega (dialControl)
+func (s *Store) checkHost(host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return s.checkAddress(addr)
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return s.checkAddress(netip.AddrFrom4([4]byte{127, 0, 0, 1}))
+	}
+	return nil
+}
+
+// dialControl aplica a política ao endereço resolvido de cada conexão, cobrindo nomes que
+// apontam para redes internas e mudanças de DNS após o cadastro
+func (s *Store) dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("unexpected dial address %s: %w", address, err)
+	}
+	return s.checkAddress(addr)
+}
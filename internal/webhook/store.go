@@ -0,0 +1,348 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rodrigues-daniel/data-platform/internal/models"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	keyPrefix = "webhooks."
+
+	// maxUpdateRetries tentativas de atualização com conflito de revisão no KV
+	maxUpdateRetries = 5
+
+	// secretBytes tamanho do segredo gerado quando o pedido não informa um
+	secretBytes = 32
+)
+
+var (
+	ErrNotFound = errors.New("webhook not found")
+	ErrInvalid  = errors.New("invalid webhook")
+)
+
+// eventTypes tipos de evento aceitos no filtro de um webhook
+var eventTypes = map[string]bool{
+	models.EventSchemaCreated:     true,
+	models.EventSchemaSoftDeleted: true,
+	models.EventSchemaDeleted:     true,
+	models.EventConfigChanged:     true,
+	models.EventModeChanged:       true,
+	models.EventSubjectDeleted:    true,
+}
+
+// Store mantém os webhooks persistidos no KV, com cópia em memória atualizada via watcher
+type Store struct {
+	kv       nats.KeyValue
+	mu       sync.RWMutex
+	webhooks map[string]models.Webhook
+
+	// allowedNetworks redes internas liberadas como destino (padrão: nenhuma)
+	allowedNetworks []netip.Prefix
+
+	// changes sinaliza alterações para o dispatcher (buffer 1, sinais coalescidos)
+	changes chan struct{}
+}
+
+func NewStore(kv nats.KeyValue) *Store {
+	return &Store{kv: kv, webhooks: make(map[string]models.Webhook), changes: make(chan struct{}, 1)}
+}
+
+// SetAllowedNetworks libera redes internas (loopback, privadas, link-local) como destino
+func (s *Store) SetAllowedNetworks(networks []netip.Prefix) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allowedNetworks = networks
+}
+
+// Start carrega os webhooks e acompanha alterações feitas por outras instâncias
+func (s *Store) Start(ctx context.Context) error {
+	watcher, err := s.kv.Watch(keyPrefix + ">")
+	if err != nil {
+		return fmt.Errorf("failed to watch webhooks: %w", err)
+	}
+
+	initial := make(chan struct{})
+	go func() {
+		defer watcher.Stop()
+		loaded := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case entry, ok := <-watcher.Updates():
+				if !ok {
+					return
+				}
+				if entry == nil {
+					if !loaded {
+						loaded = true
+						close(initial)
+					}
+					continue
+				}
+				s.apply(entry)
+			}
+		}
+	}()
+
+	select {
+	case <-initial:
+		return nil
+	case <-time.After(10 * time.Second):
+		return fmt.Errorf("timeout loading webhooks")
+	}
+}
+
+func (s *Store) apply(entry nats.KeyValueEntry) {
+	id := strings.TrimPrefix(entry.Key(), keyPrefix)
+
+	if entry.Operation() != nats.KeyValuePut {
+		s.set(id, nil)
+		return
+	}
+
+	var webhook models.Webhook
+	if err := json.Unmarshal(entry.Value(), &webhook); err != nil {
+		log.Printf("Warning: invalid webhook %s: %v", id, err)
+		return
+	}
+	s.set(id, &webhook)
+}
+
+// set atualiza a cópia em memória (nil remove) e notifica o dispatcher
+func (s *Store) set(id string, webhook *models.Webhook) {
+	s.mu.Lock()
+	if webhook == nil {
+		delete(s.webhooks, id)
+	} else {
+		s.webhooks[id] = *webhook
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.changes <- struct{}{}:
+	default:
+	}
+}
+
+// Changes sinaliza quando webhooks são criados, alterados ou removidos
+func (s *Store) Changes() <-chan struct{} {
+	return s.changes
+}
+
+// Create valida e persiste um novo webhook (habilitado por padrão). Sem secret no pedido,
+// um é gerado; o chamador o recebe no webhook retornado e ele não é exposto depois.
+func (s *Store) Create(request *models.WebhookRequest) (*models.Webhook, error) {
+	now := time.Now()
+	webhook := &models.Webhook{Enabled: true, CreatedAt: now, UpdatedAt: now}
+	if err := s.merge(webhook, request); err != nil {
+		return nil, err
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate id: %w", err)
+	}
+	webhook.ID = id
+
+	if webhook.Secret == "" {
+		if webhook.Secret, err = randomHex(secretBytes); err != nil {
+			return nil, fmt.Errorf("failed to generate secret: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook: %w", err)
+	}
+	if _, err := s.kv.Create(keyPrefix+webhook.ID, data); err != nil {
+		return nil, fmt.Errorf("failed to save webhook: %w", err)
+	}
+
+	s.set(webhook.ID, webhook)
+	return webhook, nil
+}
+
+// Update altera um webhook; campos omitidos são mantidos. Reabilitar zera o contador de falhas.
+func (s *Store) Update(id string, request *models.WebhookRequest) (*models.Webhook, error) {
+	return s.modify(id, func(webhook *models.Webhook) error {
+		wasEnabled := webhook.Enabled
+		if err := s.merge(webhook, request); err != nil {
+			return err
+		}
+		if webhook.Enabled && !wasEnabled {
+			webhook.ConsecutiveFailures = 0
+			webhook.DisabledReason = ""
+		}
+		return nil
+	})
+}
+
+// Get retorna um webhook
+func (s *Store) Get(id string) (*models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhook, ok := s.webhooks[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return &webhook, nil
+}
+
+// Delete remove um webhook
+func (s *Store) Delete(id string) error {
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+
+	if err := s.kv.Delete(keyPrefix + id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	s.set(id, nil)
+	return nil
+}
+
+// List lista os webhooks em ordem de criação
+func (s *Store) List() []models.Webhook {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []models.Webhook{}
+	for _, webhook := range s.webhooks {
+		result = append(result, webhook)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// recordSuccess zera o contador de falhas consecutivas
+func (s *Store) recordSuccess(id string) error {
+	if webhook, err := s.Get(id); err != nil || webhook.ConsecutiveFailures == 0 {
+		return err
+	}
+
+	_, err := s.modify(id, func(webhook *models.Webhook) error {
+		webhook.ConsecutiveFailures = 0
+		return nil
+	})
+	return err
+}
+
+// recordFailure incrementa o contador de falhas consecutivas e desabilita o webhook
+// ao atingir disableAfter (0 = nunca); retorna o webhook atualizado
+func (s *Store) recordFailure(id, reason string, disableAfter int) (*models.Webhook, error) {
+	return s.modify(id, func(webhook *models.Webhook) error {
+		webhook.ConsecutiveFailures++
+		if disableAfter > 0 && webhook.ConsecutiveFailures >= disableAfter && webhook.Enabled {
+			webhook.Enabled = false
+			webhook.DisabledReason = fmt.Sprintf("disabled after %d consecutive failed deliveries: %s", webhook.ConsecutiveFailures, reason)
+		}
+		return nil
+	})
+}
+
+// modify aplica fn sobre a versão persistida do webhook, com controle de revisão no KV
+// para não perder alterações concorrentes (API e dispatcher)
+func (s *Store) modify(id string, fn func(*models.Webhook) error) (*models.Webhook, error) {
+	for attempt := 0; attempt < maxUpdateRetries; attempt++ {
+		entry, err := s.kv.Get(keyPrefix + id)
+		if errors.Is(err, nats.ErrKeyNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get webhook: %w", err)
+		}
+
+		var webhook models.Webhook
+		if err := json.Unmarshal(entry.Value(), &webhook); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webhook: %w", err)
+		}
+		if err := fn(&webhook); err != nil {
+			return nil, err
+		}
+		webhook.UpdatedAt = time.Now()
+
+		data, err := json.Marshal(&webhook)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal webhook: %w", err)
+		}
+		// Revisão alterada por outra escrita: relê e reaplica
+		if _, err := s.kv.Update(keyPrefix+id, data, entry.Revision()); err != nil {
+			if errors.Is(err, nats.ErrKeyExists) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to save webhook: %w", err)
+		}
+
+		s.set(id, &webhook)
+		return &webhook, nil
+	}
+	return nil, fmt.Errorf("failed to save webhook %s: concurrent updates", id)
+}
+
+// randomHex n bytes aleatórios em hexadecimal
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// merge aplica e valida os campos informados no pedido
+func (s *Store) merge(webhook *models.Webhook, request *models.WebhookRequest) error {
+	if request.URL != "" {
+		webhook.URL = request.URL
+	}
+	if request.Subjects != "" {
+		webhook.Subjects = request.Subjects
+	}
+	if webhook.Subjects == "" {
+		webhook.Subjects = "*"
+	}
+	if request.EventTypes != nil {
+		webhook.EventTypes = request.EventTypes
+	}
+	if request.Secret != "" {
+		webhook.Secret = request.Secret
+	}
+	if request.Enabled != nil {
+		webhook.Enabled = *request.Enabled
+	}
+
+	parsed, err := url.Parse(webhook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalid)
+	}
+	if err := s.checkHost(parsed.Hostname()); err != nil {
+		return err
+	}
+	for _, eventType := range webhook.EventTypes {
+		if !eventTypes[eventType] {
+			return fmt.Errorf("%w: unknown event type %s", ErrInvalid, eventType)
+		}
+	}
+	return nil
+}